@@ -0,0 +1,418 @@
+package httpx
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Credentials holds a parsed Authorization header: its scheme and either a
+// token68, as Basic uses, or auth-params, as Digest does. Param names are
+// lowercased and quoted values unquoted.
+type Credentials struct {
+	Scheme  string
+	Token68 string
+	Params  map[string]string
+}
+
+// ErrMalformedAuthorization is returned for an Authorization header that
+// does not follow the RFC 9110 credentials syntax.
+var ErrMalformedAuthorization = errors.New("httpx: malformed Authorization header")
+
+// ParseAuthorization parses an Authorization or Proxy-Authorization
+// header value.
+//
+//	c, _ := ParseAuthorization(`Digest username="Mufasa", realm="http-auth@example.org", nc=00000001`)
+//	c.Scheme             // "Digest"
+//	c.Params["username"] // "Mufasa"
+func ParseAuthorization(header string) (*Credentials, error) {
+	header = strings.TrimSpace(header)
+	scheme, rest, _ := strings.Cut(header, " ")
+	if !isToken(scheme) {
+		return nil, ErrMalformedAuthorization
+	}
+	c := &Credentials{Scheme: scheme}
+	rest = strings.TrimSpace(rest)
+	if rest == "" {
+		return c, nil
+	}
+	if isToken68(rest) {
+		c.Token68 = rest
+		return c, nil
+	}
+
+	c.Params = make(map[string]string)
+	for _, param := range SplitHeaderList(rest) {
+		name, value, ok := strings.Cut(param, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = strings.TrimSpace(value)
+		if !ok || !isToken(name) || value == "" {
+			return nil, ErrMalformedAuthorization
+		}
+		if value[0] == '"' {
+			if len(value) < 2 || value[len(value)-1] != '"' {
+				return nil, ErrMalformedAuthorization
+			}
+			value = Unquote(value)
+		} else if !isToken(value) {
+			return nil, ErrMalformedAuthorization
+		}
+		if _, dup := c.Params[name]; dup {
+			return nil, ErrMalformedAuthorization
+		}
+		c.Params[name] = value
+	}
+	return c, nil
+}
+
+// isToken68 reports whether s is an RFC 9110 token68.
+func isToken68(s string) bool {
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("-._~+/", c) >= 0) {
+			break
+		}
+		i++
+	}
+	if i == 0 {
+		return false
+	}
+	for ; i < len(s); i++ {
+		if s[i] != '=' {
+			return false
+		}
+	}
+	return true
+}
+
+// quotedString returns s as a quoted-string, even if it is a token, for
+// the auth-params that must be quoted.
+func quotedString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+type authUserKey struct{}
+
+// AuthUser returns the username BasicAuth or DigestAuth authenticated the
+// request with, or "".
+func AuthUser(r *http.Request) string {
+	user, _ := r.Context().Value(authUserKey{}).(string)
+	return user
+}
+
+func withAuthUser(r *http.Request, user string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), authUserKey{}, user))
+}
+
+// BasicAuth is a port of Rack::Auth::Basic: HTTP Basic authentication
+// (RFC 7617). Credentials are decoded as UTF-8, which the challenge
+// announces with charset="UTF-8".
+//
+//	auth := &BasicAuth{
+//		Realm: "Admin",
+//		Authenticate: func(user, password string) bool {
+//			return SecureCompare(user, "admin") && SecureCompare(password, os.Getenv("ADMIN_PASSWORD"))
+//		},
+//	}
+//	http.ListenAndServe(":8080", auth.Handler(adminApp))
+type BasicAuth struct {
+	Realm string
+
+	// Authenticate reports whether the credentials are valid. It should
+	// compare secrets with SecureCompare.
+	Authenticate func(user, password string) bool
+
+	// Unauthorized writes the response to a request without valid
+	// credentials, once the challenge has been set. It defaults to a
+	// plain 401 Unauthorized.
+	Unauthorized http.Handler
+}
+
+// Handler returns next wrapped with Basic authentication.
+func (b *BasicAuth) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := ParseAuthorization(r.Header.Get("Authorization"))
+		if err != nil && r.Header.Get("Authorization") != "" {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		if c != nil && strings.EqualFold(c.Scheme, "Basic") {
+			if user, password, ok := decodeBasic(c.Token68); ok && b.Authenticate(user, password) {
+				next.ServeHTTP(w, withAuthUser(r, user))
+				return
+			}
+		}
+		w.Header().Set("WWW-Authenticate", "Basic realm="+quotedString(b.Realm)+`, charset="UTF-8"`)
+		unauthorized(w, r, b.Unauthorized)
+	})
+}
+
+// ParseBasicAuth returns the user and password of a Basic Authorization
+// header.
+func ParseBasicAuth(header string) (user, password string, ok bool) {
+	c, err := ParseAuthorization(header)
+	if err != nil || !strings.EqualFold(c.Scheme, "Basic") {
+		return "", "", false
+	}
+	return decodeBasic(c.Token68)
+}
+
+func decodeBasic(token string) (user, password string, ok bool) {
+	b, err := base64.StdEncoding.DecodeString(token)
+	if err != nil || !utf8.Valid(b) {
+		return "", "", false
+	}
+	return strings.Cut(string(b), ":")
+}
+
+func unauthorized(w http.ResponseWriter, r *http.Request, h http.Handler) {
+	if h != nil {
+		h.ServeHTTP(w, r)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
+
+// DigestAuth is a port of Rack::Auth::Digest: HTTP Digest authentication
+// (RFC 7616) with qop="auth". One challenge is sent per algorithm, strongest
+// first, and the client picks the one it supports.
+//
+// Nonces are stateless: each carries its creation time and an HMAC of it
+// under Secret, and expires after NonceTimeout, when the client is told to
+// retry with a fresh one (stale=true) without prompting the user again.
+//
+//	auth := &DigestAuth{
+//		Realm:    "admin@example.com",
+//		Secret:   []byte(os.Getenv("DIGEST_SECRET")),
+//		Password: func(user string) (string, bool) { p, ok := passwords[user]; return p, ok },
+//	}
+type DigestAuth struct {
+	Realm string
+
+	// Password returns the user's password, or false if there is no
+	// such user.
+	Password func(user string) (string, bool)
+
+	// Secret signs the nonces. If empty, a random secret is generated,
+	// which does not survive restarts and is not shared between
+	// processes.
+	Secret []byte
+
+	// NonceTimeout is how long a nonce stays valid. It defaults to five
+	// minutes.
+	NonceTimeout time.Duration
+
+	// Algorithms lists the algorithms offered, in order of preference.
+	// It defaults to SHA-256 then MD5, for older clients.
+	Algorithms []string
+
+	// Unauthorized writes the response to a request without valid
+	// credentials, once the challenges have been set. It defaults to a
+	// plain 401 Unauthorized.
+	Unauthorized http.Handler
+
+	// Now returns the current time. It defaults to time.Now.
+	Now func() time.Time
+
+	once   sync.Once
+	secret []byte
+}
+
+// Handler returns next wrapped with Digest authentication.
+func (d *DigestAuth) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var stale bool
+		if c, err := ParseAuthorization(r.Header.Get("Authorization")); err == nil && strings.EqualFold(c.Scheme, "Digest") {
+			var user string
+			var ok bool
+			user, ok, stale = d.verify(r, c.Params)
+			if ok {
+				next.ServeHTTP(w, withAuthUser(r, user))
+				return
+			}
+		}
+		nonce := d.nonce(d.now())
+		for _, alg := range d.algorithms() {
+			challenge := "Digest realm=" + quotedString(d.Realm) +
+				`, qop="auth", algorithm=` + alg +
+				", nonce=" + quotedString(nonce) +
+				", opaque=" + quotedString(d.opaque()) +
+				", charset=UTF-8"
+			if stale {
+				challenge += ", stale=true"
+			}
+			w.Header().Add("WWW-Authenticate", challenge)
+		}
+		unauthorized(w, r, d.Unauthorized)
+	})
+}
+
+// verify checks the Digest credentials in params against the request. It
+// returns the user, whether the credentials are valid and, if they are
+// valid but for an expired nonce, stale.
+func (d *DigestAuth) verify(r *http.Request, params map[string]string) (user string, ok, stale bool) {
+	user = params["username"]
+	if ext, found := params["username*"]; found {
+		if user != "" {
+			return "", false, false
+		}
+		var err error
+		if user, err = decodeExtValue(ext); err != nil {
+			return "", false, false
+		}
+	}
+
+	alg := params["algorithm"]
+	if alg == "" {
+		alg = "MD5"
+	}
+	h := digestHash(alg)
+	if h == nil || !d.offers(alg) {
+		return "", false, false
+	}
+	if params["realm"] != d.Realm || params["qop"] != "auth" || params["opaque"] != d.opaque() ||
+		params["uri"] != r.URL.RequestURI() || params["nc"] == "" || params["cnonce"] == "" {
+		return "", false, false
+	}
+	created, valid := d.checkNonce(params["nonce"])
+	if !valid {
+		return "", false, false
+	}
+
+	password, found := d.Password(user)
+	if !found {
+		return "", false, false
+	}
+	ha1 := digestHex(h, user+":"+d.Realm+":"+password)
+	ha2 := digestHex(h, r.Method+":"+params["uri"])
+	expected := digestHex(h, ha1+":"+params["nonce"]+":"+params["nc"]+":"+params["cnonce"]+":auth:"+ha2)
+	if !SecureCompare(expected, strings.ToLower(params["response"])) {
+		return "", false, false
+	}
+	if d.now().Sub(created) > d.nonceTimeout() {
+		return user, false, true
+	}
+	return user, true, false
+}
+
+// nonce returns a nonce for now: the time followed by its HMAC, base64
+// encoded.
+func (d *DigestAuth) nonce(now time.Time) string {
+	b := make([]byte, 8, 8+sha256.Size)
+	binary.BigEndian.PutUint64(b, uint64(now.UnixNano()))
+	mac := hmac.New(sha256.New, d.key())
+	mac.Write(b)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(b))
+}
+
+// checkNonce verifies the nonce's HMAC and returns its creation time.
+func (d *DigestAuth) checkNonce(nonce string) (time.Time, bool) {
+	b, err := base64.RawURLEncoding.DecodeString(nonce)
+	if err != nil || len(b) != 8+sha256.Size {
+		return time.Time{}, false
+	}
+	mac := hmac.New(sha256.New, d.key())
+	mac.Write(b[:8])
+	if !hmac.Equal(mac.Sum(nil), b[8:]) {
+		return time.Time{}, false
+	}
+	return time.Unix(0, int64(binary.BigEndian.Uint64(b[:8]))), true
+}
+
+// opaque is derived from the secret, so that it is the same across
+// requests without being stored.
+func (d *DigestAuth) opaque() string {
+	mac := hmac.New(sha256.New, d.key())
+	mac.Write([]byte("opaque"))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+func (d *DigestAuth) key() []byte {
+	if len(d.Secret) > 0 {
+		return d.Secret
+	}
+	d.once.Do(func() {
+		d.secret = make([]byte, 32)
+		if _, err := rand.Read(d.secret); err != nil {
+			panic("httpx: generating digest secret: " + err.Error())
+		}
+	})
+	return d.secret
+}
+
+func (d *DigestAuth) algorithms() []string {
+	if len(d.Algorithms) == 0 {
+		return []string{"SHA-256", "MD5"}
+	}
+	return d.Algorithms
+}
+
+func (d *DigestAuth) offers(alg string) bool {
+	for _, a := range d.algorithms() {
+		if strings.EqualFold(a, alg) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *DigestAuth) nonceTimeout() time.Duration {
+	if d.NonceTimeout == 0 {
+		return 5 * time.Minute
+	}
+	return d.NonceTimeout
+}
+
+func (d *DigestAuth) now() time.Time {
+	if d.Now == nil {
+		return time.Now()
+	}
+	return d.Now()
+}
+
+// digestHash returns the hash constructor for a Digest algorithm, or nil
+// if it is not supported. The -sess variants are not.
+func digestHash(alg string) func() hash.Hash {
+	switch strings.ToUpper(alg) {
+	case "SHA-256":
+		return sha256.New
+	case "MD5":
+		return md5.New
+	}
+	return nil
+}
+
+func digestHex(h func() hash.Hash, s string) string {
+	sum := h()
+	sum.Write([]byte(s))
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+// decodeExtValue decodes an RFC 8187 ext-value: a charset, an optional
+// language and the percent-encoded value, separated by single quotes.
+// Only UTF-8 is supported.
+func decodeExtValue(s string) (string, error) {
+	parts := strings.SplitN(s, "'", 3)
+	if len(parts) != 3 || !strings.EqualFold(parts[0], "UTF-8") {
+		return "", ErrMalformedAuthorization
+	}
+	v, err := url.PathUnescape(parts[2])
+	if err != nil || !utf8.ValidString(v) {
+		return "", ErrMalformedAuthorization
+	}
+	return v, nil
+}
@@ -0,0 +1,178 @@
+package httpx
+
+import (
+	"errors"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// A BodyLimitRule sets the body limit for requests matching Method and
+// Path. An empty Method matches any method; a Path ending in "/" matches
+// every path below it.
+type BodyLimitRule struct {
+	Method string
+	Path   string
+	Limit  int64
+}
+
+// BodyLimit limits the size and type of request bodies. Requests with a
+// Content-Length over the limit are rejected with 413 Content Too Large
+// before their body is read; other bodies are cut off at the limit with
+// http.MaxBytesReader. Bodies whose media type is not in ContentTypes
+// are rejected with 415 Unsupported Media Type.
+//
+// Bodies are left unread, so handlers may stream them. Handlers that use
+// forms call ParseForm, which answers malformed input with 400 Bad Request
+// and oversized input with 413 rather than surfacing errors deep inside
+// them; ParseForms does this for every request up front instead.
+// Rejections are written as problem details.
+//
+//	limit := &BodyLimit{
+//		Limit:        1 << 20,
+//		Rules:        []BodyLimitRule{{Method: "POST", Path: "/uploads/", Limit: 100 << 20}},
+//		ContentTypes: []string{"application/json", "multipart/form-data"},
+//	}
+//	http.ListenAndServe(":8080", limit.Handler(app))
+type BodyLimit struct {
+	// Limit is the default limit in bytes. It defaults to 1 MiB; a
+	// negative limit disables it.
+	Limit int64
+
+	// Rules override Limit for some routes. The first matching rule
+	// applies.
+	Rules []BodyLimitRule
+
+	// ContentTypes lists the media types accepted for request bodies,
+	// such as "application/json", or "text/*" for a whole type. Empty
+	// accepts any.
+	ContentTypes []string
+
+	// ParseForms parses the query string and form bodies before the next
+	// handler is called, as ParseForm does. Leave it off for handlers that
+	// read multipart bodies with r.MultipartReader, which fails once the
+	// form has been parsed.
+	ParseForms bool
+
+	// MaxMemory is passed to ParseForm when ParseForms is set.
+	MaxMemory int64
+}
+
+// Handler returns next wrapped with body limits.
+func (b *BodyLimit) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit := b.limitFor(r)
+		if limit >= 0 && r.ContentLength > limit {
+			tooLarge(limit).ServeHTTP(w, r)
+			return
+		}
+
+		var mediaType string
+		if hasBody(r) {
+			if ct := r.Header.Get("Content-Type"); ct != "" {
+				mediaType, _, _ = mime.ParseMediaType(ct)
+			}
+			if !b.accepts(mediaType) {
+				p := NewProblem(http.StatusUnsupportedMediaType, "Content-Type "+strconv.Quote(mediaType)+" is not supported.")
+				p.Extensions = map[string]interface{}{"accepted": b.ContentTypes}
+				p.ServeHTTP(w, r)
+				return
+			}
+			if limit >= 0 {
+				r.Body = http.MaxBytesReader(w, r.Body, limit)
+			}
+		}
+
+		if b.ParseForms && !ParseForm(w, r, b.MaxMemory) {
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (b *BodyLimit) limitFor(r *http.Request) int64 {
+	for _, rule := range b.Rules {
+		if rule.Method != "" && rule.Method != r.Method {
+			continue
+		}
+		if rule.Path == r.URL.Path || strings.HasSuffix(rule.Path, "/") && strings.HasPrefix(r.URL.Path, rule.Path) {
+			return rule.Limit
+		}
+	}
+	if b.Limit == 0 {
+		return 1 << 20
+	}
+	return b.Limit
+}
+
+func (b *BodyLimit) accepts(mediaType string) bool {
+	if len(b.ContentTypes) == 0 {
+		return true
+	}
+	for _, ct := range b.ContentTypes {
+		ct = strings.ToLower(ct)
+		if ct == mediaType || strings.HasSuffix(ct, "/*") && strings.HasPrefix(mediaType, ct[:len(ct)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasBody(r *http.Request) bool {
+	return r.Body != nil && r.Body != http.NoBody && r.ContentLength != 0
+}
+
+// ParseForm parses the query string and the form body of r, with
+// ParseMultipartForm for multipart bodies, storing up to maxMemory bytes of
+// files in memory; zero means 32 MiB. If parsing fails it answers with
+// ParseErrorProblem and returns false.
+//
+//	if !httpx.ParseForm(w, r, 0) {
+//		return
+//	}
+//	name := r.FormValue("name")
+func ParseForm(w http.ResponseWriter, r *http.Request, maxMemory int64) bool {
+	if maxMemory == 0 {
+		maxMemory = 32 << 20
+	}
+	var err error
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		err = r.ParseMultipartForm(maxMemory)
+	} else {
+		err = r.ParseForm()
+	}
+	if err != nil {
+		ParseErrorProblem(err).ServeHTTP(w, r)
+		return false
+	}
+	return true
+}
+
+// ParseErrorProblem maps an error from parsing a request to problem
+// details: 413 Content Too Large if the body exceeded its limit, 400 Bad
+// Request otherwise. Handlers decoding bodies themselves, say with
+// encoding/json, can use it to answer the same way BodyLimit does.
+//
+//	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+//		ParseErrorProblem(err).ServeHTTP(w, r)
+//		return
+//	}
+func ParseErrorProblem(err error) *Problem {
+	var maxBytes *http.MaxBytesError
+	if errors.As(err, &maxBytes) {
+		return tooLarge(maxBytes.Limit)
+	}
+	if errors.Is(err, multipart.ErrMessageTooLarge) {
+		return NewProblem(http.StatusRequestEntityTooLarge, "The multipart form is too large.")
+	}
+	return NewProblem(http.StatusBadRequest, "The request could not be parsed: "+err.Error())
+}
+
+func tooLarge(limit int64) *Problem {
+	p := NewProblem(http.StatusRequestEntityTooLarge, "The request body exceeds the limit of "+strconv.FormatInt(limit, 10)+" bytes.")
+	p.Extensions = map[string]interface{}{"limit": limit}
+	return p
+}
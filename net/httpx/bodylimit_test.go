@@ -0,0 +1,179 @@
+package httpx
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func doBodyRequest(h http.Handler, method, target, contentType, body string, chunked bool) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	if chunked {
+		r.ContentLength = -1
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) *Problem {
+	t.Helper()
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("expected a problem but got Content-Type '%s'", ct)
+	}
+	var p Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	return &p
+}
+
+func Test_BodyLimit(t *testing.T) {
+	limit := &BodyLimit{
+		Limit: 10,
+		Rules: []BodyLimitRule{{Method: "POST", Path: "/uploads/", Limit: 1000}},
+	}
+	h := limit.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			ParseErrorProblem(err).ServeHTTP(w, r)
+			return
+		}
+		w.Write(b)
+	}))
+
+	if w := doBodyRequest(h, "POST", "/", "text/plain", "short", false); w.Code != http.StatusOK || w.Body.String() != "short" {
+		t.Errorf("expected short body to pass but got %d", w.Code)
+	}
+	w := doBodyRequest(h, "POST", "/", "text/plain", "far too long a body", false)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413 but got %d", w.Code)
+	}
+	if p := decodeProblem(t, w); p.Status != 413 || p.Extensions["limit"] != float64(10) {
+		t.Errorf("unexpected problem %+v", p)
+	}
+	if w := doBodyRequest(h, "POST", "/", "text/plain", "far too long a body", true); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected chunked body over the limit to give 413 but got %d", w.Code)
+	}
+	if w := doBodyRequest(h, "POST", "/uploads/a", "text/plain", "far too long a body", false); w.Code != http.StatusOK {
+		t.Errorf("expected the route limit to apply but got %d", w.Code)
+	}
+}
+
+func Test_BodyLimitContentTypes(t *testing.T) {
+	limit := &BodyLimit{ContentTypes: []string{"application/json", "text/*"}}
+	h := limit.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for _, ct := range []string{"application/json; charset=utf-8", "text/csv"} {
+		if w := doBodyRequest(h, "POST", "/", ct, "{}", false); w.Code != http.StatusOK {
+			t.Errorf("expected %s to be accepted but got %d", ct, w.Code)
+		}
+	}
+	for _, ct := range []string{"application/xml", ""} {
+		w := doBodyRequest(h, "POST", "/", ct, "{}", false)
+		if w.Code != http.StatusUnsupportedMediaType {
+			t.Errorf("expected %q to give 415 but got %d", ct, w.Code)
+		}
+		decodeProblem(t, w)
+	}
+	if w := doBodyRequest(h, "GET", "/", "", "", false); w.Code != http.StatusOK {
+		t.Errorf("expected a request without a body to pass but got %d", w.Code)
+	}
+}
+
+func Test_BodyLimitParseErrors(t *testing.T) {
+	limit := &BodyLimit{Limit: 100, ParseForms: true}
+	var form string
+	h := limit.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		form = r.FormValue("a")
+	}))
+
+	if w := doBodyRequest(h, "POST", "/", "application/x-www-form-urlencoded", "a=1", false); w.Code != http.StatusOK || form != "1" {
+		t.Errorf("expected the form to be parsed but got %d '%s'", w.Code, form)
+	}
+	if w := doBodyRequest(h, "POST", "/", "application/x-www-form-urlencoded", "a=%zz", false); w.Code != http.StatusBadRequest {
+		t.Errorf("expected a malformed form to give 400 but got %d", w.Code)
+	}
+	if w := doBodyRequest(h, "GET", "/?a=%zz", "", "", false); w.Code != http.StatusBadRequest {
+		t.Errorf("expected a malformed query to give 400 but got %d", w.Code)
+	}
+	if w := doBodyRequest(h, "POST", "/", "multipart/form-data", "--x--", false); w.Code != http.StatusBadRequest {
+		t.Errorf("expected multipart without a boundary to give 400 but got %d", w.Code)
+	}
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	mw.WriteField("a", strings.Repeat("x", 200))
+	mw.Close()
+	if w := doBodyRequest(h, "POST", "/", mw.FormDataContentType(), buf.String(), true); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected an oversized multipart form to give 413 but got %d", w.Code)
+	}
+}
+
+func Test_BodyLimitStreaming(t *testing.T) {
+	limit := &BodyLimit{}
+	var parts []string
+	h := limit.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mr, err := r.MultipartReader()
+		if err != nil {
+			ParseErrorProblem(err).ServeHTTP(w, r)
+			return
+		}
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				ParseErrorProblem(err).ServeHTTP(w, r)
+				return
+			}
+			b, _ := io.ReadAll(part)
+			parts = append(parts, part.FormName()+"="+string(b))
+		}
+	}))
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	mw.WriteField("a", "1")
+	mw.WriteField("b", "2")
+	mw.Close()
+	w := doBodyRequest(h, "POST", "/", mw.FormDataContentType(), buf.String(), false)
+	if w.Code != http.StatusOK || strings.Join(parts, "&") != "a=1&b=2" {
+		t.Errorf("expected the handler to stream the parts but got %d %q", w.Code, parts)
+	}
+}
+
+func Test_ParseForm(t *testing.T) {
+	limit := &BodyLimit{Limit: 100}
+	var form string
+	h := limit.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !ParseForm(w, r, 0) {
+			return
+		}
+		form = r.FormValue("a")
+	}))
+
+	if w := doBodyRequest(h, "POST", "/", "application/x-www-form-urlencoded", "a=1", false); w.Code != http.StatusOK || form != "1" {
+		t.Errorf("expected the form to be parsed but got %d '%s'", w.Code, form)
+	}
+	if w := doBodyRequest(h, "POST", "/", "application/x-www-form-urlencoded", "a=%zz", false); w.Code != http.StatusBadRequest {
+		t.Errorf("expected a malformed form to give 400 but got %d", w.Code)
+	}
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	mw.WriteField("a", strings.Repeat("x", 200))
+	mw.Close()
+	if w := doBodyRequest(h, "POST", "/", mw.FormDataContentType(), buf.String(), true); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected an oversized multipart form to give 413 but got %d", w.Code)
+	}
+}
@@ -0,0 +1,48 @@
+package httpx
+
+import (
+	"net/http"
+)
+
+// A Middleware wraps a handler. The Handler methods of the middleware
+// types in this package, such as (*CORS).Handler, are Middlewares.
+type Middleware func(next http.Handler) http.Handler
+
+// Builder composes middleware around a handler, like Rack::Builder.
+// Middleware added first is outermost and sees the request first.
+//
+//	app := NewBuilder(
+//		(&HostAuthorization{AllowedHosts: []string{".example.com"}}).Handler,
+//		(&CORS{AllowedOrigins: []string{"https://example.com"}}).Handler,
+//	).Use(Protection()).Run(mux)
+type Builder struct {
+	middleware []Middleware
+}
+
+// NewBuilder returns a Builder using the given middleware.
+func NewBuilder(middleware ...Middleware) *Builder {
+	return &Builder{middleware: middleware}
+}
+
+// Use appends middleware to the stack.
+func (b *Builder) Use(middleware ...Middleware) *Builder {
+	b.middleware = append(b.middleware, middleware...)
+	return b
+}
+
+// Run returns app wrapped in the middleware stack.
+func (b *Builder) Run(app http.Handler) http.Handler {
+	for i := len(b.middleware) - 1; i >= 0; i-- {
+		app = b.middleware[i](app)
+	}
+	return app
+}
+
+// Middleware returns the whole stack as a single Middleware, so that
+// builders can be nested.
+func (b *Builder) Middleware() Middleware {
+	middleware := append([]Middleware(nil), b.middleware...)
+	return func(next http.Handler) http.Handler {
+		return NewBuilder(middleware...).Run(next)
+	}
+}
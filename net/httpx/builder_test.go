@@ -0,0 +1,40 @@
+package httpx
+
+import (
+	"net/http"
+	"testing"
+)
+
+func Test_Builder(t *testing.T) {
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	inner := NewBuilder(trace("b"), trace("c"))
+	app := NewBuilder(trace("a")).Use(inner.Middleware()).Run(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		order = append(order, "app")
+	}))
+	doRequest(app, "GET", "/", nil)
+
+	if !equalStrings(order, []string{"a", "b", "c", "app"}) {
+		t.Errorf("expected middleware to run outermost first but got %v", order)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
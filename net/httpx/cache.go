@@ -0,0 +1,798 @@
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache-Status values written by Cache (RFC 9211).
+const (
+	cacheStatusHit         = "httpx; hit"
+	cacheStatusStale       = "httpx; hit; fwd=stale"
+	cacheStatusMiss        = "httpx; fwd=miss"
+	cacheStatusRevalidated = "httpx; fwd=stale; fwd-status=304"
+	cacheStatusBypass      = "httpx; fwd=bypass"
+)
+
+// clientConditionals are the request headers that make a response depend
+// on what the client already has. Cache removes them from the requests it
+// forwards and answers them itself.
+var clientConditionals = []string{"If-None-Match", "If-Modified-Since", "Range", "If-Range"}
+
+// Cache is a shared HTTP cache middleware in the spirit of Rack::Cache,
+// following the storage, freshness and validation rules of RFC 9111.
+// Responses to GET requests are stored in Store, keyed by Key and selected
+// among their variants by the response's Vary header. Stale responses are
+// revalidated with If-None-Match and If-Modified-Since, or served while
+// revalidating in the background when stale-while-revalidate allows it.
+//
+// Responses are passed on to the client as they are written, flushes
+// included, so streaming handlers work behind the cache; a copy is kept
+// only of responses that may be stored.
+//
+//	cache := NewCache(NewMemoryStore(1000))
+//	http.ListenAndServe(":8080", cache.Handler(app))
+type Cache struct {
+	Store CacheStore
+
+	// Key returns the cache key for a request. It defaults to the
+	// request's host and URI.
+	Key func(r *http.Request) string
+
+	// Now returns the current time. It defaults to time.Now and may be
+	// replaced to test freshness.
+	Now func() time.Time
+
+	// ErrorLog receives store errors, which are otherwise treated as
+	// misses. If nil, errors are logged through the log package.
+	ErrorLog *log.Logger
+
+	// MaxEntrySize is the largest response body stored, in bytes. Larger
+	// responses are passed on without being stored. It defaults to 1 MiB.
+	MaxEntrySize int64
+
+	mu           sync.Mutex
+	revalidating map[string]bool
+	background   sync.WaitGroup
+}
+
+// NewCache returns a Cache using store.
+func NewCache(store CacheStore) *Cache {
+	return &Cache{Store: store}
+}
+
+// Handler returns next wrapped with the cache.
+func (c *Cache) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.serve(w, r, next)
+	})
+}
+
+func (c *Cache) serve(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	key := c.key(r)
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		if !isSafeMethod(r.Method) && rec.status < 400 {
+			c.delete(key)
+		}
+		return
+	}
+
+	reqCC, err := ParseCacheControl(strings.Join(r.Header.Values("Cache-Control"), ","))
+	if err != nil {
+		reqCC = NewCacheControl()
+	}
+	if reqCC.NoStore {
+		w.Header().Set("Cache-Status", cacheStatusBypass)
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	entries := c.get(key)
+	idx := selectVariant(entries, r)
+	if idx == -1 {
+		if reqCC.OnlyIfCached {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		c.fetch(w, r, next, key, entries)
+		return
+	}
+
+	entry := entries[idx]
+	now := c.now()
+	respCC := entryCacheControl(entry)
+	age := currentAge(entry, now)
+	lifetime := freshnessLifetime(entry, respCC)
+
+	if reqCC.MaxAge != Unset && age > time.Duration(reqCC.MaxAge)*time.Second {
+		lifetime = 0
+	}
+	if reqCC.MinFresh != Unset {
+		lifetime -= time.Duration(reqCC.MinFresh) * time.Second
+	}
+	fresh := age < lifetime
+	if !fresh && !respCC.MustRevalidate && !respCC.NoCache {
+		if reqCC.MaxStaleAny {
+			fresh = true
+		} else if reqCC.MaxStale != Unset && age < lifetime+time.Duration(reqCC.MaxStale)*time.Second {
+			fresh = true
+		}
+	}
+	if respCC.NoCache || reqCC.NoCache {
+		fresh = false
+	}
+
+	switch {
+	case fresh:
+		c.writeEntry(w, r, entry, age, cacheStatusHit)
+	case reqCC.OnlyIfCached:
+		w.WriteHeader(http.StatusGatewayTimeout)
+	case !respCC.MustRevalidate && !respCC.NoCache && !reqCC.NoCache &&
+		respCC.StaleWhileRevalidate != Unset &&
+		age < lifetime+time.Duration(respCC.StaleWhileRevalidate)*time.Second:
+		c.writeEntry(w, r, entry, age, cacheStatusStale)
+		c.revalidateInBackground(r, next, key)
+	default:
+		c.revalidate(w, r, next, key, entries, idx)
+	}
+}
+
+// fetch forwards r to next and stores the response if it is storable. The
+// client's conditional and range headers are withheld from next, so that
+// the full response is fetched, and answered from it.
+func (c *Cache) fetch(w http.ResponseWriter, r *http.Request, next http.Handler, key string, entries []*CacheEntry) {
+	if r.Method == http.MethodHead {
+		w.Header().Set("Cache-Status", cacheStatusMiss)
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	req := r.Clone(r.Context())
+	for _, name := range clientConditionals {
+		req.Header.Del(name)
+	}
+	cw := &cacheWriter{cache: c, w: w, r: r, req: req, header: make(http.Header), requestTime: c.now()}
+	next.ServeHTTP(cw, req)
+	cw.finish(key, entries)
+}
+
+// revalidate sends a conditional request for the stored entry and serves
+// either the updated entry or the new response. A stored response may
+// stand in for a server error while stale-if-error allows it.
+func (c *Cache) revalidate(w http.ResponseWriter, r *http.Request, next http.Handler, key string, entries []*CacheEntry, idx int) {
+	entry := entries[idx]
+	updated, buf := c.validate(r, next, entry)
+	if updated != nil {
+		entries = append([]*CacheEntry(nil), entries...)
+		entries[idx] = updated
+		c.put(key, entries)
+		c.writeEntry(w, r, updated, currentAge(updated, c.now()), cacheStatusRevalidated)
+		return
+	}
+
+	if buf.status >= 500 {
+		cc := entryCacheControl(entry)
+		age := currentAge(entry, c.now())
+		if !cc.MustRevalidate && cc.StaleIfError != Unset &&
+			age < freshnessLifetime(entry, cc)+time.Duration(cc.StaleIfError)*time.Second {
+			c.writeEntry(w, r, entry, age, cacheStatusStale)
+			return
+		}
+	}
+
+	// The conditional request was sent as a GET, so its response may be
+	// stored even when the client asked with HEAD.
+	get := r
+	if r.Method == http.MethodHead {
+		get = r.Clone(r.Context())
+		get.Method = http.MethodGet
+	}
+	newEntry := buf.entry(get, buf.requestTime, c.now())
+	if isStorable(get, newEntry) {
+		c.put(key, replaceVariant(entries, newEntry))
+	} else if buf.status < 500 {
+		c.put(key, removeVariant(entries, idx))
+	}
+	buf.header.Set("Cache-Status", cacheStatusMiss)
+	buf.writeTo(w, r)
+}
+
+// revalidateInBackground refreshes the entry for r without holding up the
+// client. Only one background revalidation runs per key.
+func (c *Cache) revalidateInBackground(r *http.Request, next http.Handler, key string) {
+	c.mu.Lock()
+	if c.revalidating == nil {
+		c.revalidating = make(map[string]bool)
+	}
+	if c.revalidating[key] {
+		c.mu.Unlock()
+		return
+	}
+	c.revalidating[key] = true
+	c.mu.Unlock()
+
+	req := r.Clone(context.Background())
+	req.Method = http.MethodGet
+	c.background.Add(1)
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			delete(c.revalidating, key)
+			c.mu.Unlock()
+			c.background.Done()
+		}()
+
+		entries := c.get(key)
+		idx := selectVariant(entries, req)
+		if idx == -1 {
+			return
+		}
+		updated, buf := c.validate(req, next, entries[idx])
+		if updated == nil {
+			updated = buf.entry(req, buf.requestTime, c.now())
+			if !isStorable(req, updated) {
+				return
+			}
+		}
+		entries = append([]*CacheEntry(nil), entries...)
+		entries[idx] = updated
+		c.put(key, entries)
+	}()
+}
+
+// validate forwards a conditional version of r. If the origin answers 304
+// Not Modified, the returned entry is entry with its headers refreshed;
+// otherwise the full response is returned.
+func (c *Cache) validate(r *http.Request, next http.Handler, entry *CacheEntry) (*CacheEntry, *bufferedResponse) {
+	req := r.Clone(r.Context())
+	req.Method = http.MethodGet
+	for _, name := range clientConditionals {
+		req.Header.Del(name)
+	}
+	header := http.Header(entry.Header)
+	if etag := header.Get("ETag"); etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lm := header.Get("Last-Modified"); lm != "" {
+		req.Header.Set("If-Modified-Since", lm)
+	}
+
+	buf := newBufferedResponse()
+	buf.requestTime = c.now()
+	next.ServeHTTP(buf, req)
+	if buf.status != http.StatusNotModified {
+		return nil, buf
+	}
+
+	updated := *entry
+	updated.Header = http.Header(entry.Header).Clone()
+	for name, values := range buf.header {
+		if name == "Content-Length" {
+			continue
+		}
+		updated.Header[name] = values
+	}
+	updated.RequestTime = buf.requestTime
+	updated.ResponseTime = c.now()
+	if http.Header(updated.Header).Get("Date") == "" || buf.header.Get("Date") == "" {
+		http.Header(updated.Header).Set("Date", HTTPDate(updated.ResponseTime))
+	}
+	return &updated, buf
+}
+
+// writeEntry serves a stored entry.
+func (c *Cache) writeEntry(w http.ResponseWriter, r *http.Request, entry *CacheEntry, age time.Duration, status string) {
+	header := w.Header()
+	for name, values := range entry.Header {
+		header[name] = append([]string(nil), values...)
+	}
+	header.Set("Age", Age(age))
+	header.Set("Cache-Status", status)
+	serveEntry(w, r, entry)
+}
+
+// serveEntry writes the status and body of a complete response whose
+// headers are already in w. It answers 304 Not Modified if the client's
+// own validators match a successful response, and serves the requested
+// range of a 200 response.
+func serveEntry(w http.ResponseWriter, r *http.Request, entry *CacheEntry) {
+	header := w.Header()
+	if entry.Status >= 200 && entry.Status < 300 && notModified(r, http.Header(entry.Header)) {
+		header.Del("Content-Length")
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	if entry.Status == http.StatusOK && r.Header.Get("Range") != "" {
+		header.Del("Content-Length")
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(entry.Body))
+		return
+	}
+	w.WriteHeader(entry.Status)
+	if r.Method != http.MethodHead {
+		w.Write(entry.Body)
+	}
+}
+
+// Wait blocks until all background revalidations have finished.
+func (c *Cache) Wait() {
+	c.background.Wait()
+}
+
+func (c *Cache) key(r *http.Request) string {
+	if c.Key != nil {
+		return c.Key(r)
+	}
+	return r.Host + r.URL.RequestURI()
+}
+
+func (c *Cache) maxEntrySize() int64 {
+	if c.MaxEntrySize == 0 {
+		return 1 << 20
+	}
+	return c.MaxEntrySize
+}
+
+func (c *Cache) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+func (c *Cache) get(key string) []*CacheEntry {
+	entries, err := c.Store.Get(key)
+	if err != nil {
+		c.logf("httpx: cache get %q: %v", key, err)
+		return nil
+	}
+	return entries
+}
+
+func (c *Cache) put(key string, entries []*CacheEntry) {
+	var err error
+	if len(entries) == 0 {
+		err = c.Store.Delete(key)
+	} else {
+		err = c.Store.Put(key, entries)
+	}
+	if err != nil {
+		c.logf("httpx: cache put %q: %v", key, err)
+	}
+}
+
+func (c *Cache) delete(key string) {
+	if err := c.Store.Delete(key); err != nil {
+		c.logf("httpx: cache delete %q: %v", key, err)
+	}
+}
+
+func (c *Cache) logf(format string, args ...interface{}) {
+	if c.ErrorLog != nil {
+		c.ErrorLog.Printf(format, args...)
+	} else {
+		log.Printf(format, args...)
+	}
+}
+
+// FreshnessLifetime returns how long a response with the given headers
+// stays fresh in a shared cache: s-maxage, then max-age, then Expires
+// relative to Date, then a heuristic of 10% of the time since
+// Last-Modified (RFC 9111 section 4.2.1).
+func FreshnessLifetime(header http.Header) time.Duration {
+	entry := &CacheEntry{Status: http.StatusOK, Header: header}
+	return freshnessLifetime(entry, entryCacheControl(entry))
+}
+
+func freshnessLifetime(entry *CacheEntry, cc *CacheControl) time.Duration {
+	header := http.Header(entry.Header)
+	if cc.SMaxAge != Unset {
+		return time.Duration(cc.SMaxAge) * time.Second
+	}
+	if cc.MaxAge != Unset {
+		return time.Duration(cc.MaxAge) * time.Second
+	}
+	date := entry.ResponseTime
+	if d, err := ParseHTTPDate(header.Get("Date")); err == nil {
+		date = d
+	}
+	if expires := header.Get("Expires"); expires != "" {
+		t, err := ParseHTTPDate(expires)
+		if err != nil || !t.After(date) {
+			return 0
+		}
+		return t.Sub(date)
+	}
+	if isHeuristicallyCacheable(entry.Status) {
+		if lm, err := ParseHTTPDate(header.Get("Last-Modified")); err == nil && lm.Before(date) {
+			return date.Sub(lm) / 10
+		}
+	}
+	return 0
+}
+
+// currentAge implements the age calculation of RFC 9111 section 4.2.3.
+func currentAge(entry *CacheEntry, now time.Time) time.Duration {
+	header := http.Header(entry.Header)
+	var apparentAge, ageValue time.Duration
+	if date, err := ParseHTTPDate(header.Get("Date")); err == nil && entry.ResponseTime.After(date) {
+		apparentAge = entry.ResponseTime.Sub(date)
+	}
+	if d, err := ParseAge(header.Get("Age")); err == nil {
+		ageValue = d
+	}
+	correctedAge := ageValue + entry.ResponseTime.Sub(entry.RequestTime)
+	if correctedAge < apparentAge {
+		correctedAge = apparentAge
+	}
+	return correctedAge + now.Sub(entry.ResponseTime)
+}
+
+// isStorable reports whether a shared cache may store the response to r
+// (RFC 9111 section 3). Not Modified and partial responses are refused, as
+// Cache neither updates nor combines stored entries with them (sections
+// 3.3 and 3.4).
+func isStorable(r *http.Request, entry *CacheEntry) bool {
+	if r.Method != http.MethodGet {
+		return false
+	}
+	if entry.Status == http.StatusNotModified || entry.Status == http.StatusPartialContent {
+		return false
+	}
+	header := http.Header(entry.Header)
+	if strings.TrimSpace(header.Get("Vary")) == "*" {
+		return false
+	}
+	reqCC, _ := ParseCacheControl(strings.Join(r.Header.Values("Cache-Control"), ","))
+	if reqCC != nil && reqCC.NoStore {
+		return false
+	}
+	cc := entryCacheControl(entry)
+	if cc.NoStore || cc.Private {
+		return false
+	}
+	if r.Header.Get("Authorization") != "" && !cc.Public && !cc.MustRevalidate && cc.SMaxAge == Unset {
+		return false
+	}
+	switch {
+	case cc.Public, cc.MaxAge != Unset, cc.SMaxAge != Unset, header.Get("Expires") != "":
+		return entry.Status < 500 || entry.Status == http.StatusNotImplemented
+	case isHeuristicallyCacheable(entry.Status):
+		return header.Get("ETag") != "" || header.Get("Last-Modified") != ""
+	}
+	return false
+}
+
+// isHeuristicallyCacheable reports whether status is cacheable by default
+// (RFC 9110 section 15.1), leaving out 206 Partial Content, which Cache
+// doesn't store.
+func isHeuristicallyCacheable(status int) bool {
+	switch status {
+	case 200, 203, 204, 300, 301, 308, 404, 405, 410, 414, 501:
+		return true
+	}
+	return false
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// entryCacheControl parses the stored response's Cache-Control header,
+// falling back to no directives if it is malformed.
+func entryCacheControl(entry *CacheEntry) *CacheControl {
+	cc, err := ParseCacheControl(strings.Join(http.Header(entry.Header).Values("Cache-Control"), ","))
+	if err != nil {
+		return NewCacheControl()
+	}
+	return cc
+}
+
+// varyFields returns the canonical field names listed in header's Vary.
+func varyFields(header http.Header) []string {
+	var fields []string
+	for _, v := range header.Values("Vary") {
+		for _, name := range SplitHeaderList(v) {
+			fields = append(fields, http.CanonicalHeaderKey(name))
+		}
+	}
+	return fields
+}
+
+// selectVariant returns the index of the entry whose Vary'ed request
+// headers match r, or -1.
+func selectVariant(entries []*CacheEntry, r *http.Request) int {
+	for i, entry := range entries {
+		if varyMatches(entry, r.Header) {
+			return i
+		}
+	}
+	return -1
+}
+
+func varyMatches(entry *CacheEntry, header http.Header) bool {
+	for _, name := range varyFields(http.Header(entry.Header)) {
+		if normalizeVaryValue(header.Values(name)) != normalizeVaryValue(entry.RequestHeader[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeVaryValue(values []string) string {
+	var parts []string
+	for _, v := range values {
+		parts = append(parts, SplitHeaderList(v)...)
+	}
+	return strings.Join(parts, ",")
+}
+
+// replaceVariant returns entries with any variant matching entry's Vary'ed
+// request headers replaced by entry.
+func replaceVariant(entries []*CacheEntry, entry *CacheEntry) []*CacheEntry {
+	result := []*CacheEntry{entry}
+	for _, e := range entries {
+		if !varyMatches(e, http.Header(entry.RequestHeader)) || !varyMatches(entry, http.Header(e.RequestHeader)) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+func removeVariant(entries []*CacheEntry, idx int) []*CacheEntry {
+	result := append([]*CacheEntry(nil), entries[:idx]...)
+	return append(result, entries[idx+1:]...)
+}
+
+// notModified reports whether the client's conditional headers match the
+// stored response (RFC 9110 section 13.1).
+func notModified(r *http.Request, header http.Header) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		etag := strings.TrimPrefix(header.Get("ETag"), "W/")
+		if etag == "" {
+			return false
+		}
+		for _, tag := range SplitHeaderList(inm) {
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+	ims, err := ParseHTTPDate(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	lm, err := ParseHTTPDate(header.Get("Last-Modified"))
+	return err == nil && !lm.After(ims)
+}
+
+// bufferedResponse is an http.ResponseWriter that holds the response in
+// memory so that it can be stored before being sent.
+type bufferedResponse struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+	requestTime time.Time
+}
+
+func newBufferedResponse() *bufferedResponse {
+	return &bufferedResponse{header: make(http.Header), status: http.StatusOK}
+}
+
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	if b.wroteHeader {
+		return
+	}
+	b.wroteHeader = true
+	b.status = status
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	b.WriteHeader(http.StatusOK)
+	return b.body.Write(p)
+}
+
+// entry converts the buffered response to a CacheEntry for r, adding a
+// Date header if the handler did not set one.
+func (b *bufferedResponse) entry(r *http.Request, requestTime, responseTime time.Time) *CacheEntry {
+	if b.header.Get("Date") == "" {
+		b.header.Set("Date", HTTPDate(responseTime))
+	}
+	return &CacheEntry{
+		Status:        b.status,
+		Header:        b.header.Clone(),
+		Body:          append([]byte(nil), b.body.Bytes()...),
+		RequestHeader: varyRequestHeader(b.header, r),
+		RequestTime:   requestTime,
+		ResponseTime:  responseTime,
+	}
+}
+
+// varyRequestHeader returns the values of r's header fields named by the
+// response's Vary header.
+func varyRequestHeader(header http.Header, r *http.Request) map[string][]string {
+	reqHeader := make(map[string][]string)
+	for _, name := range varyFields(header) {
+		if values := r.Header.Values(name); len(values) > 0 {
+			reqHeader[name] = append([]string(nil), values...)
+		}
+	}
+	return reqHeader
+}
+
+// writeTo sends the buffered response as the answer to r.
+func (b *bufferedResponse) writeTo(w http.ResponseWriter, r *http.Request) {
+	header := w.Header()
+	for name, values := range b.header {
+		header[name] = values
+	}
+	serveEntry(w, r, &CacheEntry{Status: b.status, Header: b.header, Body: b.body.Bytes()})
+}
+
+// cacheWriter passes the response to a cache miss on to the client as it
+// is written, keeping a copy of it if it may be stored. Whether it may is
+// decided when the header is written.
+type cacheWriter struct {
+	cache *Cache
+	w     http.ResponseWriter
+	// r is the client's request and req the one forwarded without the
+	// client's conditional and range headers.
+	r, req *http.Request
+
+	header       http.Header
+	status       int
+	wroteHeader  bool
+	requestTime  time.Time
+	responseTime time.Time
+
+	// store is set while the response is being kept for the cache, in
+	// entryHeader and body.
+	store       bool
+	entryHeader http.Header
+	body        bytes.Buffer
+	// hold is set while the response is kept from the client, so that the
+	// client's range can be served from the complete response.
+	hold bool
+	// discard is set once the client has been answered 304 Not Modified.
+	discard bool
+}
+
+func (cw *cacheWriter) Header() http.Header {
+	return cw.header
+}
+
+func (cw *cacheWriter) WriteHeader(status int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+	cw.status = status
+	cw.responseTime = cw.cache.now()
+	if cw.header.Get("Date") == "" {
+		cw.header.Set("Date", HTTPDate(cw.responseTime))
+	}
+
+	cw.entryHeader = cw.header.Clone()
+	cw.store = isStorable(cw.req, &CacheEntry{Status: status, Header: cw.entryHeader})
+	if n, err := strconv.ParseInt(cw.header.Get("Content-Length"), 10, 64); err == nil && n > cw.cache.maxEntrySize() {
+		cw.store = false
+	}
+
+	header := cw.w.Header()
+	for name, values := range cw.header {
+		header[name] = values
+	}
+	header.Set("Cache-Status", cacheStatusMiss)
+	switch {
+	case status >= 200 && status < 300 && notModified(cw.r, cw.header):
+		header.Del("Content-Length")
+		cw.w.WriteHeader(http.StatusNotModified)
+		cw.discard = true
+	case cw.store && status == http.StatusOK && cw.r.Header.Get("Range") != "":
+		cw.hold = true
+	default:
+		cw.w.WriteHeader(status)
+	}
+}
+
+func (cw *cacheWriter) Write(p []byte) (int, error) {
+	cw.WriteHeader(http.StatusOK)
+	if cw.store {
+		if int64(cw.body.Len()+len(p)) > cw.cache.maxEntrySize() {
+			cw.store = false
+			if cw.hold {
+				// Too large to store, so the client gets the whole
+				// response instead of its range.
+				cw.hold = false
+				cw.w.WriteHeader(cw.status)
+				if _, err := cw.w.Write(cw.body.Bytes()); err != nil {
+					return 0, err
+				}
+			}
+			cw.body = bytes.Buffer{}
+		} else {
+			cw.body.Write(p)
+		}
+	}
+	if cw.hold || cw.discard {
+		return len(p), nil
+	}
+	return cw.w.Write(p)
+}
+
+// FlushError flushes the response to the client, for
+// http.ResponseController.
+func (cw *cacheWriter) FlushError() error {
+	cw.WriteHeader(http.StatusOK)
+	if cw.hold || cw.discard {
+		return nil
+	}
+	return http.NewResponseController(cw.w).Flush()
+}
+
+// Flush implements http.Flusher.
+func (cw *cacheWriter) Flush() {
+	cw.FlushError()
+}
+
+// Unwrap returns the client's ResponseWriter, for
+// http.ResponseController.
+func (cw *cacheWriter) Unwrap() http.ResponseWriter {
+	return cw.w
+}
+
+// finish stores the response if it was kept, and sends it to the client
+// if it was held back.
+func (cw *cacheWriter) finish(key string, entries []*CacheEntry) {
+	cw.WriteHeader(http.StatusOK)
+	if !cw.store {
+		return
+	}
+	entry := &CacheEntry{
+		Status:        cw.status,
+		Header:        cw.entryHeader,
+		Body:          append([]byte(nil), cw.body.Bytes()...),
+		RequestHeader: varyRequestHeader(cw.entryHeader, cw.req),
+		RequestTime:   cw.requestTime,
+		ResponseTime:  cw.responseTime,
+	}
+	cw.cache.put(key, replaceVariant(entries, entry))
+	if cw.hold {
+		serveEntry(cw.w, cw.r, entry)
+	}
+}
+
+// statusRecorder records the status code written through it.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap returns the underlying ResponseWriter, for
+// http.ResponseController.
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
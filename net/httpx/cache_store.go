@@ -0,0 +1,191 @@
+package httpx
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// A CacheEntry is a stored response together with the request headers it
+// was selected by and the times needed to compute its age.
+type CacheEntry struct {
+	Status int
+	Header map[string][]string
+	Body   []byte
+
+	// RequestHeader holds the request's values for each field named by
+	// the response's Vary header.
+	RequestHeader map[string][]string
+
+	RequestTime  time.Time
+	ResponseTime time.Time
+}
+
+// A CacheStore holds the responses stored by a Cache. Each key maps to
+// the variants of one resource, one entry per distinct set of Vary'ed
+// request headers. Get returns nil and no error for an unknown key.
+//
+// Implementations must be safe for concurrent use and must not modify
+// entries after they have been stored.
+type CacheStore interface {
+	Get(key string) ([]*CacheEntry, error)
+	Put(key string, entries []*CacheEntry) error
+	Delete(key string) error
+}
+
+// MemoryStore is an in-memory CacheStore that evicts the least recently
+// used key once it holds more than its capacity.
+type MemoryStore struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+type memoryItem struct {
+	key     string
+	entries []*CacheEntry
+}
+
+// NewMemoryStore returns a MemoryStore holding at most capacity keys. A
+// capacity of zero or less means no limit.
+func NewMemoryStore(capacity int) *MemoryStore {
+	return &MemoryStore{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the entries stored under key and marks it as recently used.
+func (m *MemoryStore) Get(key string) ([]*CacheEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[key]; ok {
+		m.ll.MoveToFront(el)
+		return el.Value.(*memoryItem).entries, nil
+	}
+	return nil, nil
+}
+
+// Put stores entries under key, evicting the least recently used key if
+// the store is full.
+func (m *MemoryStore) Put(key string, entries []*CacheEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[key]; ok {
+		m.ll.MoveToFront(el)
+		el.Value.(*memoryItem).entries = entries
+		return nil
+	}
+	m.items[key] = m.ll.PushFront(&memoryItem{key: key, entries: entries})
+	if m.capacity > 0 && m.ll.Len() > m.capacity {
+		oldest := m.ll.Back()
+		m.ll.Remove(oldest)
+		delete(m.items, oldest.Value.(*memoryItem).key)
+	}
+	return nil
+}
+
+// Delete removes key from the store.
+func (m *MemoryStore) Delete(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[key]; ok {
+		m.ll.Remove(el)
+		delete(m.items, key)
+	}
+	return nil
+}
+
+// Len returns the number of keys in the store.
+func (m *MemoryStore) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ll.Len()
+}
+
+// DiskStore is a CacheStore that keeps each key's entries in a gob-encoded
+// file under a directory. Files are named after the SHA-256 of the key.
+type DiskStore struct {
+	mu  sync.RWMutex
+	dir string
+}
+
+// NewDiskStore returns a DiskStore writing to dir, creating it if needed.
+func NewDiskStore(dir string) (*DiskStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DiskStore{dir: dir}, nil
+}
+
+// Get reads the entries stored under key.
+func (d *DiskStore) Get(key string) ([]*CacheEntry, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	f, err := os.Open(d.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []*CacheEntry
+	if err := gob.NewDecoder(f).Decode(&entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Put writes entries under key, replacing the file atomically.
+func (d *DiskStore) Put(key string, entries []*CacheEntry) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	tmp, err := os.CreateTemp(d.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if err := gob.NewEncoder(tmp).Encode(entries); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), d.path(key))
+}
+
+// Delete removes the file stored under key.
+func (d *DiskStore) Delete(key string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	err := os.Remove(d.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path returns the file name used for key.
+func (d *DiskStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:]))
+}
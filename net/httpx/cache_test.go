@@ -0,0 +1,357 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testClock is a manually advanced clock for Cache.Now.
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+// countingHandler returns a handler that counts its calls and writes the
+// call number as the body.
+func countingHandler(calls *int, fn func(w http.ResponseWriter, r *http.Request)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		fn(w, r)
+		w.Write([]byte(strconv.Itoa(*calls)))
+	})
+}
+
+func newTestCache(clock *testClock) *Cache {
+	cache := NewCache(NewMemoryStore(10))
+	cache.Now = clock.Now
+	return cache
+}
+
+func doRequest(h http.Handler, method, target string, header http.Header) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, nil)
+	for name, values := range header {
+		r.Header[name] = values
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func Test_CacheFreshness(t *testing.T) {
+	clock := &testClock{now: time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)}
+	var calls int
+	h := newTestCache(clock).Handler(countingHandler(&calls, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Date", HTTPDate(clock.Now()))
+	}))
+
+	if w := doRequest(h, "GET", "/a", nil); w.Body.String() != "1" || w.Header().Get("Cache-Status") != cacheStatusMiss {
+		t.Errorf("expected first request to miss but got %q (%s)", w.Body.String(), w.Header().Get("Cache-Status"))
+	}
+	clock.Advance(30 * time.Second)
+	w := doRequest(h, "GET", "/a", nil)
+	if w.Body.String() != "1" || w.Header().Get("Cache-Status") != cacheStatusHit {
+		t.Errorf("expected fresh hit but got %q (%s)", w.Body.String(), w.Header().Get("Cache-Status"))
+	}
+	if age := w.Header().Get("Age"); age != "30" {
+		t.Errorf("expected Age to be 30 but was %s", age)
+	}
+	if w := doRequest(h, "GET", "/a", http.Header{"Cache-Control": {"max-age=10"}}); w.Body.String() != "2" {
+		t.Errorf("expected request max-age to force a fetch but got %q", w.Body.String())
+	}
+	clock.Advance(61 * time.Second)
+	if w := doRequest(h, "GET", "/a", nil); w.Body.String() != "3" {
+		t.Errorf("expected stale entry to be refetched but got %q", w.Body.String())
+	}
+	if w := doRequest(h, "POST", "/a", nil); w.Body.String() != "4" {
+		t.Errorf("expected POST to pass through but got %q", w.Body.String())
+	}
+	if w := doRequest(h, "GET", "/a", nil); w.Body.String() != "5" {
+		t.Errorf("expected POST to invalidate the entry but got %q", w.Body.String())
+	}
+}
+
+func Test_CacheRevalidation(t *testing.T) {
+	clock := &testClock{now: time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)}
+	var calls, conditional int
+	h := newTestCache(clock).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Cache-Control", "max-age=10")
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte("body"))
+	}))
+
+	doRequest(h, "GET", "/a", nil)
+	clock.Advance(20 * time.Second)
+	w := doRequest(h, "GET", "/a", nil)
+	if w.Code != http.StatusOK || w.Body.String() != "body" || conditional != 1 {
+		t.Errorf("expected revalidated body but got %d %q after %d conditional requests", w.Code, w.Body.String(), conditional)
+	}
+	if w.Header().Get("Cache-Status") != cacheStatusRevalidated {
+		t.Errorf("expected revalidated status but got %s", w.Header().Get("Cache-Status"))
+	}
+	if w := doRequest(h, "GET", "/a", nil); w.Header().Get("Cache-Status") != cacheStatusHit || calls != 2 {
+		t.Errorf("expected revalidation to refresh the entry but got %s after %d calls", w.Header().Get("Cache-Status"), calls)
+	}
+	if w := doRequest(h, "GET", "/a", http.Header{"If-None-Match": {`"v1"`}}); w.Code != http.StatusNotModified {
+		t.Errorf("expected client validator to get 304 but got %d", w.Code)
+	}
+}
+
+func Test_CacheConditionalMiss(t *testing.T) {
+	clock := &testClock{now: time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)}
+	var calls int
+	h := newTestCache(clock).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeContent(w, r, "", time.Time{}, strings.NewReader("body"))
+	}))
+
+	if w := doRequest(h, "GET", "/a", http.Header{"If-None-Match": {`"v1"`}}); w.Code != http.StatusNotModified {
+		t.Errorf("expected client validator to get 304 on a miss but got %d", w.Code)
+	}
+	w := doRequest(h, "GET", "/a", nil)
+	if w.Code != http.StatusOK || w.Body.String() != "body" || w.Header().Get("Cache-Status") != cacheStatusHit {
+		t.Errorf("expected the full response to be stored but got %d %q (%s)", w.Code, w.Body.String(), w.Header().Get("Cache-Status"))
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call but got %d", calls)
+	}
+}
+
+func Test_CacheRangeMiss(t *testing.T) {
+	clock := &testClock{now: time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)}
+	h := newTestCache(clock).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Content-Type", "text/plain")
+		http.ServeContent(w, r, "", time.Time{}, strings.NewReader("body"))
+	}))
+
+	w := doRequest(h, "GET", "/a", http.Header{"Range": {"bytes=0-1"}})
+	if w.Code != http.StatusPartialContent || w.Body.String() != "bo" {
+		t.Errorf("expected the range to be served on a miss but got %d %q", w.Code, w.Body.String())
+	}
+	w = doRequest(h, "GET", "/a", nil)
+	if w.Code != http.StatusOK || w.Body.String() != "body" || w.Header().Get("Cache-Status") != cacheStatusHit {
+		t.Errorf("expected the full response to be stored but got %d %q (%s)", w.Code, w.Body.String(), w.Header().Get("Cache-Status"))
+	}
+	if w := doRequest(h, "GET", "/a", http.Header{"Range": {"bytes=2-"}}); w.Code != http.StatusPartialContent || w.Body.String() != "dy" {
+		t.Errorf("expected the range to be served from the cache but got %d %q", w.Code, w.Body.String())
+	}
+}
+
+func Test_CacheStreaming(t *testing.T) {
+	release := make(chan struct{})
+	cache := NewCache(NewMemoryStore(10))
+	srv := httptest.NewServer(cache.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sse, err := NewSSEWriter(w)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sse.Send(Event{Data: "first"})
+		<-release
+	})))
+	defer srv.Close()
+	defer close(release)
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the SSE handler to work behind the cache but got %d", resp.StatusCode)
+	}
+	events := make(chan string, 1)
+	go func() {
+		e, _ := NewSSEReader(resp.Body).Next()
+		events <- e.Data
+	}()
+	select {
+	case data := <-events:
+		if data != "first" {
+			t.Errorf("expected the first event but got %q", data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("expected the event to be flushed before the handler returned")
+	}
+}
+
+func Test_CacheMaxEntrySize(t *testing.T) {
+	clock := &testClock{now: time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)}
+	var calls int
+	cache := newTestCache(clock)
+	cache.MaxEntrySize = 10
+	h := cache.Handler(countingHandler(&calls, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write([]byte(r.URL.Path))
+	}))
+
+	for i := 0; i < 2; i++ {
+		if w := doRequest(h, "GET", "/a-rather-long-path", nil); w.Body.String() != "/a-rather-long-path"+strconv.Itoa(i+1) {
+			t.Errorf("expected the whole response but got %q", w.Body.String())
+		}
+	}
+	if w := doRequest(h, "GET", "/a-rather-long-path", http.Header{"Range": {"bytes=0-1"}}); w.Code != http.StatusOK || w.Body.String() != "/a-rather-long-path3" {
+		t.Errorf("expected a large response to be sent whole but got %d %q", w.Code, w.Body.String())
+	}
+	doRequest(h, "GET", "/b", nil)
+	doRequest(h, "GET", "/b", nil)
+	if calls != 4 {
+		t.Errorf("expected only the small response to be stored but got %d calls", calls)
+	}
+}
+
+func Test_CacheConditionalNotStored(t *testing.T) {
+	clock := &testClock{now: time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)}
+	h := newTestCache(clock).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("body"))
+	}))
+	if w := doRequest(h, "GET", "/a", http.Header{"If-None-Match": {`"v1"`}}); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("expected 304 for an unstored response but got %d %q", w.Code, w.Body.String())
+	}
+}
+
+func Test_CacheVary(t *testing.T) {
+	clock := &testClock{now: time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)}
+	var calls int
+	h := newTestCache(clock).Handler(countingHandler(&calls, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "public, max-age=60")
+		w.Header().Set("Vary", "Accept-Language")
+	}))
+
+	en := http.Header{"Accept-Language": {"en"}}
+	de := http.Header{"Accept-Language": {"de"}}
+	doRequest(h, "GET", "/a", en)
+	doRequest(h, "GET", "/a", de)
+	if w := doRequest(h, "GET", "/a", en); w.Body.String() != "1" {
+		t.Errorf("expected en variant but got %q", w.Body.String())
+	}
+	if w := doRequest(h, "GET", "/a", de); w.Body.String() != "2" {
+		t.Errorf("expected de variant but got %q", w.Body.String())
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls but got %d", calls)
+	}
+}
+
+func Test_CacheStaleWhileRevalidate(t *testing.T) {
+	clock := &testClock{now: time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)}
+	var calls int
+	cache := newTestCache(clock)
+	h := cache.Handler(countingHandler(&calls, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=10, stale-while-revalidate=30")
+	}))
+
+	doRequest(h, "GET", "/a", nil)
+	clock.Advance(20 * time.Second)
+	if w := doRequest(h, "GET", "/a", nil); w.Body.String() != "1" || w.Header().Get("Cache-Status") != cacheStatusStale {
+		t.Errorf("expected stale body while revalidating but got %q (%s)", w.Body.String(), w.Header().Get("Cache-Status"))
+	}
+	cache.Wait()
+	if w := doRequest(h, "GET", "/a", nil); w.Body.String() != "2" || w.Header().Get("Cache-Status") != cacheStatusHit {
+		t.Errorf("expected background revalidation to store a fresh body but got %q (%s)", w.Body.String(), w.Header().Get("Cache-Status"))
+	}
+}
+
+func Test_CacheNotStorable(t *testing.T) {
+	clock := &testClock{now: time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)}
+	for _, cc := range []string{"no-store", "private, max-age=60", ""} {
+		var calls int
+		h := newTestCache(clock).Handler(countingHandler(&calls, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Cache-Control", cc)
+		}))
+		doRequest(h, "GET", "/a", nil)
+		doRequest(h, "GET", "/a", nil)
+		if calls != 2 {
+			t.Errorf("expected %q response not to be stored", cc)
+		}
+	}
+
+	for _, status := range []int{http.StatusNotModified, http.StatusPartialContent} {
+		entry := &CacheEntry{Status: status, Header: map[string][]string{"Cache-Control": {"max-age=60"}}}
+		if isStorable(httptest.NewRequest("GET", "/a", nil), entry) {
+			t.Errorf("expected a %d response not to be storable", status)
+		}
+	}
+}
+
+func Test_FreshnessLifetime(t *testing.T) {
+	date := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+	header := http.Header{
+		"Date":          {HTTPDate(date)},
+		"Expires":       {HTTPDate(date.Add(time.Hour))},
+		"Last-Modified": {HTTPDate(date.Add(-100 * time.Hour))},
+	}
+	if d := FreshnessLifetime(header); d != time.Hour {
+		t.Errorf("expected Expires to give one hour but got %v", d)
+	}
+	header.Set("Cache-Control", "max-age=5, s-maxage=7")
+	if d := FreshnessLifetime(header); d != 7*time.Second {
+		t.Errorf("expected s-maxage to win but got %v", d)
+	}
+	header.Del("Cache-Control")
+	header.Del("Expires")
+	if d := FreshnessLifetime(header); d != 10*time.Hour {
+		t.Errorf("expected heuristic lifetime of 10 hours but got %v", d)
+	}
+}
+
+func Test_MemoryStoreEviction(t *testing.T) {
+	store := NewMemoryStore(2)
+	store.Put("a", []*CacheEntry{{Status: 200}})
+	store.Put("b", []*CacheEntry{{Status: 200}})
+	store.Get("a")
+	store.Put("c", []*CacheEntry{{Status: 200}})
+	if entries, _ := store.Get("b"); entries != nil {
+		t.Error("expected least recently used key to be evicted")
+	}
+	if entries, _ := store.Get("a"); entries == nil {
+		t.Error("expected recently used key to be kept")
+	}
+	if store.Len() != 2 {
+		t.Errorf("expected 2 keys but got %d", store.Len())
+	}
+}
+
+func Test_DiskStore(t *testing.T) {
+	store, err := NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries, err := store.Get("missing"); entries != nil || err != nil {
+		t.Errorf("expected nil entries and no error but got %v, %v", entries, err)
+	}
+	want := &CacheEntry{Status: 200, Header: http.Header{"Etag": {`"x"`}}, Body: []byte("hi")}
+	if err := store.Put("k", []*CacheEntry{want}); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := store.Get("k")
+	if err != nil || len(entries) != 1 || string(entries[0].Body) != "hi" || entries[0].Header["Etag"][0] != `"x"` {
+		t.Errorf("expected stored entry back but got %v, %v", entries, err)
+	}
+	store.Delete("k")
+	if entries, _ := store.Get("k"); entries != nil {
+		t.Error("expected entry to be deleted")
+	}
+}
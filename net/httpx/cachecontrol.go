@@ -0,0 +1,301 @@
+package httpx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Unset marks a delta-seconds field of a CacheControl as absent.
+const Unset = -1
+
+// A CacheDirective is a Cache-Control directive not otherwise known to
+// CacheControl, kept so that it survives a parse/serialize round trip.
+type CacheDirective struct {
+	Name  string
+	Value string
+}
+
+// CacheControl is the parsed form of a request or response Cache-Control
+// header (RFC 9111 section 5.2 and RFC 5861). Delta-seconds fields hold
+// Unset when the directive is absent.
+type CacheControl struct {
+	MaxAge               int
+	SMaxAge              int
+	MaxStale             int
+	MinFresh             int
+	StaleWhileRevalidate int
+	StaleIfError         int
+
+	// MaxStaleAny is set when max-stale was given without a value,
+	// meaning the client accepts a stale response of any age.
+	MaxStaleAny bool
+
+	NoCache         bool
+	NoStore         bool
+	NoTransform     bool
+	OnlyIfCached    bool
+	MustRevalidate  bool
+	ProxyRevalidate bool
+	MustUnderstand  bool
+	Public          bool
+	Private         bool
+	Immutable       bool
+
+	// NoCacheFields and PrivateFields hold the field names given to the
+	// qualified forms of no-cache and private.
+	NoCacheFields []string
+	PrivateFields []string
+
+	Extensions []CacheDirective
+}
+
+// NewCacheControl returns an empty CacheControl with all delta-seconds
+// fields Unset.
+func NewCacheControl() *CacheControl {
+	return &CacheControl{
+		MaxAge:               Unset,
+		SMaxAge:              Unset,
+		MaxStale:             Unset,
+		MinFresh:             Unset,
+		StaleWhileRevalidate: Unset,
+		StaleIfError:         Unset,
+	}
+}
+
+// ParseCacheControl parses the value of a Cache-Control header. Directive
+// names are case-insensitive. An error is returned for a directive whose
+// argument is missing or malformed, or for a directive given more than
+// once; unknown directives are kept in Extensions.
+//
+//	cc, _ := ParseCacheControl(`public, max-age=3600, stale-while-revalidate=60`)
+//	cc.MaxAge                  // 3600
+//	cc.StaleWhileRevalidate    // 60
+func ParseCacheControl(header string) (*CacheControl, error) {
+	cc := NewCacheControl()
+	seen := make(map[string]bool)
+
+	for _, elem := range SplitHeaderList(header) {
+		name, value, hasValue := strings.Cut(elem, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = Unquote(strings.TrimSpace(value))
+
+		if seen[name] {
+			return nil, fmt.Errorf("httpx: duplicate cache directive %q", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "max-age":
+			cc.MaxAge, err = parseDeltaSeconds(name, value, hasValue)
+		case "s-maxage":
+			cc.SMaxAge, err = parseDeltaSeconds(name, value, hasValue)
+		case "min-fresh":
+			cc.MinFresh, err = parseDeltaSeconds(name, value, hasValue)
+		case "stale-while-revalidate":
+			cc.StaleWhileRevalidate, err = parseDeltaSeconds(name, value, hasValue)
+		case "stale-if-error":
+			cc.StaleIfError, err = parseDeltaSeconds(name, value, hasValue)
+		case "max-stale":
+			if hasValue {
+				cc.MaxStale, err = parseDeltaSeconds(name, value, hasValue)
+			} else {
+				cc.MaxStaleAny = true
+			}
+		case "no-cache":
+			cc.NoCache = true
+			if hasValue {
+				cc.NoCacheFields = splitFieldNames(value)
+			}
+		case "private":
+			cc.Private = true
+			if hasValue {
+				cc.PrivateFields = splitFieldNames(value)
+			}
+		case "no-store":
+			cc.NoStore = true
+		case "no-transform":
+			cc.NoTransform = true
+		case "only-if-cached":
+			cc.OnlyIfCached = true
+		case "must-revalidate":
+			cc.MustRevalidate = true
+		case "proxy-revalidate":
+			cc.ProxyRevalidate = true
+		case "must-understand":
+			cc.MustUnderstand = true
+		case "public":
+			cc.Public = true
+		case "immutable":
+			cc.Immutable = true
+		default:
+			cc.Extensions = append(cc.Extensions, CacheDirective{Name: name, Value: value})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cc, nil
+}
+
+// ValidateRequest reports an error if cc contains directives that are
+// only defined for responses.
+func (cc *CacheControl) ValidateRequest() error {
+	var invalid []string
+	if cc.SMaxAge != Unset {
+		invalid = append(invalid, "s-maxage")
+	}
+	if cc.StaleWhileRevalidate != Unset {
+		invalid = append(invalid, "stale-while-revalidate")
+	}
+	if cc.Public {
+		invalid = append(invalid, "public")
+	}
+	if cc.Private {
+		invalid = append(invalid, "private")
+	}
+	if cc.MustRevalidate {
+		invalid = append(invalid, "must-revalidate")
+	}
+	if cc.ProxyRevalidate {
+		invalid = append(invalid, "proxy-revalidate")
+	}
+	if cc.MustUnderstand {
+		invalid = append(invalid, "must-understand")
+	}
+	if cc.Immutable {
+		invalid = append(invalid, "immutable")
+	}
+	if len(cc.NoCacheFields) > 0 {
+		invalid = append(invalid, "no-cache with field names")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("httpx: response-only cache directives in request: %s", strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+// ValidateResponse reports an error if cc contains directives that are
+// only defined for requests, or directives that contradict each other.
+func (cc *CacheControl) ValidateResponse() error {
+	var invalid []string
+	if cc.MaxStale != Unset || cc.MaxStaleAny {
+		invalid = append(invalid, "max-stale")
+	}
+	if cc.MinFresh != Unset {
+		invalid = append(invalid, "min-fresh")
+	}
+	if cc.OnlyIfCached {
+		invalid = append(invalid, "only-if-cached")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("httpx: request-only cache directives in response: %s", strings.Join(invalid, ", "))
+	}
+
+	switch {
+	case cc.Public && cc.Private:
+		return errors.New("httpx: cache directives public and private are mutually exclusive")
+	case cc.NoStore && cc.Immutable:
+		return errors.New("httpx: cache directive immutable has no effect with no-store")
+	case cc.MustUnderstand && !cc.NoStore:
+		return errors.New("httpx: cache directive must-understand requires no-store as a fallback")
+	}
+	return nil
+}
+
+// String serializes cc as a Cache-Control header value. Directives are
+// written in a fixed order followed by any extensions in the order they
+// were parsed or added.
+//
+//	cc := NewCacheControl()
+//	cc.Public = true
+//	cc.MaxAge = 60
+//	cc.String()            // "public, max-age=60"
+func (cc *CacheControl) String() string {
+	var parts []string
+
+	flag := func(set bool, name string) {
+		if set {
+			parts = append(parts, name)
+		}
+	}
+	delta := func(value int, name string) {
+		if value != Unset {
+			parts = append(parts, name+"="+strconv.Itoa(value))
+		}
+	}
+	fields := func(set bool, names []string, name string) {
+		if !set {
+			return
+		}
+		if len(names) == 0 {
+			parts = append(parts, name)
+			return
+		}
+		parts = append(parts, name+`="`+strings.Join(names, ", ")+`"`)
+	}
+
+	flag(cc.Public, "public")
+	fields(cc.Private, cc.PrivateFields, "private")
+	fields(cc.NoCache, cc.NoCacheFields, "no-cache")
+	flag(cc.NoStore, "no-store")
+	flag(cc.NoTransform, "no-transform")
+	flag(cc.MustUnderstand, "must-understand")
+	delta(cc.MaxAge, "max-age")
+	delta(cc.SMaxAge, "s-maxage")
+	if cc.MaxStaleAny {
+		parts = append(parts, "max-stale")
+	} else {
+		delta(cc.MaxStale, "max-stale")
+	}
+	delta(cc.MinFresh, "min-fresh")
+	flag(cc.OnlyIfCached, "only-if-cached")
+	flag(cc.MustRevalidate, "must-revalidate")
+	flag(cc.ProxyRevalidate, "proxy-revalidate")
+	flag(cc.Immutable, "immutable")
+	delta(cc.StaleWhileRevalidate, "stale-while-revalidate")
+	delta(cc.StaleIfError, "stale-if-error")
+
+	for _, ext := range cc.Extensions {
+		if ext.Value == "" {
+			parts = append(parts, ext.Name)
+		} else {
+			parts = append(parts, ext.Name+"="+Quote(ext.Value))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// parseDeltaSeconds parses the delta-seconds argument of the named
+// directive. Values too large to represent are capped at 2^31-1, in the
+// spirit of RFC 9111 section 1.2.2.
+func parseDeltaSeconds(name, value string, hasValue bool) (int, error) {
+	if !hasValue || value == "" {
+		return Unset, fmt.Errorf("httpx: cache directive %q requires a value", name)
+	}
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return Unset, fmt.Errorf("httpx: invalid delta-seconds %q for cache directive %q", value, name)
+		}
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n > math.MaxInt32 {
+		return math.MaxInt32, nil
+	}
+	return int(n), nil
+}
+
+// splitFieldNames splits the field-name list of a qualified no-cache or
+// private directive.
+func splitFieldNames(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,87 @@
+package httpx
+
+import "testing"
+
+func Test_ParseCacheControl(t *testing.T) {
+	cc, err := ParseCacheControl(`Public, max-age=3600, s-maxage="600", stale-while-revalidate=60, immutable, community="UCI"`)
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	if !cc.Public || !cc.Immutable {
+		t.Errorf("expected public and immutable to be set: %+v", cc)
+	}
+	if cc.MaxAge != 3600 || cc.SMaxAge != 600 || cc.StaleWhileRevalidate != 60 {
+		t.Errorf("unexpected delta-seconds: %+v", cc)
+	}
+	if cc.MinFresh != Unset || cc.StaleIfError != Unset {
+		t.Errorf("expected absent directives to be Unset: %+v", cc)
+	}
+	if len(cc.Extensions) != 1 || cc.Extensions[0] != (CacheDirective{Name: "community", Value: "UCI"}) {
+		t.Errorf("expected community extension but got %v", cc.Extensions)
+	}
+
+	cc, err = ParseCacheControl(`private="Set-Cookie, X-Session", no-cache, max-stale`)
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	if !cc.Private || len(cc.PrivateFields) != 2 || cc.PrivateFields[1] != "X-Session" {
+		t.Errorf("expected private field names but got %v", cc.PrivateFields)
+	}
+	if !cc.NoCache || !cc.MaxStaleAny || cc.MaxStale != Unset {
+		t.Errorf("expected no-cache and max-stale without value: %+v", cc)
+	}
+
+	for _, header := range []string{"max-age", "max-age=", "max-age=-1", "max-age=1.5", "max-age=1, max-age=2"} {
+		if _, err := ParseCacheControl(header); err == nil {
+			t.Errorf("expected an error for %q", header)
+		}
+	}
+
+	cc, _ = ParseCacheControl("max-age=99999999999")
+	if cc.MaxAge != 2147483647 {
+		t.Errorf("expected overflowing max-age to be capped but got %d", cc.MaxAge)
+	}
+}
+
+func Test_CacheControlValidate(t *testing.T) {
+	cc, _ := ParseCacheControl("public, private")
+	if cc.ValidateResponse() == nil {
+		t.Error("expected public and private to be rejected")
+	}
+	if cc.ValidateRequest() == nil {
+		t.Error("expected public in a request to be rejected")
+	}
+	cc, _ = ParseCacheControl("max-age=0, min-fresh=10, only-if-cached")
+	if err := cc.ValidateRequest(); err != nil {
+		t.Errorf("expected valid request directives but got %v", err)
+	}
+	if cc.ValidateResponse() == nil {
+		t.Error("expected min-fresh in a response to be rejected")
+	}
+	cc, _ = ParseCacheControl("no-store, immutable")
+	if cc.ValidateResponse() == nil {
+		t.Error("expected immutable with no-store to be rejected")
+	}
+}
+
+func Test_CacheControlString(t *testing.T) {
+	cc := NewCacheControl()
+	if result := cc.String(); result != "" {
+		t.Errorf("expected empty header but got '%s'", result)
+	}
+	cc.Public = true
+	cc.MaxAge = 60
+	cc.StaleWhileRevalidate = 30
+	if result := cc.String(); result != "public, max-age=60, stale-while-revalidate=30" {
+		t.Errorf("unexpected serialization '%s'", result)
+	}
+
+	header := `private="Set-Cookie", no-cache, max-age=0, must-revalidate, foo="a b"`
+	cc, err := ParseCacheControl(header)
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	if result := cc.String(); result != header {
+		t.Errorf("expected '%s' to round trip but got '%s'", header, result)
+	}
+}
@@ -0,0 +1,105 @@
+package httpx
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// DefaultTrustedProxies are the networks ClientIP trusts when no others
+// are given: loopback and private addresses, as in Rack.
+var DefaultTrustedProxies = parseCIDRs(
+	"127.0.0.0/8", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16",
+	"::1/128", "fc00::/7",
+)
+
+// ClientIP returns the address of the client that made r. When the peer
+// is a trusted proxy, the Forwarded header (RFC 7239), or failing that
+// X-Forwarded-For, is walked from the nearest hop back and the first
+// untrusted address is returned. trusted defaults to
+// DefaultTrustedProxies.
+//
+//	// RemoteAddr "10.0.0.2:4711", X-Forwarded-For: "203.0.113.7, 10.0.0.1"
+//	ClientIP(r)        // "203.0.113.7"
+func ClientIP(r *http.Request, trusted ...*net.IPNet) string {
+	if len(trusted) == 0 {
+		trusted = DefaultTrustedProxies
+	}
+	remote := stripPort(r.RemoteAddr)
+	if !isTrusted(remote, trusted) {
+		return remote
+	}
+
+	var hops []string
+	if values := r.Header.Values("Forwarded"); len(values) > 0 {
+		hops = forwardedFor(values)
+	} else {
+		for _, v := range r.Header.Values("X-Forwarded-For") {
+			for _, hop := range strings.Split(v, ",") {
+				if hop = strings.TrimSpace(hop); hop != "" {
+					hops = append(hops, stripPort(hop))
+				}
+			}
+		}
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		if !isTrusted(hops[i], trusted) {
+			return hops[i]
+		}
+	}
+	if len(hops) > 0 {
+		return hops[0]
+	}
+	return remote
+}
+
+// forwardedFor returns the for= parameters of the elements of the
+// Forwarded header values, in order.
+func forwardedFor(values []string) []string {
+	var hops []string
+	for _, v := range values {
+		for _, elem := range SplitHeaderList(v) {
+			for _, pair := range strings.Split(elem, ";") {
+				name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if ok && strings.EqualFold(name, "for") {
+					hops = append(hops, stripPort(Unquote(value)))
+				}
+			}
+		}
+	}
+	return hops
+}
+
+// stripPort removes a port and IPv6 brackets from a host.
+func stripPort(hostport string) string {
+	if host, _, err := net.SplitHostPort(hostport); err == nil {
+		return host
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(hostport, "["), "]")
+}
+
+func isTrusted(addr string, trusted []*net.IPNet) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, n := range trusted {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}
@@ -0,0 +1,31 @@
+package httpx
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_ClientIP(t *testing.T) {
+	cases := []struct {
+		remote string
+		header map[string]string
+		want   string
+	}{
+		{"203.0.113.9:1234", map[string]string{"X-Forwarded-For": "1.2.3.4"}, "203.0.113.9"},
+		{"10.0.0.2:1234", nil, "10.0.0.2"},
+		{"10.0.0.2:1234", map[string]string{"X-Forwarded-For": "203.0.113.7, 10.0.0.1"}, "203.0.113.7"},
+		{"10.0.0.2:1234", map[string]string{"X-Forwarded-For": "6.6.6.6, 203.0.113.7, 10.0.0.1"}, "203.0.113.7"},
+		{"127.0.0.1:1234", map[string]string{"Forwarded": `for=192.0.2.60;proto=http, for="[2001:db8::1]:4711"`}, "2001:db8::1"},
+		{"[::1]:1234", map[string]string{"X-Forwarded-For": "192.168.1.1, 10.1.1.1"}, "192.168.1.1"},
+	}
+	for _, c := range cases {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = c.remote
+		for name, value := range c.header {
+			r.Header.Set(name, value)
+		}
+		if got := ClientIP(r); got != c.want {
+			t.Errorf("expected %s for %s %v but got %s", c.want, c.remote, c.header, got)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package httpx
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORS is a Cross-Origin Resource Sharing middleware. It answers
+// preflight requests itself and adds the Access-Control-* headers to the
+// responses of allowed cross-origin requests.
+//
+// Entries of AllowedOrigins are either "*", an exact origin such as
+// "https://example.com", or a pattern with a wildcard subdomain such as
+// "https://*.example.com", which matches any subdomain but not the bare
+// domain.
+//
+//	cors := &CORS{
+//		AllowedOrigins:   []string{"https://example.com", "https://*.example.com"},
+//		AllowedMethods:   []string{"GET", "POST", "DELETE"},
+//		AllowedHeaders:   []string{"Content-Type", "X-CSRF-Token"},
+//		AllowCredentials: true,
+//		MaxAge:           time.Hour,
+//	}
+//	http.ListenAndServe(":8080", cors.Handler(app))
+type CORS struct {
+	AllowedOrigins []string
+
+	// AllowOriginFunc, if set, is consulted for origins that do not
+	// match AllowedOrigins.
+	AllowOriginFunc func(origin string) bool
+
+	// AllowedMethods defaults to GET, HEAD and POST.
+	AllowedMethods []string
+
+	// AllowedHeaders lists the request headers a cross-origin request
+	// may use, compared case-insensitively. "*" allows any header.
+	AllowedHeaders []string
+
+	// ExposedHeaders lists the response headers scripts may read.
+	ExposedHeaders []string
+
+	// AllowCredentials allows cookies and HTTP authentication. The
+	// request's origin is then echoed rather than "*". It can't be
+	// combined with an AllowedOrigins entry of "*", which would let any
+	// site make credentialed requests.
+	AllowCredentials bool
+
+	// MaxAge is how long browsers may cache a preflight response. Zero
+	// omits Access-Control-Max-Age.
+	MaxAge time.Duration
+
+	// AllowPrivateNetwork answers Private Network Access preflights,
+	// which ask whether a public site may reach a private address.
+	AllowPrivateNetwork bool
+
+	// PassthroughPreflight hands preflight requests on to the next
+	// handler after the CORS headers have been set, instead of answering
+	// them with 204 No Content.
+	PassthroughPreflight bool
+}
+
+// Handler returns next wrapped with CORS handling. It panics if
+// AllowCredentials is combined with the "*" origin, which the Fetch
+// standard forbids.
+func (c *CORS) Handler(next http.Handler) http.Handler {
+	if c.AllowCredentials && c.allowsAnyOrigin() {
+		panic(`httpx: CORS AllowCredentials can't be used with the "*" origin`)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if r.Method == http.MethodOptions && origin != "" && r.Header.Get("Access-Control-Request-Method") != "" {
+			c.preflight(w, r, origin)
+			if c.PassthroughPreflight {
+				next.ServeHTTP(w, r)
+			} else {
+				w.WriteHeader(http.StatusNoContent)
+			}
+			return
+		}
+
+		h := w.Header()
+		if !c.allowsAnyOrigin() {
+			h.Add("Vary", "Origin")
+		}
+		if origin != "" && c.AllowsOrigin(origin) {
+			c.setOrigin(h, origin)
+			if len(c.ExposedHeaders) > 0 {
+				h.Set("Access-Control-Expose-Headers", strings.Join(c.ExposedHeaders, ", "))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// preflight sets the headers answering a preflight request. Nothing but
+// Vary is set if the origin, method or any requested header is not
+// allowed, which makes the browser fail the request.
+func (c *CORS) preflight(w http.ResponseWriter, r *http.Request, origin string) {
+	h := w.Header()
+	h.Add("Vary", "Origin")
+	h.Add("Vary", "Access-Control-Request-Method")
+	h.Add("Vary", "Access-Control-Request-Headers")
+	if c.AllowPrivateNetwork {
+		h.Add("Vary", "Access-Control-Request-Private-Network")
+	}
+
+	if !c.AllowsOrigin(origin) {
+		return
+	}
+	method := r.Header.Get("Access-Control-Request-Method")
+	if !c.allowsMethod(method) {
+		return
+	}
+	var requested []string
+	for _, v := range r.Header.Values("Access-Control-Request-Headers") {
+		requested = append(requested, SplitHeaderList(v)...)
+	}
+	for _, name := range requested {
+		if !c.allowsHeader(name) {
+			return
+		}
+	}
+	private := strings.EqualFold(r.Header.Get("Access-Control-Request-Private-Network"), "true")
+	if private && !c.AllowPrivateNetwork {
+		return
+	}
+
+	c.setOrigin(h, origin)
+	h.Set("Access-Control-Allow-Methods", strings.Join(c.methods(), ", "))
+	if len(requested) > 0 {
+		h.Set("Access-Control-Allow-Headers", strings.Join(requested, ", "))
+	}
+	if c.MaxAge > 0 {
+		h.Set("Access-Control-Max-Age", strconv.Itoa(int(c.MaxAge/time.Second)))
+	}
+	if private {
+		h.Set("Access-Control-Allow-Private-Network", "true")
+	}
+}
+
+// AllowsOrigin reports whether origin may make cross-origin requests.
+func (c *CORS) AllowsOrigin(origin string) bool {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" && origin != "null" || matchOrigin(allowed, origin) {
+			return true
+		}
+	}
+	return c.AllowOriginFunc != nil && c.AllowOriginFunc(origin)
+}
+
+func (c *CORS) setOrigin(h http.Header, origin string) {
+	if c.allowsAnyOrigin() {
+		h.Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+	h.Set("Access-Control-Allow-Origin", origin)
+	if c.AllowCredentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func (c *CORS) allowsAnyOrigin() bool {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CORS) methods() []string {
+	if len(c.AllowedMethods) == 0 {
+		return []string{http.MethodGet, http.MethodHead, http.MethodPost}
+	}
+	return c.AllowedMethods
+}
+
+func (c *CORS) allowsMethod(method string) bool {
+	for _, m := range c.methods() {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CORS) allowsHeader(name string) bool {
+	for _, allowed := range c.AllowedHeaders {
+		if allowed == "*" || strings.EqualFold(allowed, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchOrigin reports whether origin matches pattern, which may contain a
+// single "*" standing for one or more subdomain labels.
+func matchOrigin(pattern, origin string) bool {
+	star := strings.IndexByte(pattern, '*')
+	if star == -1 {
+		return strings.EqualFold(pattern, origin)
+	}
+	prefix, suffix := pattern[:star], pattern[star+1:]
+	if len(origin) <= len(prefix)+len(suffix) ||
+		!strings.EqualFold(origin[:len(prefix)], prefix) ||
+		!strings.EqualFold(origin[len(origin)-len(suffix):], suffix) {
+		return false
+	}
+	sub := origin[len(prefix) : len(origin)-len(suffix)]
+	if sub[0] == '.' || sub[len(sub)-1] == '.' {
+		return false
+	}
+	for i := 0; i < len(sub); i++ {
+		c := sub[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
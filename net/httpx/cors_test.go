@@ -0,0 +1,104 @@
+package httpx
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_CORSPreflight(t *testing.T) {
+	cors := &CORS{
+		AllowedOrigins:      []string{"https://*.example.com"},
+		AllowedMethods:      []string{"GET", "PUT"},
+		AllowedHeaders:      []string{"Content-Type", "X-CSRF-Token"},
+		AllowCredentials:    true,
+		AllowPrivateNetwork: true,
+		MaxAge:              10 * time.Minute,
+	}
+	var called bool
+	h := cors.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true }))
+
+	w := doRequest(h, "OPTIONS", "/", http.Header{
+		"Origin":                                 {"https://app.example.com"},
+		"Access-Control-Request-Method":          {"PUT"},
+		"Access-Control-Request-Headers":         {"content-type,x-csrf-token"},
+		"Access-Control-Request-Private-Network": {"true"},
+	})
+	if called || w.Code != http.StatusNoContent {
+		t.Errorf("expected preflight to be answered with 204 but got %d (called: %v)", w.Code, called)
+	}
+	expected := map[string]string{
+		"Access-Control-Allow-Origin":          "https://app.example.com",
+		"Access-Control-Allow-Credentials":     "true",
+		"Access-Control-Allow-Methods":         "GET, PUT",
+		"Access-Control-Allow-Headers":         "content-type, x-csrf-token",
+		"Access-Control-Max-Age":               "600",
+		"Access-Control-Allow-Private-Network": "true",
+	}
+	for name, value := range expected {
+		if got := w.Header().Get(name); got != value {
+			t.Errorf("expected %s to be '%s' but was '%s'", name, value, got)
+		}
+	}
+	if vary := w.Header().Values("Vary"); len(vary) != 4 || vary[0] != "Origin" {
+		t.Errorf("unexpected Vary %v", vary)
+	}
+
+	for _, header := range []http.Header{
+		{"Origin": {"https://example.com"}, "Access-Control-Request-Method": {"PUT"}},
+		{"Origin": {"https://app.example.com"}, "Access-Control-Request-Method": {"DELETE"}},
+		{"Origin": {"https://app.example.com"}, "Access-Control-Request-Method": {"GET"}, "Access-Control-Request-Headers": {"X-Other"}},
+	} {
+		w := doRequest(h, "OPTIONS", "/", header)
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+			t.Errorf("expected preflight %v to be refused but got origin '%s'", header, got)
+		}
+	}
+}
+
+func Test_CORSActualRequest(t *testing.T) {
+	cors := &CORS{AllowedOrigins: []string{"*"}, ExposedHeaders: []string{"X-Total"}}
+	h := cors.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := doRequest(h, "GET", "/", http.Header{"Origin": {"https://anywhere.test"}})
+	if w.Header().Get("Access-Control-Allow-Origin") != "*" || w.Header().Get("Access-Control-Expose-Headers") != "X-Total" {
+		t.Errorf("unexpected headers %v", w.Header())
+	}
+	if w.Header().Get("Vary") != "" {
+		t.Errorf("expected no Vary for a wildcard origin but got '%s'", w.Header().Get("Vary"))
+	}
+
+	cors = &CORS{AllowedOrigins: []string{"https://example.com"}}
+	h = cors.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	w = doRequest(h, "GET", "/", http.Header{"Origin": {"https://evil.test"}})
+	if w.Header().Get("Access-Control-Allow-Origin") != "" || w.Header().Get("Vary") != "Origin" {
+		t.Errorf("expected refused origin with Vary: Origin but got %v", w.Header())
+	}
+}
+
+func Test_matchOrigin(t *testing.T) {
+	cases := map[string]bool{
+		"https://a.example.com":         true,
+		"https://a.b.example.com":       true,
+		"https://example.com":           false,
+		"https://.example.com":          false,
+		"http://a.example.com":          false,
+		"https://a.example.com.evil":    false,
+		"https://evil.com/.example.com": false,
+	}
+	for origin, want := range cases {
+		if got := matchOrigin("https://*.example.com", origin); got != want {
+			t.Errorf("expected %s to match: %v but got %v", origin, want, got)
+		}
+	}
+}
+
+func Test_CORSWildcardCredentials(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected \"*\" with AllowCredentials to panic")
+		}
+	}()
+	cors := &CORS{AllowedOrigins: []string{"https://example.com", "*"}, AllowCredentials: true}
+	cors.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+}
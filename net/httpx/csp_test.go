@@ -0,0 +1,87 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_ContentSecurityPolicyBuild(t *testing.T) {
+	csp := NewContentSecurityPolicy().
+		DefaultSrc(CSPSelf).
+		ScriptSrc(CSPSelf, "https://cdn.example.com").
+		ObjectSrc(CSPNone).
+		UpgradeInsecureRequests().
+		ReportURI("/csp")
+
+	expected := "default-src 'self'; script-src 'self' https://cdn.example.com; object-src 'none'; upgrade-insecure-requests; report-uri /csp"
+	if result := csp.Build(""); result != expected {
+		t.Errorf("expected '%s' but got '%s'", expected, result)
+	}
+	expected = "default-src 'self'; script-src 'self' https://cdn.example.com 'nonce-abc'; object-src 'none'; upgrade-insecure-requests; report-uri /csp"
+	if result := csp.Build("abc"); result != expected {
+		t.Errorf("expected '%s' but got '%s'", expected, result)
+	}
+
+	csp.ScriptSrc(CSPNone).Set("report-uri")
+	if result := csp.Build("abc"); strings.Contains(result, "script-src 'none' 'nonce") || strings.Contains(result, "report-uri") {
+		t.Errorf("unexpected policy '%s'", result)
+	}
+}
+
+func Test_ContentSecurityPolicyHandler(t *testing.T) {
+	csp := NewContentSecurityPolicy().DefaultSrc(CSPSelf).ScriptSrc(CSPSelf).ReportTo("csp", "https://example.com/csp")
+	csp.ReportOnly = true
+
+	var nonce string
+	h := csp.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nonce = CSPNonce(r.Context())
+	}))
+	w := doRequest(h, "GET", "/", nil)
+	if nonce == "" {
+		t.Fatal("expected a nonce in the request context")
+	}
+	policy := w.Header().Get("Content-Security-Policy-Report-Only")
+	if !strings.Contains(policy, "'nonce-"+nonce+"'") || !strings.Contains(policy, "report-to csp") {
+		t.Errorf("unexpected policy '%s'", policy)
+	}
+	if w.Header().Get("Reporting-Endpoints") != `csp="https://example.com/csp"` {
+		t.Errorf("unexpected Reporting-Endpoints '%s'", w.Header().Get("Reporting-Endpoints"))
+	}
+	if doRequest(h, "GET", "/", nil); CSPNonce(httptest.NewRequest("GET", "/", nil).Context()) != "" {
+		t.Error("expected no nonce outside the handler")
+	}
+}
+
+func Test_CSPReportHandler(t *testing.T) {
+	var reports []CSPReport
+	h := CSPReportHandler(func(r *http.Request, report CSPReport) {
+		reports = append(reports, report)
+	})
+
+	legacy := `{"csp-report":{"document-uri":"https://example.com/","blocked-uri":"inline","violated-directive":"script-src","line-number":4}}`
+	r := httptest.NewRequest("POST", "/csp", strings.NewReader(legacy))
+	r.Header.Set("Content-Type", "application/csp-report")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusNoContent || len(reports) != 1 || reports[0].BlockedURI != "inline" || reports[0].LineNumber != 4 {
+		t.Errorf("unexpected legacy result %d %+v", w.Code, reports)
+	}
+
+	batch := `[{"type":"csp-violation","body":{"documentURL":"https://example.com/","blockedURL":"eval","effectiveDirective":"script-src-elem"}},{"type":"deprecation","body":{}}]`
+	r = httptest.NewRequest("POST", "/csp", strings.NewReader(batch))
+	r.Header.Set("Content-Type", "application/reports+json")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusNoContent || len(reports) != 2 || reports[1].EffectiveDirective != "script-src-elem" {
+		t.Errorf("unexpected reporting API result %d %+v", w.Code, reports)
+	}
+
+	r = httptest.NewRequest("POST", "/csp", strings.NewReader("{"))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for malformed JSON but got %d", w.Code)
+	}
+}
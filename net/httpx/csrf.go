@@ -0,0 +1,283 @@
+package httpx
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// csrfTokenLength is the length in bytes of an unmasked token.
+const csrfTokenLength = 32
+
+// A CSRFTokenStore keeps the unmasked per-session CSRF token. A session
+// middleware can implement it to keep the token alongside the rest of the
+// session; CookieCSRFStore is used otherwise.
+type CSRFTokenStore interface {
+	// Get returns the token stored for r's session, or "" if none.
+	Get(r *http.Request) (string, error)
+
+	// Save stores token for r's session.
+	Save(w http.ResponseWriter, r *http.Request, token string) error
+}
+
+// CookieCSRFStore is a CSRFTokenStore that keeps the token in an
+// HttpOnly cookie.
+type CookieCSRFStore struct {
+	Name     string // defaults to "_csrf_token"
+	Path     string // defaults to "/"
+	Domain   string
+	Secure   bool
+	SameSite http.SameSite // defaults to http.SameSiteLaxMode
+}
+
+// Get returns the token in the cookie.
+func (s *CookieCSRFStore) Get(r *http.Request) (string, error) {
+	c, err := r.Cookie(s.name())
+	if err == http.ErrNoCookie {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return c.Value, nil
+}
+
+// Save sets the cookie to token.
+func (s *CookieCSRFStore) Save(w http.ResponseWriter, r *http.Request, token string) error {
+	path := s.Path
+	if path == "" {
+		path = "/"
+	}
+	sameSite := s.SameSite
+	if sameSite == 0 {
+		sameSite = http.SameSiteLaxMode
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     s.name(),
+		Value:    token,
+		Path:     path,
+		Domain:   s.Domain,
+		Secure:   s.Secure,
+		HttpOnly: true,
+		SameSite: sameSite,
+	})
+	return nil
+}
+
+func (s *CookieCSRFStore) name() string {
+	if s.Name == "" {
+		return "_csrf_token"
+	}
+	return s.Name
+}
+
+// CSRF protects against cross-site request forgery in the manner of
+// Rails' authenticity tokens and Rack::Protection. Every unsafe request
+// (anything but GET, HEAD, OPTIONS and TRACE) must carry the session's
+// token in the form field FieldName or the header HeaderName. Handlers
+// embed the token with CSRFToken, which masks it with a fresh one-time
+// pad on every call so the page never contains the same bytes twice
+// (which defeats BREACH).
+//
+// Before the token is checked, requests that a browser marks as
+// cross-site through Sec-Fetch-Site, or whose Origin is neither the
+// request's own host nor one of TrustedOrigins, are rejected.
+//
+//	csrf := &CSRF{SkipPaths: []string{"/webhooks/"}}
+//	http.ListenAndServe(":8080", csrf.Handler(app))
+//
+//	// in a template:
+//	<input type="hidden" name="authenticity_token" value="{{ .CSRFToken }}">
+type CSRF struct {
+	// Store keeps the per-session token. It defaults to a
+	// CookieCSRFStore.
+	Store CSRFTokenStore
+
+	FieldName  string // defaults to "authenticity_token"
+	HeaderName string // defaults to "X-CSRF-Token"
+
+	// TrustedOrigins lists other origins, such as
+	// "https://admin.example.com", allowed to make unsafe requests.
+	TrustedOrigins []string
+
+	// SkipPaths lists paths that are not checked. A path ending in "/"
+	// skips every path below it.
+	SkipPaths []string
+
+	// Skip, if set, exempts the requests for which it returns true.
+	Skip func(r *http.Request) bool
+
+	// Failure writes the response for a rejected request. It defaults
+	// to a plain 403 Forbidden.
+	Failure http.Handler
+}
+
+type csrfTokenKey struct{}
+
+// Handler returns next wrapped with CSRF protection.
+func (c *CSRF) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		store := c.store()
+		token, err := store.Get(r)
+		if err != nil || !validRawToken(token) {
+			token, err = newCSRFToken()
+			if err == nil {
+				err = store.Save(w, r, token)
+			}
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+		}
+		r = r.WithContext(context.WithValue(r.Context(), csrfTokenKey{}, token))
+
+		if isSafeMethod(r.Method) || c.skipped(r) || c.verified(r, token) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if c.Failure != nil {
+			c.Failure.ServeHTTP(w, r)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	})
+}
+
+// verified reports whether an unsafe request comes from an allowed origin
+// and carries a valid token.
+func (c *CSRF) verified(r *http.Request, token string) bool {
+	if r.Header.Get("Sec-Fetch-Site") == "cross-site" && !c.trustedOrigin(r.Header.Get("Origin")) {
+		return false
+	}
+	if origin := r.Header.Get("Origin"); origin != "" && !c.sameOrigin(r, origin) && !c.trustedOrigin(origin) {
+		return false
+	}
+
+	sent := r.Header.Get(c.headerName())
+	if sent == "" {
+		sent = r.PostFormValue(c.fieldName())
+	}
+	return VerifyCSRFToken(token, sent)
+}
+
+func (c *CSRF) sameOrigin(r *http.Request, origin string) bool {
+	u, err := url.Parse(origin)
+	return err == nil && u.Host != "" && strings.EqualFold(u.Host, r.Host)
+}
+
+func (c *CSRF) trustedOrigin(origin string) bool {
+	if origin == "" {
+		return false
+	}
+	for _, trusted := range c.TrustedOrigins {
+		if strings.EqualFold(trusted, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CSRF) skipped(r *http.Request) bool {
+	for _, path := range c.SkipPaths {
+		if path == r.URL.Path || strings.HasSuffix(path, "/") && strings.HasPrefix(r.URL.Path, path) {
+			return true
+		}
+	}
+	return c.Skip != nil && c.Skip(r)
+}
+
+func (c *CSRF) store() CSRFTokenStore {
+	if c.Store == nil {
+		return &CookieCSRFStore{}
+	}
+	return c.Store
+}
+
+func (c *CSRF) fieldName() string {
+	if c.FieldName == "" {
+		return "authenticity_token"
+	}
+	return c.FieldName
+}
+
+func (c *CSRF) headerName() string {
+	if c.HeaderName == "" {
+		return "X-CSRF-Token"
+	}
+	return c.HeaderName
+}
+
+// CSRFToken returns a freshly masked token for the request's session, for
+// embedding in a form or a meta tag. It returns "" outside a CSRF
+// handler.
+func CSRFToken(r *http.Request) string {
+	token, _ := r.Context().Value(csrfTokenKey{}).(string)
+	if token == "" {
+		return ""
+	}
+	masked, err := MaskCSRFToken(token)
+	if err != nil {
+		return ""
+	}
+	return masked
+}
+
+// MaskCSRFToken masks the raw token with a random one-time pad. The result
+// is the pad followed by the token XORed with the pad, base64url-encoded.
+func MaskCSRFToken(token string) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", err
+	}
+	pad := make([]byte, len(raw))
+	if _, err := rand.Read(pad); err != nil {
+		return "", err
+	}
+	masked := make([]byte, 0, 2*len(raw))
+	masked = append(masked, pad...)
+	for i, b := range raw {
+		masked = append(masked, b^pad[i])
+	}
+	return base64.RawURLEncoding.EncodeToString(masked), nil
+}
+
+// VerifyCSRFToken reports whether sent, masked or not, matches the raw
+// token. The comparison is made with SecureCompare.
+func VerifyCSRFToken(token, sent string) bool {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) != csrfTokenLength {
+		return false
+	}
+	b, err := base64.RawURLEncoding.DecodeString(sent)
+	if err != nil {
+		return false
+	}
+	switch len(b) {
+	case csrfTokenLength:
+		return SecureCompare(string(b), string(raw))
+	case 2 * csrfTokenLength:
+		pad, masked := b[:csrfTokenLength], b[csrfTokenLength:]
+		unmasked := make([]byte, csrfTokenLength)
+		for i := range masked {
+			unmasked[i] = masked[i] ^ pad[i]
+		}
+		return SecureCompare(string(unmasked), string(raw))
+	}
+	return false
+}
+
+func newCSRFToken() (string, error) {
+	b := make([]byte, csrfTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func validRawToken(token string) bool {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	return err == nil && len(raw) == csrfTokenLength
+}
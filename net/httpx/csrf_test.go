@@ -0,0 +1,87 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// csrfSession performs a GET through h and returns the session cookie and
+// a masked token.
+func csrfSession(t *testing.T, h http.Handler, token *string) *http.Cookie {
+	w := doRequest(h, "GET", "/form", nil)
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || !cookies[0].HttpOnly {
+		t.Fatalf("expected an HttpOnly token cookie but got %v", cookies)
+	}
+	if *token == "" {
+		t.Fatal("expected CSRFToken to return a token")
+	}
+	return cookies[0]
+}
+
+func Test_CSRF(t *testing.T) {
+	var token string
+	csrf := &CSRF{SkipPaths: []string{"/webhooks/"}}
+	h := csrf.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = CSRFToken(r)
+	}))
+	cookie := csrfSession(t, h, &token)
+
+	post := func(header http.Header, form url.Values, path string) int {
+		r := httptest.NewRequest("POST", path, strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.AddCookie(cookie)
+		for name, values := range header {
+			r.Header[name] = values
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	if code := post(nil, url.Values{"authenticity_token": {token}}, "/"); code != http.StatusOK {
+		t.Errorf("expected form token to be accepted but got %d", code)
+	}
+	if code := post(http.Header{"X-Csrf-Token": {token}, "Origin": {"http://example.com"}}, nil, "/"); code != http.StatusOK {
+		t.Errorf("expected header token from the same origin to be accepted but got %d", code)
+	}
+	if code := post(nil, nil, "/"); code != http.StatusForbidden {
+		t.Errorf("expected missing token to be rejected but got %d", code)
+	}
+	if code := post(nil, url.Values{"authenticity_token": {token[:len(token)-2] + "AA"}}, "/"); code != http.StatusForbidden {
+		t.Errorf("expected tampered token to be rejected but got %d", code)
+	}
+	if code := post(http.Header{"X-Csrf-Token": {token}, "Origin": {"https://evil.test"}}, nil, "/"); code != http.StatusForbidden {
+		t.Errorf("expected foreign origin to be rejected but got %d", code)
+	}
+	if code := post(http.Header{"X-Csrf-Token": {token}, "Sec-Fetch-Site": {"cross-site"}}, nil, "/"); code != http.StatusForbidden {
+		t.Errorf("expected cross-site fetch to be rejected but got %d", code)
+	}
+	if code := post(nil, nil, "/webhooks/github"); code != http.StatusOK {
+		t.Errorf("expected skipped path to be allowed but got %d", code)
+	}
+
+	csrf.TrustedOrigins = []string{"https://admin.example.com"}
+	if code := post(http.Header{"X-Csrf-Token": {token}, "Origin": {"https://admin.example.com"}, "Sec-Fetch-Site": {"cross-site"}}, nil, "/"); code != http.StatusOK {
+		t.Errorf("expected trusted origin to be accepted but got %d", code)
+	}
+}
+
+func Test_MaskCSRFToken(t *testing.T) {
+	raw, _ := newCSRFToken()
+	a, _ := MaskCSRFToken(raw)
+	b, _ := MaskCSRFToken(raw)
+	if a == b {
+		t.Error("expected masked tokens to differ")
+	}
+	if !VerifyCSRFToken(raw, a) || !VerifyCSRFToken(raw, b) || !VerifyCSRFToken(raw, raw) {
+		t.Error("expected masked and raw tokens to verify")
+	}
+	other, _ := newCSRFToken()
+	if VerifyCSRFToken(other, a) || VerifyCSRFToken(raw, "") || VerifyCSRFToken(raw, "!!") {
+		t.Error("expected foreign or malformed tokens not to verify")
+	}
+}
@@ -0,0 +1,171 @@
+package httpx
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layouts of the three HTTP-date formats of RFC 9110 section 5.6.7.
+const (
+	imfFixdateLayout = "Mon, 02 Jan 2006 15:04:05 GMT"
+	rfc850Layout     = "Monday, 02-Jan-06 15:04:05 GMT"
+	asctimeLayout    = "Mon Jan _2 15:04:05 2006"
+)
+
+// An HTTPDateError describes an HTTP-date that could not be parsed.
+type HTTPDateError struct {
+	Value  string
+	Format string // "IMF-fixdate", "RFC 850" or "asctime", if recognized
+	Err    error
+}
+
+func (e *HTTPDateError) Error() string {
+	if e.Format == "" {
+		return fmt.Sprintf("httpx: invalid HTTP-date %q: %v", e.Value, e.Err)
+	}
+	return fmt.Sprintf("httpx: invalid %s HTTP-date %q: %v", e.Format, e.Value, e.Err)
+}
+
+func (e *HTTPDateError) Unwrap() error {
+	return e.Err
+}
+
+// ParseHTTPDate parses an HTTP-date in any of the three formats a
+// recipient must accept: the preferred IMF-fixdate and the obsolete
+// RFC 850 and asctime formats. Two-digit RFC 850 years more than 50 years
+// in the future are taken to be in the past century. The result is in UTC.
+//
+//	ParseHTTPDate("Sun, 06 Nov 1994 08:49:37 GMT")    // 1994-11-06 08:49:37 UTC
+//	ParseHTTPDate("Sunday, 06-Nov-94 08:49:37 GMT")   // 1994-11-06 08:49:37 UTC
+//	ParseHTTPDate("Sun Nov  6 08:49:37 1994")         // 1994-11-06 08:49:37 UTC
+func ParseHTTPDate(value string) (time.Time, error) {
+	return parseHTTPDate(value, time.Now())
+}
+
+func parseHTTPDate(value string, now time.Time) (time.Time, error) {
+	comma := strings.IndexByte(value, ',')
+	switch {
+	case value == "":
+		return time.Time{}, &HTTPDateError{Value: value, Err: errors.New("empty value")}
+	case comma == 3:
+		return parseDateLayout(value, imfFixdateLayout, "IMF-fixdate", now)
+	case comma > 3:
+		return parseDateLayout(value, rfc850Layout, "RFC 850", now)
+	case comma == -1 && len(value) > 3 && value[3] == ' ':
+		return parseDateLayout(value, asctimeLayout, "asctime", now)
+	}
+	return time.Time{}, &HTTPDateError{Value: value, Err: errors.New("unrecognized format")}
+}
+
+// parseDateLayout parses value with layout, checking that the day name
+// agrees with the date.
+func parseDateLayout(value, layout, format string, now time.Time) (time.Time, error) {
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		if pe, ok := err.(*time.ParseError); ok && pe.Message != "" {
+			err = errors.New(strings.TrimPrefix(pe.Message, ": "))
+		} else if ok {
+			err = fmt.Errorf("cannot parse %q as %q", pe.ValueElem, pe.LayoutElem)
+		}
+		return time.Time{}, &HTTPDateError{Value: value, Format: format, Err: err}
+	}
+	if layout == rfc850Layout {
+		// time.Parse puts 00-68 in the 2000s; RFC 9110 instead wants the
+		// most recent year with those digits that is not more than 50
+		// years ahead.
+		year := now.UTC().Year()/100*100 + t.Year()%100
+		if year > now.UTC().Year()+50 {
+			year -= 100
+		}
+		t = time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	}
+	day := value[:strings.IndexAny(value, ", ")]
+	if !strings.EqualFold(day, t.Weekday().String()) && !strings.EqualFold(day, t.Weekday().String()[:3]) {
+		return time.Time{}, &HTTPDateError{
+			Value:  value,
+			Format: format,
+			Err:    fmt.Errorf("day name %s does not match date (%s)", day, t.Weekday()),
+		}
+	}
+	return t.UTC(), nil
+}
+
+// DeltaSeconds formats d as a non-negative count of whole seconds, as used
+// by the Age, Retry-After and max-age fields.
+//
+//	DeltaSeconds(90 * time.Second)      // "90"
+//	DeltaSeconds(-time.Second)          // "0"
+func DeltaSeconds(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	return strconv.FormatInt(int64(d/time.Second), 10)
+}
+
+// ParseDeltaSeconds parses a delta-seconds value into a time.Duration.
+func ParseDeltaSeconds(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, errors.New("httpx: empty delta-seconds")
+	}
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return 0, fmt.Errorf("httpx: invalid delta-seconds %q", value)
+		}
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n > int64(maxDeltaSeconds/time.Second) {
+		return maxDeltaSeconds, nil
+	}
+	return time.Duration(n) * time.Second, nil
+}
+
+// maxDeltaSeconds caps delta-seconds values that would overflow.
+const maxDeltaSeconds = (1<<31 - 1) * time.Second
+
+// Age returns the value of an Age header for a response that has been
+// held for d.
+func Age(d time.Duration) string {
+	return DeltaSeconds(d)
+}
+
+// ParseAge parses the value of an Age header.
+func ParseAge(value string) (time.Duration, error) {
+	return ParseDeltaSeconds(value)
+}
+
+// RetryAfter returns the value of a Retry-After header asking the client
+// to wait for d.
+func RetryAfter(d time.Duration) string {
+	return DeltaSeconds(d)
+}
+
+// RetryAfterDate returns the value of a Retry-After header asking the
+// client to wait until t.
+func RetryAfterDate(t time.Time) string {
+	return HTTPDate(t)
+}
+
+// ParseRetryAfter parses a Retry-After header given either as
+// delta-seconds or as an HTTP-date, returning how long after now the
+// client should wait. Dates in the past yield zero.
+//
+//	ParseRetryAfter("120", now)                              // 2m0s
+//	ParseRetryAfter("Fri, 31 Dec 1999 23:59:59 GMT", now)    // 0s
+func ParseRetryAfter(value string, now time.Time) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value != "" && value[0] >= '0' && value[0] <= '9' {
+		return ParseDeltaSeconds(value)
+	}
+	t, err := parseHTTPDate(value, now)
+	if err != nil {
+		return 0, err
+	}
+	if d := t.Sub(now); d > 0 {
+		return d, nil
+	}
+	return 0, nil
+}
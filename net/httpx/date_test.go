@@ -0,0 +1,88 @@
+package httpx
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_ParseHTTPDate(t *testing.T) {
+	want := time.Date(1994, 11, 6, 8, 49, 37, 0, time.UTC)
+	for _, value := range []string{
+		"Sun, 06 Nov 1994 08:49:37 GMT",
+		"Sunday, 06-Nov-94 08:49:37 GMT",
+		"Sun Nov  6 08:49:37 1994",
+	} {
+		got, err := ParseHTTPDate(value)
+		if err != nil {
+			t.Errorf("expected %q to parse but got %v", value, err)
+			continue
+		}
+		if !got.Equal(want) || got.Location() != time.UTC {
+			t.Errorf("expected %q to be %v but was %v", value, want, got)
+		}
+	}
+
+	if result := HTTPDate(want.In(time.FixedZone("EST", -5*3600))); result != "Sun, 06 Nov 1994 08:49:37 GMT" {
+		t.Errorf("expected HTTPDate to convert to UTC but got '%s'", result)
+	}
+}
+
+func Test_ParseHTTPDateTwoDigitYear(t *testing.T) {
+	now := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	got, err := parseHTTPDate("Sunday, 01-Jan-73 00:00:00 GMT", now)
+	if err != nil || got.Year() != 2073 {
+		t.Errorf("expected 73 to be 2073 but got %v (%v)", got, err)
+	}
+	got, err = parseHTTPDate("Tuesday, 01-Jan-74 00:00:00 GMT", now)
+	if err != nil || got.Year() != 1974 {
+		t.Errorf("expected 74 to be 1974 but got %v (%v)", got, err)
+	}
+}
+
+func Test_ParseHTTPDateErrors(t *testing.T) {
+	cases := map[string]string{
+		"":                               "",
+		"yesterday":                      "",
+		"Sun, 06 Nov 1994 08:49:37 UTC":  "IMF-fixdate",
+		"Mon, 06 Nov 1994 08:49:37 GMT":  "IMF-fixdate",
+		"Sunday, 06-Nov-94 25:49:37 GMT": "RFC 850",
+		"Sun Nov 31 08:49:37 1994":       "asctime",
+	}
+	for value, format := range cases {
+		_, err := ParseHTTPDate(value)
+		var de *HTTPDateError
+		if !errors.As(err, &de) {
+			t.Errorf("expected an HTTPDateError for %q but got %v", value, err)
+			continue
+		}
+		if de.Format != format {
+			t.Errorf("expected %q to be reported as %q but was %q", value, format, de.Format)
+		}
+	}
+}
+
+func Test_RetryAfter(t *testing.T) {
+	now := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+	if result := RetryAfter(90*time.Second + 500*time.Millisecond); result != "90" {
+		t.Errorf("expected '90' but got '%s'", result)
+	}
+	if result := Age(-time.Second); result != "0" {
+		t.Errorf("expected '0' but got '%s'", result)
+	}
+	if d, err := ParseRetryAfter("120", now); err != nil || d != 2*time.Minute {
+		t.Errorf("expected 2m but got %v (%v)", d, err)
+	}
+	if d, err := ParseRetryAfter(RetryAfterDate(now.Add(time.Hour)), now); err != nil || d != time.Hour {
+		t.Errorf("expected 1h but got %v (%v)", d, err)
+	}
+	if d, err := ParseRetryAfter("Fri, 31 Dec 1999 23:59:59 GMT", now); err != nil || d != 0 {
+		t.Errorf("expected past date to give 0 but got %v (%v)", d, err)
+	}
+	if _, err := ParseRetryAfter("-5", now); err == nil {
+		t.Error("expected an error for a negative delay")
+	}
+	if _, err := ParseAge("1.5"); err == nil {
+		t.Error("expected an error for a fractional age")
+	}
+}
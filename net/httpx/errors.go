@@ -0,0 +1,363 @@
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"strings"
+)
+
+// A StatusCoder is an error that chooses the status of its response.
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// StatusError attaches a status code to an error.
+//
+//	return &StatusError{Status: http.StatusConflict, Err: err}
+type StatusError struct {
+	Status int
+	Err    error
+}
+
+func (e *StatusError) Error() string {
+	if e.Err == nil {
+		return http.StatusText(e.Status)
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns the status.
+func (e *StatusError) StatusCode() int {
+	return e.Status
+}
+
+// ProblemFor maps err to a Problem:
+//
+//   - a *Problem anywhere in the chain is used as is;
+//   - a StatusCoder gives its status;
+//   - fs.ErrNotExist is 404 and fs.ErrPermission 403;
+//   - context.DeadlineExceeded is 503;
+//   - JSON syntax and type errors, and http.MaxBytesError, are mapped
+//     as ParseErrorProblem maps them;
+//   - anything else is 500.
+//
+// The title is the status text. Errors' messages become the detail for
+// client errors only, so that server errors do not leak internals.
+func ProblemFor(err error) *Problem {
+	var p *Problem
+	if errors.As(err, &p) {
+		return p
+	}
+
+	var (
+		coder    StatusCoder
+		syntax   *json.SyntaxError
+		typ      *json.UnmarshalTypeError
+		maxBytes *http.MaxBytesError
+	)
+	status := http.StatusInternalServerError
+	switch {
+	case errors.As(err, &coder):
+		status = coder.StatusCode()
+	case errors.Is(err, fs.ErrNotExist):
+		status = http.StatusNotFound
+	case errors.Is(err, fs.ErrPermission):
+		status = http.StatusForbidden
+	case errors.Is(err, context.DeadlineExceeded):
+		status = http.StatusServiceUnavailable
+	case errors.As(err, &syntax), errors.As(err, &typ), errors.As(err, &maxBytes):
+		return ParseErrorProblem(err)
+	}
+
+	p = NewProblem(status, "")
+	if status < 500 {
+		p.Detail = err.Error()
+	}
+	return p
+}
+
+// ErrorHandler lets handlers return errors, which it renders as problems
+// (see ProblemFor): as application/problem+json, or as an HTML page for
+// clients that prefer text/html, such as browsers. Server errors are
+// logged. Panics are recovered and rendered as 500 errors, except for
+// http.ErrAbortHandler.
+//
+// With Debug set, server errors are instead rendered like
+// Rack::ShowExceptions, with the error chain, the stack trace of panics
+// and the request. Never enable it in production.
+//
+//	eh := &ErrorHandler{Debug: os.Getenv("APP_ENV") == "development"}
+//	mux.Handle("/users/", eh.Func(func(w http.ResponseWriter, r *http.Request) error {
+//		user, err := users.Find(r.URL.Path)
+//		if err != nil {
+//			return err
+//		}
+//		return json.NewEncoder(w).Encode(user)
+//	}))
+type ErrorHandler struct {
+	Debug bool
+
+	// ErrorLog receives server errors. It defaults to the standard
+	// logger.
+	ErrorLog *log.Logger
+}
+
+// ErrorHandlerFunc is a handler that can fail.
+type ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// Func returns a handler calling fn and rendering the error it returns.
+func (e *ErrorHandler) Func(fn ErrorHandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ew := &errorResponseWriter{ResponseWriter: w}
+		defer e.recover(ew, r)
+		if err := fn(ew, r); err != nil {
+			e.render(ew, r, err, nil)
+		}
+	})
+}
+
+// Handler returns next wrapped so that its panics are rendered as errors.
+func (e *ErrorHandler) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ew := &errorResponseWriter{ResponseWriter: w}
+		defer e.recover(ew, r)
+		next.ServeHTTP(ew, r)
+	})
+}
+
+// WriteError renders err as the response to r.
+func (e *ErrorHandler) WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	e.render(&errorResponseWriter{ResponseWriter: w}, r, err, nil)
+}
+
+func (e *ErrorHandler) recover(w *errorResponseWriter, r *http.Request) {
+	v := recover()
+	if v == nil {
+		return
+	}
+	if v == http.ErrAbortHandler {
+		panic(v)
+	}
+	err, ok := v.(error)
+	if !ok {
+		err = fmt.Errorf("%v", v)
+	}
+	e.render(w, r, fmt.Errorf("panic: %w", err), debug.Stack())
+}
+
+func (e *ErrorHandler) render(w *errorResponseWriter, r *http.Request, err error, stack []byte) {
+	p := ProblemFor(err)
+	if p.Status == 0 || p.Status >= 500 {
+		if stack != nil {
+			e.logf("httpx: %s %s: %v\n%s", r.Method, r.URL.Path, err, stack)
+		} else {
+			e.logf("httpx: %s %s: %v", r.Method, r.URL.Path, err)
+		}
+	}
+	if w.written {
+		// The response is under way; all that can be done is log.
+		return
+	}
+
+	html := prefersHTML(r.Header.Get("Accept"))
+	if e.Debug && (p.Status == 0 || p.Status >= 500) {
+		if html {
+			e.renderDebugPage(w, r, p, err, stack)
+			return
+		}
+		p = copyProblem(p)
+		p.Detail = err.Error()
+		p.Extensions["errors"] = errorChain(err)
+		if stack != nil {
+			p.Extensions["stack"] = strings.Split(strings.TrimSpace(string(stack)), "\n")
+		}
+	}
+	if html {
+		renderProblemPage(w, p)
+		return
+	}
+	p.ServeHTTP(w, r)
+}
+
+func (e *ErrorHandler) logf(format string, args ...interface{}) {
+	if e.ErrorLog != nil {
+		e.ErrorLog.Printf(format, args...)
+	} else {
+		log.Printf(format, args...)
+	}
+}
+
+func copyProblem(p *Problem) *Problem {
+	c := *p
+	c.Extensions = make(map[string]interface{}, len(p.Extensions)+2)
+	for k, v := range p.Extensions {
+		c.Extensions[k] = v
+	}
+	return &c
+}
+
+// errorChain describes err and the errors it wraps, outermost first.
+func errorChain(err error) []string {
+	var chain []string
+	for ; err != nil; err = errors.Unwrap(err) {
+		chain = append(chain, fmt.Sprintf("%T: %v", err, err))
+	}
+	return chain
+}
+
+// prefersHTML reports whether the Accept header ranks text/html above
+// JSON. Ties go to JSON, so that API clients sending */* get JSON.
+func prefersHTML(accept string) bool {
+	if accept == "" {
+		return false
+	}
+	values := QValues(accept)
+	html := acceptQuality(values, "text/html")
+	json := acceptQuality(values, "application/problem+json")
+	if q := acceptQuality(values, "application/json"); q > json {
+		json = q
+	}
+	return html > json
+}
+
+// acceptQuality returns the quality the most specific matching media
+// range in values gives mediaType, or 0 if none matches.
+func acceptQuality(values []QValue, mediaType string) float64 {
+	quality, specificity := 0.0, -1
+	for _, v := range values {
+		rng := strings.ToLower(strings.TrimSpace(v.Value))
+		s := -1
+		switch {
+		case rng == mediaType:
+			s = 2
+		case rng == "*/*":
+			s = 0
+		case strings.HasSuffix(rng, "/*") && strings.HasPrefix(mediaType, rng[:len(rng)-1]):
+			s = 1
+		}
+		if s > specificity {
+			quality, specificity = v.Quality, s
+		}
+	}
+	return quality
+}
+
+var problemPage = template.Must(template.New("problem").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Status}} {{.Title}}</title></head>
+<body>
+<h1>{{.Status}} {{.Title}}</h1>
+{{if .Detail}}<p>{{.Detail}}</p>{{end}}
+</body>
+</html>
+`))
+
+func renderProblemPage(w http.ResponseWriter, p *Problem) {
+	status := p.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", "text/html; charset=utf-8")
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	problemPage.Execute(w, struct {
+		Status        int
+		Title, Detail string
+	}{status, p.Title, p.Detail})
+}
+
+var debugPage = template.Must(template.New("debug").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Type}} at {{.Request.URL.Path}}</title>
+<style>
+body { font-family: sans-serif; margin: 0; }
+header { background: #fcc; padding: 1em 2em; }
+section { padding: 0 2em; }
+pre { background: #f4f4f4; padding: 1em; overflow: auto; }
+th { text-align: left; padding-right: 1em; vertical-align: top; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Type}} at {{.Request.URL.Path}}</h1>
+<h2>{{.Message}}</h2>
+</header>
+<section>
+<h3>Errors</h3>
+<pre>{{range .Chain}}{{.}}
+{{end}}</pre>
+{{if .Stack}}<h3>Stack trace</h3>
+<pre>{{.Stack}}</pre>{{end}}
+<h3>Request</h3>
+<table>
+<tr><th>Method</th><td>{{.Request.Method}}</td></tr>
+<tr><th>URL</th><td>{{.Request.URL}}</td></tr>
+<tr><th>Remote address</th><td>{{.Request.RemoteAddr}}</td></tr>
+{{range $name, $values := .Request.Header}}<tr><th>{{$name}}</th><td>{{range $values}}{{.}}<br>{{end}}</td></tr>
+{{end}}</table>
+</section>
+</body>
+</html>
+`))
+
+func (e *ErrorHandler) renderDebugPage(w http.ResponseWriter, r *http.Request, p *Problem, err error, stack []byte) {
+	root := err
+	for errors.Unwrap(root) != nil {
+		root = errors.Unwrap(root)
+	}
+	status := p.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", "text/html; charset=utf-8")
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	debugPage.Execute(w, struct {
+		Type    string
+		Message string
+		Chain   []string
+		Stack   string
+		Request *http.Request
+	}{fmt.Sprintf("%T", root), err.Error(), errorChain(err), string(stack), r})
+}
+
+// errorResponseWriter records whether the response has been started, in
+// which case an error can no longer be rendered.
+type errorResponseWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (w *errorResponseWriter) WriteHeader(status int) {
+	w.written = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *errorResponseWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying ResponseWriter, for
+// http.ResponseController.
+func (w *errorResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
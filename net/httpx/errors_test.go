@@ -0,0 +1,132 @@
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func Test_ProblemFor(t *testing.T) {
+	custom := NewProblem(http.StatusPaymentRequired, "Out of credit.")
+	tests := []struct {
+		err    error
+		status int
+		detail string
+	}{
+		{fmt.Errorf("charging: %w", custom), 402, "Out of credit."},
+		{&StatusError{Status: http.StatusConflict, Err: errors.New("version mismatch")}, 409, "version mismatch"},
+		{fmt.Errorf("open: %w", fs.ErrNotExist), 404, "open: file does not exist"},
+		{fs.ErrPermission, 403, "permission denied"},
+		{context.DeadlineExceeded, 503, ""},
+		{json.Unmarshal([]byte("{"), &struct{}{}), 400, "The request could not be parsed: unexpected end of JSON input"},
+		{errors.New("db password is hunter2"), 500, ""},
+	}
+	for _, test := range tests {
+		p := ProblemFor(test.err)
+		if p.Status != test.status || p.Detail != test.detail || p.Title != http.StatusText(test.status) {
+			t.Errorf("expected %d '%s' for %v but got %+v", test.status, test.detail, test.err, p)
+		}
+	}
+}
+
+func Test_ErrorHandler(t *testing.T) {
+	var logged bytes.Buffer
+	eh := &ErrorHandler{ErrorLog: log.New(&logged, "", 0)}
+	h := eh.Func(func(w http.ResponseWriter, r *http.Request) error {
+		switch r.URL.Path {
+		case "/missing":
+			return &StatusError{Status: http.StatusNotFound, Err: errors.New("no such user")}
+		case "/broken":
+			return errors.New("secret failure")
+		case "/panic":
+			panic("boom")
+		case "/late":
+			w.Write([]byte("partial"))
+			return errors.New("too late")
+		}
+		w.Write([]byte("ok"))
+		return nil
+	})
+
+	if w := doRequest(h, "GET", "/", nil); w.Body.String() != "ok" {
+		t.Errorf("unexpected body '%s'", w.Body.String())
+	}
+
+	w := doRequest(h, "GET", "/missing", http.Header{"Accept": {"*/*"}})
+	if w.Code != 404 {
+		t.Errorf("expected 404 but got %d", w.Code)
+	}
+	if p := decodeProblem(t, w); p.Detail != "no such user" {
+		t.Errorf("unexpected problem %+v", p)
+	}
+	if logged.Len() != 0 {
+		t.Errorf("expected client errors not to be logged but got %q", logged.String())
+	}
+
+	w = doRequest(h, "GET", "/broken", http.Header{"Accept": {"text/html,application/xhtml+xml,*/*;q=0.8"}})
+	if w.Code != 500 || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/html") {
+		t.Errorf("expected an HTML 500 but got %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	if strings.Contains(w.Body.String(), "secret") {
+		t.Errorf("expected the error not to be shown but got %s", w.Body.String())
+	}
+	if !strings.Contains(logged.String(), "secret failure") {
+		t.Errorf("expected the error to be logged but got %q", logged.String())
+	}
+
+	logged.Reset()
+	w = doRequest(h, "GET", "/panic", nil)
+	if w.Code != 500 || !strings.Contains(logged.String(), "panic: boom") || !strings.Contains(logged.String(), "goroutine") {
+		t.Errorf("expected the panic to be rendered and logged but got %d %q", w.Code, logged.String())
+	}
+
+	w = doRequest(h, "GET", "/late", nil)
+	if w.Code != 200 || w.Body.String() != "partial" {
+		t.Errorf("expected a started response to be left alone but got %d '%s'", w.Code, w.Body.String())
+	}
+}
+
+func Test_ErrorHandlerDebug(t *testing.T) {
+	eh := &ErrorHandler{Debug: true, ErrorLog: log.New(&bytes.Buffer{}, "", 0)}
+	h := eh.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(fmt.Errorf("loading <user>: %w", fs.ErrClosed))
+	}))
+
+	w := doRequest(h, "GET", "/users/1", http.Header{"Accept": {"text/html"}})
+	body := w.Body.String()
+	for _, s := range []string{"at /users/1", "loading &lt;user&gt;", "Stack trace", "errors_test.go", "*errors.errorString"} {
+		if !strings.Contains(body, s) {
+			t.Errorf("expected the debug page to contain %q", s)
+		}
+	}
+
+	w = doRequest(h, "GET", "/users/1", nil)
+	p := decodeProblem(t, w)
+	if p.Detail != "panic: loading <user>: file already closed" || p.Extensions["stack"] == nil || len(p.Extensions["errors"].([]interface{})) != 3 {
+		t.Errorf("unexpected debug problem %+v", p)
+	}
+}
+
+func Test_PrefersHTML(t *testing.T) {
+	tests := map[string]bool{
+		"":                                    false,
+		"*/*":                                 false,
+		"text/html":                           true,
+		"application/json":                    false,
+		"text/html;q=0.5, application/*":      false,
+		"text/*, application/json;q=0.9":      true,
+		"application/problem+json, text/html": false,
+	}
+	for accept, expected := range tests {
+		if got := prefersHTML(accept); got != expected {
+			t.Errorf("expected %v for %q but got %v", expected, accept, got)
+		}
+	}
+}
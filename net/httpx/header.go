@@ -0,0 +1,110 @@
+package httpx
+
+import (
+	"strings"
+)
+
+// SplitHeaderList splits a comma-separated header value into its elements
+// as described in RFC 9110 section 5.6.1. Commas inside quoted strings do
+// not split, surrounding whitespace is trimmed and empty elements are
+// dropped.
+//
+//	SplitHeaderList(`no-cache, private="Set-Cookie, X-Foo"`)
+//	  // []string{"no-cache", `private="Set-Cookie, X-Foo"`}
+func SplitHeaderList(value string) []string {
+	var (
+		elems  []string
+		start  int
+		quoted bool
+	)
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			if quoted {
+				i++
+			}
+		case '"':
+			quoted = !quoted
+		case ',':
+			if quoted {
+				continue
+			}
+			if elem := strings.TrimSpace(value[start:i]); elem != "" {
+				elems = append(elems, elem)
+			}
+			start = i + 1
+		}
+	}
+	if start < len(value) {
+		if elem := strings.TrimSpace(value[start:]); elem != "" {
+			elems = append(elems, elem)
+		}
+	}
+	return elems
+}
+
+// Unquote removes the surrounding double quotes from an HTTP quoted-string
+// and resolves any quoted-pair escapes. Values that are not quoted are
+// returned unchanged.
+//
+//	Unquote(`"a \"b\""`)        // `a "b"`
+//	Unquote(`token`)            // "token"
+func Unquote(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+	s = s[1 : len(s)-1]
+	if strings.IndexByte(s, '\\') == -1 {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// Quote returns s as an HTTP quoted-string if it is not a valid token,
+// escaping any double quotes and backslashes. Tokens are returned as is.
+//
+//	Quote("gzip")           // "gzip"
+//	Quote("a b")            // `"a b"`
+func Quote(s string) string {
+	if isToken(s) {
+		return s
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// isToken reports whether s is a non-empty RFC 9110 token.
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isTokenChar(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isTokenChar reports whether c is a tchar.
+func isTokenChar(c byte) bool {
+	if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' {
+		return true
+	}
+	return strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0
+}
@@ -0,0 +1,31 @@
+package httpx
+
+import "testing"
+
+func Test_SplitHeaderList(t *testing.T) {
+	got := SplitHeaderList(` no-cache ,, private="Set-Cookie, X-Foo", max-age=0 `)
+	expected := []string{"no-cache", `private="Set-Cookie, X-Foo"`, "max-age=0"}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %q but got %q", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("expected element %d to be %q but was %q", i, expected[i], got[i])
+		}
+	}
+	if got := SplitHeaderList(`"a\", b", c`); len(got) != 2 {
+		t.Errorf("expected escaped quote to stay inside quoted string but got %q", got)
+	}
+}
+
+func Test_Quote(t *testing.T) {
+	if result := Quote("gzip"); result != "gzip" {
+		t.Errorf("expected 'gzip' but got '%s'", result)
+	}
+	if result := Quote(`a "b"`); result != `"a \"b\""` {
+		t.Errorf(`expected '"a \"b\""' but got '%s'`, result)
+	}
+	if result := Unquote(Quote(`a "b"`)); result != `a "b"` {
+		t.Errorf(`expected 'a "b"' but got '%s'`, result)
+	}
+}
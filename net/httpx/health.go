@@ -0,0 +1,280 @@
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// The health check groups. Liveness tells an orchestrator whether the
+// process must be restarted; readiness whether it may receive traffic.
+const (
+	HealthLive  = "live"
+	HealthReady = "ready"
+)
+
+// The statuses of a check and of a report.
+const (
+	HealthPass = "pass"
+	HealthFail = "fail"
+)
+
+// A HealthCheck is a named check. Check reports a failure by returning an
+// error, and should give up when ctx is done.
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+
+	// Timeout overrides Health.Timeout for this check.
+	Timeout time.Duration
+
+	// Groups lists the groups the check belongs to. It defaults to
+	// readiness only, since a failing dependency rarely calls for a
+	// restart.
+	Groups []string
+}
+
+// A HealthResult is the outcome of one check.
+type HealthResult struct {
+	Status  string
+	Latency time.Duration
+	Error   string
+}
+
+// MarshalJSON encodes the result with its latency in milliseconds.
+func (r HealthResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Status    string  `json:"status"`
+		LatencyMS float64 `json:"latency_ms"`
+		Error     string  `json:"error,omitempty"`
+	}{r.Status, float64(r.Latency) / float64(time.Millisecond), r.Error})
+}
+
+// A HealthReport is the outcome of all the checks of a group. Its status
+// fails if any check failed.
+type HealthReport struct {
+	Status string                  `json:"status"`
+	Checks map[string]HealthResult `json:"checks"`
+}
+
+// Health runs health checks and serves their results as JSON, with 200 OK
+// when every check passes and 503 Service Unavailable otherwise:
+//
+//	{"status":"fail","checks":{"db":{"status":"fail","latency_ms":1000.2,"error":"context deadline exceeded"}}}
+//
+// The checks of a group run concurrently, each under its own timeout, and
+// the report is cached for TTL so that frequent probes do not hammer the
+// dependencies.
+//
+//	health := NewHealth()
+//	health.Add(HealthCheck{Name: "db", Check: db.PingContext})
+//	health.Add(HealthCheck{Name: "deadlock", Check: checkWorkers, Groups: []string{HealthLive, HealthReady}})
+//	mux.Handle("/livez", health.Handler(HealthLive))
+//	mux.Handle("/readyz", health.Handler(HealthReady))
+type Health struct {
+	// Timeout bounds each check that does not set its own. It defaults
+	// to five seconds.
+	Timeout time.Duration
+
+	// TTL is how long a group's report is reused. Zero runs the checks on
+	// every request.
+	TTL time.Duration
+
+	// Now returns the current time. It defaults to time.Now.
+	Now func() time.Time
+
+	mu     sync.Mutex
+	checks []HealthCheck
+	groups map[string]*healthGroup
+}
+
+type healthGroup struct {
+	mu      sync.Mutex
+	report  *HealthReport
+	expires time.Time
+	running *healthRun
+}
+
+// healthRun is a run of a group's checks that concurrent callers wait for.
+type healthRun struct {
+	done   chan struct{}
+	report *HealthReport
+	// shared is whether the report may be given to the waiters, which it
+	// may not if the context of the caller that ran it ended.
+	shared bool
+}
+
+// NewHealth returns a Health with no checks.
+func NewHealth() *Health {
+	return &Health{}
+}
+
+// Add registers a check. It panics if a check of the same name was added,
+// since their results would share an entry in the report.
+func (h *Health) Add(check HealthCheck) {
+	if len(check.Groups) == 0 {
+		check.Groups = []string{HealthReady}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, c := range h.checks {
+		if c.Name == check.Name {
+			panic("httpx: duplicate health check name " + check.Name)
+		}
+	}
+	h.checks = append(h.checks, check)
+	h.groups = nil
+}
+
+// Handler returns a handler reporting on the checks of group.
+func (h *Health) Handler(group string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := h.Run(r.Context(), group)
+		body, err := json.Marshal(report)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if report.Status == HealthPass {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		w.Write(append(body, '\n'))
+	})
+}
+
+// Run runs the checks of group, or returns the cached report if it is
+// younger than TTL. Concurrent calls for the same group share one run,
+// taking the report of the run in progress. A report is neither cached
+// nor shared if ctx ended during the run, since its failures may be the
+// caller's rather than the dependencies'.
+func (h *Health) Run(ctx context.Context, group string) *HealthReport {
+	h.mu.Lock()
+	if h.groups == nil {
+		h.groups = make(map[string]*healthGroup)
+	}
+	g, ok := h.groups[group]
+	if !ok {
+		g = &healthGroup{}
+		h.groups[group] = g
+	}
+	var checks []HealthCheck
+	for _, c := range h.checks {
+		for _, name := range c.Groups {
+			if name == group {
+				checks = append(checks, c)
+				break
+			}
+		}
+	}
+	h.mu.Unlock()
+
+	for {
+		g.mu.Lock()
+		if g.report != nil && h.now().Before(g.expires) {
+			report := g.report
+			g.mu.Unlock()
+			return report
+		}
+		run := g.running
+		if run == nil {
+			break
+		}
+		g.mu.Unlock()
+		<-run.done
+		if run.shared {
+			return run.report
+		}
+	}
+	run := &healthRun{done: make(chan struct{})}
+	g.running = run
+	g.mu.Unlock()
+
+	report := h.runChecks(ctx, checks)
+
+	g.mu.Lock()
+	g.running = nil
+	run.report = report
+	run.shared = ctx.Err() == nil
+	if h.TTL > 0 && run.shared {
+		g.report = report
+		g.expires = h.now().Add(h.TTL)
+	}
+	g.mu.Unlock()
+	close(run.done)
+	return report
+}
+
+// runChecks runs checks concurrently and collects their results.
+func (h *Health) runChecks(ctx context.Context, checks []HealthCheck) *HealthReport {
+	report := &HealthReport{Status: HealthPass, Checks: make(map[string]HealthResult, len(checks))}
+	results := make([]HealthResult, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c HealthCheck) {
+			defer wg.Done()
+			results[i] = h.runCheck(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+	for i, c := range checks {
+		report.Checks[c.Name] = results[i]
+		if results[i].Status != HealthPass {
+			report.Status = HealthFail
+		}
+	}
+	return report
+}
+
+// runCheck runs one check under its timeout. A check that ignores its
+// context is abandoned when the timeout expires, and a panicking check
+// fails.
+func (h *Health) runCheck(ctx context.Context, c HealthCheck) HealthResult {
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = h.Timeout
+	}
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if v := recover(); v != nil {
+				done <- fmt.Errorf("httpx: health check %s panicked: %v", c.Name, v)
+			}
+		}()
+		done <- c.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	result := HealthResult{Status: HealthPass, Latency: time.Since(start)}
+	if err != nil {
+		result.Status = HealthFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func (h *Health) now() time.Time {
+	if h.Now == nil {
+		return time.Now()
+	}
+	return h.Now()
+}
@@ -0,0 +1,161 @@
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_HealthGroups(t *testing.T) {
+	health := NewHealth()
+	health.Add(HealthCheck{Name: "db", Check: func(ctx context.Context) error { return errors.New("connection refused") }})
+	health.Add(HealthCheck{Name: "workers", Check: func(ctx context.Context) error { return nil }, Groups: []string{HealthLive, HealthReady}})
+
+	w := doRequest(health.Handler(HealthLive), "GET", "/livez", nil)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected liveness to pass but got %d", w.Code)
+	}
+	var report struct {
+		Status string
+		Checks map[string]struct {
+			Status    string
+			LatencyMS *float64 `json:"latency_ms"`
+			Error     string
+		}
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if report.Status != HealthPass || len(report.Checks) != 1 || report.Checks["workers"].LatencyMS == nil {
+		t.Errorf("unexpected liveness report %s", w.Body.String())
+	}
+
+	w = doRequest(health.Handler(HealthReady), "GET", "/readyz", nil)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected readiness to fail but got %d", w.Code)
+	}
+	report.Checks = nil
+	json.Unmarshal(w.Body.Bytes(), &report)
+	if report.Status != HealthFail || report.Checks["db"].Error != "connection refused" || report.Checks["workers"].Status != HealthPass {
+		t.Errorf("unexpected readiness report %s", w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("unexpected Content-Type '%s'", ct)
+	}
+}
+
+func Test_HealthTimeoutAndPanic(t *testing.T) {
+	health := &Health{Timeout: 20 * time.Millisecond}
+	health.Add(HealthCheck{Name: "stuck", Check: func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}})
+	health.Add(HealthCheck{Name: "slow", Timeout: time.Second, Check: func(ctx context.Context) error {
+		time.Sleep(30 * time.Millisecond)
+		return nil
+	}})
+	health.Add(HealthCheck{Name: "broken", Check: func(ctx context.Context) error { panic("boom") }})
+
+	start := time.Now()
+	report := health.Run(context.Background(), HealthReady)
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected checks to run concurrently and time out but took %v", elapsed)
+	}
+	if r := report.Checks["stuck"]; r.Status != HealthFail || r.Error != context.DeadlineExceeded.Error() {
+		t.Errorf("expected stuck check to time out but got %+v", r)
+	}
+	if r := report.Checks["slow"]; r.Status != HealthPass || r.Latency < 30*time.Millisecond {
+		t.Errorf("expected slow check to pass but got %+v", r)
+	}
+	if r := report.Checks["broken"]; r.Status != HealthFail {
+		t.Errorf("expected panicking check to fail but got %+v", r)
+	}
+}
+
+func Test_HealthTTL(t *testing.T) {
+	clock := &testClock{now: time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)}
+	health := &Health{TTL: 10 * time.Second, Now: clock.Now}
+	var runs int32
+	health.Add(HealthCheck{Name: "db", Check: func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	}})
+
+	health.Run(context.Background(), HealthReady)
+	clock.Advance(5 * time.Second)
+	health.Run(context.Background(), HealthReady)
+	if n := atomic.LoadInt32(&runs); n != 1 {
+		t.Errorf("expected the cached report to be reused but the check ran %d times", n)
+	}
+	clock.Advance(5 * time.Second)
+	health.Run(context.Background(), HealthReady)
+	if n := atomic.LoadInt32(&runs); n != 2 {
+		t.Errorf("expected the check to run again after the TTL but it ran %d times", n)
+	}
+}
+
+func Test_HealthCanceledProbe(t *testing.T) {
+	health := &Health{TTL: time.Minute}
+	health.Add(HealthCheck{Name: "db", Check: func(ctx context.Context) error {
+		return ctx.Err()
+	}})
+	h := health.Handler(HealthReady)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r := httptest.NewRequest("GET", "/readyz", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected the canceled probe to fail but got %d", w.Code)
+	}
+	if w := doRequest(h, "GET", "/readyz", nil); w.Code != http.StatusOK {
+		t.Errorf("expected the next probe not to get the canceled report but got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func Test_HealthSharedRun(t *testing.T) {
+	health := NewHealth()
+	var runs int32
+	started, release := make(chan struct{}), make(chan struct{})
+	health.Add(HealthCheck{Name: "db", Check: func(ctx context.Context) error {
+		if atomic.AddInt32(&runs, 1) == 1 {
+			close(started)
+		}
+		<-release
+		return nil
+	}})
+
+	reports := make(chan *HealthReport, 5)
+	go func() { reports <- health.Run(context.Background(), HealthReady) }()
+	<-started
+	for i := 0; i < 4; i++ {
+		go func() { reports <- health.Run(context.Background(), HealthReady) }()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	for i := 0; i < 5; i++ {
+		if report := <-reports; report.Status != HealthPass {
+			t.Errorf("expected every caller to get the passing report but got %+v", report)
+		}
+	}
+	if n := atomic.LoadInt32(&runs); n != 1 {
+		t.Errorf("expected concurrent calls to share one run but the check ran %d times", n)
+	}
+}
+
+func Test_HealthDuplicateName(t *testing.T) {
+	health := NewHealth()
+	health.Add(HealthCheck{Name: "db", Check: func(ctx context.Context) error { return nil }})
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a duplicate check name to panic")
+		}
+	}()
+	health.Add(HealthCheck{Name: "db", Check: func(ctx context.Context) error { return nil }, Groups: []string{HealthLive}})
+}
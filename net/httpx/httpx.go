@@ -3,6 +3,7 @@
 package httpx
 
 import (
+	"crypto/subtle"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -44,6 +45,13 @@ func Unescape(s string) string {
 	return str
 }
 
+// SecureCompare compares two strings in constant time, so that the time
+// taken does not reveal how much of a secret an attacker guessed right.
+// Only the lengths of the strings may leak.
+func SecureCompare(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
 // A QValue represents a quality value header element.
 // Used by QValues to return values with their quality
 // preferences.
@@ -103,9 +111,11 @@ func QValues(header string) []QValue {
 	return values
 }
 
-// HTTPDate formats a time.Time for use in HTTP headers.
+// HTTPDate formats a time.Time for use in HTTP headers. The time is
+// converted to UTC first, as the format requires. See ParseHTTPDate for
+// the reverse.
 func HTTPDate(t time.Time) string {
-	return t.Format(http.TimeFormat)
+	return t.UTC().Format(http.TimeFormat)
 }
 
 type Range struct {
@@ -64,6 +64,15 @@ func Test_QValues(t *testing.T) {
 	checkQValues(t, expected, values)
 }
 
+func Test_SecureCompare(t *testing.T) {
+	if !SecureCompare("secret", "secret") {
+		t.Error("expected equal strings to compare equal")
+	}
+	if SecureCompare("secret", "secreT") || SecureCompare("secret", "secret!") || SecureCompare("", "x") {
+		t.Error("expected different strings to compare unequal")
+	}
+}
+
 type qvDiff struct {
 	Expected QValue
 	Actual   QValue
@@ -0,0 +1,122 @@
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Problem is an RFC 9457 problem details object, served as
+// application/problem+json. Extensions holds the extension members,
+// which are encoded alongside the standard ones; an extension cannot
+// override a standard member. A Problem is an error, so handlers can
+// return one (see ErrorHandler), and an http.Handler that writes itself.
+//
+//	p := NewProblem(http.StatusForbidden, "Your balance is too low.")
+//	p.Type = "https://example.com/probs/out-of-credit"
+//	p.Extensions = map[string]interface{}{"balance": 30}
+//	p.ServeHTTP(w, r)
+type Problem struct {
+	Type     string // defaults to "about:blank"
+	Title    string
+	Status   int
+	Detail   string
+	Instance string
+
+	Extensions map[string]interface{}
+}
+
+// ProblemDetails is Problem under the name RFC 9457 gives the object.
+type ProblemDetails = Problem
+
+// NewProblem returns a problem with the given status, the status
+// text as its title, and detail.
+func NewProblem(status int, detail string) *Problem {
+	return &Problem{Title: http.StatusText(status), Status: status, Detail: detail}
+}
+
+// Error returns the detail, or the title if there is none.
+func (p *Problem) Error() string {
+	if p.Detail != "" {
+		return p.Detail
+	}
+	if p.Title != "" {
+		return p.Title
+	}
+	return http.StatusText(p.Status)
+}
+
+var problemMembers = map[string]bool{"type": true, "title": true, "status": true, "detail": true, "instance": true}
+
+// MarshalJSON encodes the problem with its extension members.
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		if !problemMembers[k] {
+			m[k] = v
+		}
+	}
+	m["type"] = p.Type
+	if p.Type == "" {
+		m["type"] = "about:blank"
+	}
+	if p.Title != "" {
+		m["title"] = p.Title
+	}
+	if p.Status != 0 {
+		m["status"] = p.Status
+	}
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON decodes a problem, collecting unknown members into
+// Extensions. Standard members of the wrong type are ignored, as RFC 9457
+// requires.
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*p = Problem{}
+	p.Type, _ = m["type"].(string)
+	p.Title, _ = m["title"].(string)
+	if status, ok := m["status"].(float64); ok {
+		p.Status = int(status)
+	}
+	p.Detail, _ = m["detail"].(string)
+	p.Instance, _ = m["instance"].(string)
+	for k, v := range m {
+		if !problemMembers[k] {
+			if p.Extensions == nil {
+				p.Extensions = make(map[string]interface{})
+			}
+			p.Extensions[k] = v
+		}
+	}
+	return nil
+}
+
+// ServeHTTP writes the problem as application/problem+json, with its
+// status or 500 if it has none.
+func (p *Problem) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	status := p.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", "application/problem+json")
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	w.Write(append(body, '\n'))
+}
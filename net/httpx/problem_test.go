@@ -0,0 +1,33 @@
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func Test_ProblemJSON(t *testing.T) {
+	p := NewProblem(http.StatusForbidden, "Your balance is too low.")
+	p.Type = "https://example.com/probs/out-of-credit"
+	p.Extensions = map[string]interface{}{"balance": 30, "status": "ignored"}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	expected := `{"balance":30,"detail":"Your balance is too low.","status":403,"title":"Forbidden","type":"https://example.com/probs/out-of-credit"}`
+	if string(b) != expected {
+		t.Errorf("expected %s but got %s", expected, b)
+	}
+
+	var decoded Problem
+	if err := json.Unmarshal([]byte(`{"status":"bad","title":"T","extra":[1]}`), &decoded); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if decoded.Status != 0 || decoded.Title != "T" || decoded.Extensions["extra"] == nil {
+		t.Errorf("unexpected problem %+v", decoded)
+	}
+	if p.Error() != "Your balance is too low." {
+		t.Errorf("unexpected error string '%s'", p.Error())
+	}
+}
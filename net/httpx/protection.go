@@ -0,0 +1,226 @@
+package httpx
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Protection returns the protections that are safe to enable everywhere,
+// as Rack::Protection does by default: PathTraversal, FrameOptions,
+// ContentTypeOptions and ReferrerPolicy with their default settings.
+// HostAuthorization and HSTS need configuring and are added separately.
+func Protection() Middleware {
+	return NewBuilder(
+		(&PathTraversal{}).Handler,
+		(&FrameOptions{}).Handler,
+		(&ContentTypeOptions{}).Handler,
+		(&ReferrerPolicy{}).Handler,
+	).Middleware()
+}
+
+// PathTraversal normalizes the request path before it reaches the next
+// handler: percent-escapes are decoded with UnescapePath, so that
+// "%2e%2e%2f" cannot smuggle in a "../", and "." and ".." segments are
+// resolved without climbing above the root. A trailing slash is kept.
+type PathTraversal struct{}
+
+// Handler returns next wrapped with path normalization.
+func (p *PathTraversal) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cleaned := CleanPath(UnescapePath(r.URL.EscapedPath()))
+		if cleaned != r.URL.Path {
+			r2 := r.Clone(r.Context())
+			r2.URL.Path = cleaned
+			r2.URL.RawPath = ""
+			r = r2
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// CleanPath resolves "." and ".." segments in path and collapses repeated
+// slashes. Backslashes are treated as separators. The result always
+// starts with "/" and keeps a trailing slash.
+//
+//	CleanPath("/foo/../../etc/passwd")     // "/etc/passwd"
+//	CleanPath("/a/./b//c/")                // "/a/b/c/"
+func CleanPath(path string) string {
+	path = strings.ReplaceAll(path, "\\", "/")
+	var parts []string
+	for _, seg := range strings.Split(path, "/") {
+		switch seg {
+		case "", ".":
+		case "..":
+			if len(parts) > 0 {
+				parts = parts[:len(parts)-1]
+			}
+		default:
+			parts = append(parts, seg)
+		}
+	}
+	cleaned := "/" + strings.Join(parts, "/")
+	if cleaned != "/" && (strings.HasSuffix(path, "/") || strings.HasSuffix(path, "/.") || strings.HasSuffix(path, "/..")) {
+		cleaned += "/"
+	}
+	return cleaned
+}
+
+// HostAuthorization rejects requests for hosts that are not allowed, which
+// guards against DNS rebinding and Host header attacks. Entries of
+// AllowedHosts are either an exact host, ".example.com" for a domain and
+// all of its subdomains, or "*.example.com" for subdomains only. Ports are
+// ignored. X-Forwarded-Host, if present, must be allowed as well.
+//
+//	hosts := &HostAuthorization{AllowedHosts: []string{"example.com", "*.example.com"}}
+type HostAuthorization struct {
+	AllowedHosts        []string
+	AllowedHostPatterns []*regexp.Regexp
+
+	// Exclude, if set, exempts the requests for which it returns true,
+	// such as health checks.
+	Exclude func(r *http.Request) bool
+
+	// Blocked writes the response for a rejected request. It defaults to
+	// a plain 403 Forbidden.
+	Blocked http.Handler
+}
+
+// Handler returns next wrapped with host authorization.
+func (h *HostAuthorization) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allowed := h.AllowsHost(r.Host)
+		for _, v := range r.Header.Values("X-Forwarded-Host") {
+			for _, host := range strings.Split(v, ",") {
+				allowed = allowed && h.AllowsHost(strings.TrimSpace(host))
+			}
+		}
+		if allowed || h.Exclude != nil && h.Exclude(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if h.Blocked != nil {
+			h.Blocked.ServeHTTP(w, r)
+			return
+		}
+		http.Error(w, "Blocked host: "+stripPort(r.Host), http.StatusForbidden)
+	})
+}
+
+// AllowsHost reports whether host, with or without a port, is allowed.
+func (h *HostAuthorization) AllowsHost(host string) bool {
+	host = strings.ToLower(stripPort(host))
+	if host == "" {
+		return false
+	}
+	for _, allowed := range h.AllowedHosts {
+		allowed = strings.ToLower(allowed)
+		switch {
+		case strings.HasPrefix(allowed, "*."):
+			if strings.HasSuffix(host, allowed[1:]) {
+				return true
+			}
+		case strings.HasPrefix(allowed, "."):
+			if host == allowed[1:] || strings.HasSuffix(host, allowed) {
+				return true
+			}
+		case host == allowed:
+			return true
+		}
+	}
+	for _, re := range h.AllowedHostPatterns {
+		if loc := re.FindStringIndex(host); loc != nil && loc[0] == 0 && loc[1] == len(host) {
+			return true
+		}
+	}
+	return false
+}
+
+// FrameOptions controls whether the response may be framed by other
+// sites, against clickjacking. It sends X-Frame-Options (SAMEORIGIN by
+// default) and, if FrameAncestors is set, the equivalent CSP
+// frame-ancestors directive, appended to any Content-Security-Policy
+// already set by an outer middleware.
+type FrameOptions struct {
+	Value          string // "DENY" or "SAMEORIGIN"
+	FrameAncestors []string
+}
+
+// Handler returns next wrapped with frame options.
+func (f *FrameOptions) Handler(next http.Handler) http.Handler {
+	value := f.Value
+	if value == "" {
+		value = "SAMEORIGIN"
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		h.Set("X-Frame-Options", value)
+		if len(f.FrameAncestors) > 0 {
+			directive := "frame-ancestors " + strings.Join(f.FrameAncestors, " ")
+			if csp := h.Get("Content-Security-Policy"); csp == "" {
+				h.Set("Content-Security-Policy", directive)
+			} else if !strings.Contains(csp, "frame-ancestors") {
+				h.Set("Content-Security-Policy", csp+"; "+directive)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ContentTypeOptions sends X-Content-Type-Options: nosniff, so browsers
+// keep to the declared Content-Type.
+type ContentTypeOptions struct{}
+
+// Handler returns next wrapped with X-Content-Type-Options.
+func (c *ContentTypeOptions) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ReferrerPolicy sends a Referrer-Policy header, by default
+// strict-origin-when-cross-origin.
+type ReferrerPolicy struct {
+	Policy string
+}
+
+// Handler returns next wrapped with Referrer-Policy.
+func (p *ReferrerPolicy) Handler(next http.Handler) http.Handler {
+	policy := p.Policy
+	if policy == "" {
+		policy = "strict-origin-when-cross-origin"
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Referrer-Policy", policy)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// HSTS sends Strict-Transport-Security so that browsers only use HTTPS
+// for the site. MaxAge defaults to one year.
+type HSTS struct {
+	MaxAge            time.Duration
+	IncludeSubdomains bool
+	Preload           bool
+}
+
+// Handler returns next wrapped with Strict-Transport-Security.
+func (s *HSTS) Handler(next http.Handler) http.Handler {
+	maxAge := s.MaxAge
+	if maxAge == 0 {
+		maxAge = 365 * 24 * time.Hour
+	}
+	value := "max-age=" + DeltaSeconds(maxAge)
+	if s.IncludeSubdomains {
+		value += "; includeSubDomains"
+	}
+	if s.Preload {
+		value += "; preload"
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Strict-Transport-Security", value)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,101 @@
+package httpx
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func Test_CleanPath(t *testing.T) {
+	cases := map[string]string{
+		"/":                       "/",
+		"":                        "/",
+		"/foo/../../etc/passwd":   "/etc/passwd",
+		"/a/./b//c/":              "/a/b/c/",
+		"/a/b/..":                 "/a/",
+		"/a\\..\\..\\b":           "/b",
+		"/static/../../../secret": "/secret",
+	}
+	for path, want := range cases {
+		if got := CleanPath(path); got != want {
+			t.Errorf("expected CleanPath(%q) to be %q but got %q", path, want, got)
+		}
+	}
+}
+
+func Test_PathTraversal(t *testing.T) {
+	var path string
+	h := (&PathTraversal{}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+	}))
+	doRequest(h, "GET", "/static/%2e%2e/%2e%2e%2fetc/passwd", nil)
+	if path != "/etc/passwd" {
+		t.Errorf("expected encoded traversal to be resolved but got %q", path)
+	}
+}
+
+func Test_HostAuthorization(t *testing.T) {
+	hosts := &HostAuthorization{
+		AllowedHosts:        []string{"example.com", "*.example.org", ".example.net"},
+		AllowedHostPatterns: []*regexp.Regexp{regexp.MustCompile(`tenant-\d+\.example\.io`)},
+	}
+	cases := map[string]bool{
+		"example.com":          true,
+		"EXAMPLE.com:8080":     true,
+		"www.example.com":      false,
+		"a.example.org":        true,
+		"example.org":          false,
+		"example.net":          true,
+		"a.b.example.net":      true,
+		"badexample.net":       false,
+		"tenant-42.example.io": true,
+		"tenant-x.example.io":  false,
+		"":                     false,
+	}
+	for host, want := range cases {
+		if got := hosts.AllowsHost(host); got != want {
+			t.Errorf("expected %q allowed: %v but got %v", host, want, got)
+		}
+	}
+
+	h := hosts.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	if w := doRequest(h, "GET", "http://evil.test/", nil); w.Code != http.StatusForbidden {
+		t.Errorf("expected blocked host to get 403 but got %d", w.Code)
+	}
+	if w := doRequest(h, "GET", "http://example.com/", http.Header{"X-Forwarded-Host": {"evil.test"}}); w.Code != http.StatusForbidden {
+		t.Errorf("expected blocked forwarded host to get 403 but got %d", w.Code)
+	}
+	if w := doRequest(h, "GET", "http://example.com/", nil); w.Code != http.StatusOK {
+		t.Errorf("expected allowed host to pass but got %d", w.Code)
+	}
+}
+
+func Test_SecurityHeaders(t *testing.T) {
+	app := NewBuilder(
+		Protection(),
+		(&FrameOptions{Value: "DENY", FrameAncestors: []string{CSPNone}}).Handler,
+		(&HSTS{IncludeSubdomains: true, Preload: true}).Handler,
+	).Run(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	w := doRequest(app, "GET", "/", nil)
+
+	expected := map[string]string{
+		"X-Frame-Options":           "DENY",
+		"Content-Security-Policy":   "frame-ancestors 'none'",
+		"X-Content-Type-Options":    "nosniff",
+		"Referrer-Policy":           "strict-origin-when-cross-origin",
+		"Strict-Transport-Security": "max-age=31536000; includeSubDomains; preload",
+	}
+	for name, value := range expected {
+		if got := w.Header().Get(name); got != value {
+			t.Errorf("expected %s to be '%s' but was '%s'", name, value, got)
+		}
+	}
+
+	csp := NewContentSecurityPolicy().DefaultSrc(CSPSelf)
+	app = NewBuilder(csp.Handler, (&FrameOptions{FrameAncestors: []string{CSPSelf}}).Handler).
+		Run(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	if got := doRequest(app, "GET", "/", nil).Header().Get("Content-Security-Policy"); !strings.HasSuffix(got, "; frame-ancestors 'self'") {
+		t.Errorf("expected frame-ancestors to be appended but got '%s'", got)
+	}
+}
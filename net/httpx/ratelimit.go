@@ -0,0 +1,372 @@
+package httpx
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimitAlgorithm selects how a RateLimit counts requests.
+type RateLimitAlgorithm int
+
+const (
+	// TokenBucket allows bursts of up to Limit requests and refills at
+	// Limit requests per Window.
+	TokenBucket RateLimitAlgorithm = iota
+
+	// SlidingWindow allows Limit requests in any rolling Window,
+	// estimated from the counts of the current and previous windows.
+	SlidingWindow
+)
+
+// A RateLimit is a quota of Limit requests per Window. Both must be
+// positive, and Window at least Limit nanoseconds, except in the zero
+// RateLimit, which doesn't limit requests.
+type RateLimit struct {
+	Algorithm RateLimitAlgorithm
+	Limit     int
+	Window    time.Duration
+}
+
+// validate reports an error if l is neither a usable quota nor zero.
+func (l RateLimit) validate() error {
+	if l.Limit == 0 && l.Window == 0 {
+		return nil
+	}
+	if l.Limit <= 0 || l.Window <= 0 || l.Window < time.Duration(l.Limit) {
+		return fmt.Errorf("httpx: invalid rate limit of %d requests per %v", l.Limit, l.Window)
+	}
+	return nil
+}
+
+// A RateLimitRule applies Limit to requests matching Method and Path. An
+// empty Method matches any method; a Path ending in "/" matches every
+// path below it.
+type RateLimitRule struct {
+	Method string
+	Path   string
+	Limit  RateLimit
+}
+
+// RateLimitState is the per-key state kept by a RateLimitStore. For a
+// token bucket Value holds the remaining tokens and Time the last refill;
+// for a sliding window Value and Prev hold the current and previous
+// window's counts and Time the current window's start.
+type RateLimitState struct {
+	Value float64
+	Prev  float64
+	Time  time.Time
+}
+
+// A RateLimitStore holds rate limiting state. Update must atomically
+// replace the state stored under key with the result of fn, which is
+// passed the current state and whether there was one, and keep it for at
+// least ttl. Stores backed by a remote database may call fn more than
+// once, for instance when retrying an optimistic transaction.
+type RateLimitStore interface {
+	Update(key string, ttl time.Duration, fn func(state RateLimitState, ok bool) RateLimitState) error
+}
+
+// A RateLimitResult is the outcome of counting one request.
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// A KeyFunc returns the key that a request is counted against. An empty
+// key exempts the request from rate limiting.
+type KeyFunc func(r *http.Request) string
+
+// RateLimiter is a rate limiting middleware. Requests are counted per key
+// in Store against the first matching rule in Rules, or Default if none
+// match. Every response carries RateLimit-Limit, RateLimit-Remaining,
+// RateLimit-Reset and RateLimit-Policy headers; rejected requests get a
+// 429 Too Many Requests response with Retry-After.
+//
+//	limiter := NewRateLimiter(NewMemoryRateLimitStore(), RateLimit{Limit: 100, Window: time.Minute})
+//	limiter.Rules = []RateLimitRule{
+//		{Method: "POST", Path: "/login", Limit: RateLimit{Algorithm: SlidingWindow, Limit: 5, Window: time.Minute}},
+//	}
+//	http.ListenAndServe(":8080", limiter.Handler(app))
+type RateLimiter struct {
+	Store   RateLimitStore
+	Default RateLimit
+	Rules   []RateLimitRule
+
+	// Key returns the key requests are counted against. It defaults to
+	// ClientIPKey().
+	Key KeyFunc
+
+	// Limited writes the response for a rejected request after the
+	// rate limit headers have been set. It defaults to a plain 429.
+	Limited http.Handler
+
+	// Now returns the current time. It defaults to time.Now. Stores
+	// keep their own time for expiring state, as MemoryRateLimitStore.Now
+	// does, so a replaced clock should be given to the store too.
+	Now func() time.Time
+
+	// ErrorLog receives store errors, for which requests are allowed
+	// through. If nil, errors are logged through the log package.
+	ErrorLog *log.Logger
+}
+
+// NewRateLimiter returns a RateLimiter applying limit to every request.
+func NewRateLimiter(store RateLimitStore, limit RateLimit) *RateLimiter {
+	return &RateLimiter{Store: store, Default: limit}
+}
+
+// Handler returns next wrapped with the rate limiter. It panics if Default
+// or the limit of a rule is invalid.
+func (rl *RateLimiter) Handler(next http.Handler) http.Handler {
+	if err := rl.Default.validate(); err != nil {
+		panic(err)
+	}
+	for _, rule := range rl.Rules {
+		if err := rule.Limit.validate(); err != nil {
+			panic(fmt.Errorf("%w for %s %s", err, rule.Method, rule.Path))
+		}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keyFn := rl.Key
+		if keyFn == nil {
+			keyFn = ClientIPKey()
+		}
+		key := keyFn(r)
+		limit, name := rl.limitFor(r)
+		if key == "" || limit.Limit <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		res, err := rl.Take(name+"\x00"+key, limit)
+		if err != nil {
+			if rl.ErrorLog != nil {
+				rl.ErrorLog.Printf("httpx: rate limit store: %v", err)
+			} else {
+				log.Printf("httpx: rate limit store: %v", err)
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h := w.Header()
+		h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+		h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		h.Set("RateLimit-Reset", DeltaSeconds(res.Reset+time.Second-1))
+		h.Set("RateLimit-Policy", strconv.Itoa(limit.Limit)+";w="+DeltaSeconds(limit.Window))
+		if res.Allowed {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h.Set("Retry-After", RetryAfter(res.RetryAfter+time.Second-1))
+		if rl.Limited != nil {
+			rl.Limited.ServeHTTP(w, r)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+	})
+}
+
+// Take counts one request for key against limit. It fails if limit is
+// invalid, and allows every request if limit is zero.
+func (rl *RateLimiter) Take(key string, limit RateLimit) (RateLimitResult, error) {
+	if err := limit.validate(); err != nil {
+		return RateLimitResult{}, err
+	}
+	if limit.Limit == 0 {
+		return RateLimitResult{Allowed: true}, nil
+	}
+	now := time.Now()
+	if rl.Now != nil {
+		now = rl.Now()
+	}
+
+	var res RateLimitResult
+	var update func(RateLimitState, bool) RateLimitState
+	switch limit.Algorithm {
+	case SlidingWindow:
+		update = func(s RateLimitState, ok bool) RateLimitState {
+			s, res = slidingWindow(limit, s, ok, now)
+			return s
+		}
+	default:
+		update = func(s RateLimitState, ok bool) RateLimitState {
+			s, res = tokenBucket(limit, s, ok, now)
+			return s
+		}
+	}
+	err := rl.Store.Update(key, 2*limit.Window, update)
+	return res, err
+}
+
+// limitFor returns the limit that applies to r and a name for it that
+// keeps the counts of different rules apart.
+func (rl *RateLimiter) limitFor(r *http.Request) (RateLimit, string) {
+	for _, rule := range rl.Rules {
+		if rule.Method != "" && rule.Method != r.Method {
+			continue
+		}
+		if rule.Path == r.URL.Path || strings.HasSuffix(rule.Path, "/") && strings.HasPrefix(r.URL.Path, rule.Path) {
+			return rule.Limit, rule.Method + " " + rule.Path
+		}
+	}
+	return rl.Default, ""
+}
+
+// tokenBucket refills the bucket in s for the time elapsed since its last
+// refill and takes a token from it if one is available.
+func tokenBucket(limit RateLimit, s RateLimitState, ok bool, now time.Time) (RateLimitState, RateLimitResult) {
+	capacity := float64(limit.Limit)
+	perToken := limit.Window / time.Duration(limit.Limit)
+	if !ok {
+		s = RateLimitState{Value: capacity, Time: now}
+	}
+	if elapsed := now.Sub(s.Time); elapsed > 0 {
+		s.Value = math.Min(capacity, s.Value+float64(elapsed)/float64(perToken))
+	}
+	s.Time = now
+
+	res := RateLimitResult{Limit: limit.Limit}
+	if s.Value >= 1 {
+		s.Value--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = time.Duration((1 - s.Value) * float64(perToken))
+	}
+	res.Remaining = int(s.Value)
+	res.Reset = time.Duration((capacity - s.Value) * float64(perToken))
+	return s, res
+}
+
+// slidingWindow counts the request in s if the weighted count of the
+// current and previous windows leaves room for it.
+func slidingWindow(limit RateLimit, s RateLimitState, ok bool, now time.Time) (RateLimitState, RateLimitResult) {
+	start := now.Truncate(limit.Window)
+	switch {
+	case ok && s.Time.Equal(start):
+	case ok && s.Time.Equal(start.Add(-limit.Window)):
+		s = RateLimitState{Prev: s.Value, Time: start}
+	default:
+		s = RateLimitState{Time: start}
+	}
+
+	elapsed := now.Sub(start)
+	weight := 1 - float64(elapsed)/float64(limit.Window)
+	count := s.Prev*weight + s.Value
+
+	res := RateLimitResult{Limit: limit.Limit, Reset: limit.Window - elapsed}
+	if count+1 <= float64(limit.Limit) {
+		s.Value++
+		count++
+		res.Allowed = true
+	} else if s.Prev > 0 && s.Value+1 <= float64(limit.Limit) {
+		// Wait until the previous window's share has decayed enough.
+		need := 1 - (float64(limit.Limit)-1-s.Value)/s.Prev
+		res.RetryAfter = time.Duration(need*float64(limit.Window)) - elapsed
+	} else {
+		res.RetryAfter = res.Reset
+	}
+	res.Remaining = int(math.Max(0, float64(limit.Limit)-count))
+	return s, res
+}
+
+// MemoryRateLimitStore is an in-process RateLimitStore. Expired keys are
+// swept periodically as the store is used.
+type MemoryRateLimitStore struct {
+	// Now returns the current time, for expiring state. It defaults to
+	// time.Now and should match the RateLimiter's clock.
+	Now func() time.Time
+
+	mu        sync.Mutex
+	items     map[string]memoryRateLimitItem
+	nextSweep time.Time
+}
+
+type memoryRateLimitItem struct {
+	state   RateLimitState
+	expires time.Time
+}
+
+// NewMemoryRateLimitStore returns an empty MemoryRateLimitStore.
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{items: make(map[string]memoryRateLimitItem)}
+}
+
+// Update applies fn to the state stored under key.
+func (m *MemoryRateLimitStore) Update(key string, ttl time.Duration, fn func(RateLimitState, bool) RateLimitState) error {
+	now := time.Now()
+	if m.Now != nil {
+		now = m.Now()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if now.After(m.nextSweep) {
+		for k, item := range m.items {
+			if now.After(item.expires) {
+				delete(m.items, k)
+			}
+		}
+		m.nextSweep = now.Add(time.Minute)
+	}
+
+	item, ok := m.items[key]
+	if ok && now.After(item.expires) {
+		ok = false
+	}
+	m.items[key] = memoryRateLimitItem{state: fn(item.state, ok), expires: now.Add(ttl)}
+	return nil
+}
+
+// ClientIPKey returns a KeyFunc that counts requests per client IP
+// address, as resolved by ClientIP with the given trusted proxies.
+func ClientIPKey(trusted ...*net.IPNet) KeyFunc {
+	return func(r *http.Request) string {
+		return ClientIP(r, trusted...)
+	}
+}
+
+// HeaderKey returns a KeyFunc that counts requests per value of the named
+// header, such as an API key. Requests without the header are not
+// limited.
+func HeaderKey(name string) KeyFunc {
+	return func(r *http.Request) string {
+		return r.Header.Get(name)
+	}
+}
+
+// CookieKey returns a KeyFunc that counts requests per value of the named
+// cookie. Requests without the cookie are not limited.
+func CookieKey(name string) KeyFunc {
+	return func(r *http.Request) string {
+		c, err := r.Cookie(name)
+		if err != nil {
+			return ""
+		}
+		return c.Value
+	}
+}
+
+// RouteKey returns a KeyFunc that counts requests per method and path on
+// top of key, so that each route has its own quota.
+func RouteKey(key KeyFunc) KeyFunc {
+	return func(r *http.Request) string {
+		k := key(r)
+		if k == "" {
+			return ""
+		}
+		return r.Method + " " + r.URL.Path + "\x00" + k
+	}
+}
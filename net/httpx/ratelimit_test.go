@@ -0,0 +1,136 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_RateLimiterTokenBucket(t *testing.T) {
+	clock := &testClock{now: time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)}
+	rl := NewRateLimiter(NewMemoryRateLimitStore(), RateLimit{Limit: 3, Window: 3 * time.Second})
+	rl.Now = clock.Now
+	h := rl.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for i := 0; i < 3; i++ {
+		if w := doRequest(h, "GET", "/", nil); w.Code != http.StatusOK {
+			t.Fatalf("expected request %d to be allowed but got %d", i+1, w.Code)
+		}
+	}
+	w := doRequest(h, "GET", "/", nil)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 but got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") != "1" || w.Header().Get("RateLimit-Remaining") != "0" {
+		t.Errorf("unexpected headers %v", w.Header())
+	}
+	if w.Header().Get("RateLimit-Policy") != "3;w=3" {
+		t.Errorf("expected policy '3;w=3' but got '%s'", w.Header().Get("RateLimit-Policy"))
+	}
+	clock.Advance(time.Second)
+	if w := doRequest(h, "GET", "/", nil); w.Code != http.StatusOK {
+		t.Errorf("expected a refilled token but got %d", w.Code)
+	}
+}
+
+func Test_RateLimiterSlidingWindow(t *testing.T) {
+	clock := &testClock{now: time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)}
+	limit := RateLimit{Algorithm: SlidingWindow, Limit: 4, Window: time.Minute}
+	rl := NewRateLimiter(NewMemoryRateLimitStore(), limit)
+	rl.Now = clock.Now
+
+	for i := 0; i < 4; i++ {
+		if res, _ := rl.Take("k", limit); !res.Allowed {
+			t.Fatalf("expected request %d to be allowed", i+1)
+		}
+	}
+	if res, _ := rl.Take("k", limit); res.Allowed || res.RetryAfter != time.Minute {
+		t.Errorf("expected rejection until the window ends but got %+v", res)
+	}
+	// Half way through the next window, half of the previous count remains.
+	clock.Advance(90 * time.Second)
+	for i := 0; i < 2; i++ {
+		if res, _ := rl.Take("k", limit); !res.Allowed {
+			t.Fatalf("expected request %d in the next window to be allowed", i+1)
+		}
+	}
+	if res, _ := rl.Take("k", limit); res.Allowed || res.RetryAfter != 15*time.Second {
+		t.Errorf("expected to wait for the previous window to decay but got %+v", res)
+	}
+}
+
+func Test_RateLimiterRulesAndKeys(t *testing.T) {
+	rl := NewRateLimiter(NewMemoryRateLimitStore(), RateLimit{Limit: 100, Window: time.Minute})
+	rl.Rules = []RateLimitRule{{Method: "POST", Path: "/login", Limit: RateLimit{Limit: 1, Window: time.Minute}}}
+	rl.Key = HeaderKey("X-Api-Key")
+	h := rl.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	alice := http.Header{"X-Api-Key": {"alice"}}
+	bob := http.Header{"X-Api-Key": {"bob"}}
+	doRequest(h, "POST", "/login", alice)
+	if w := doRequest(h, "POST", "/login", alice); w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected route limit to apply but got %d", w.Code)
+	}
+	if w := doRequest(h, "POST", "/login", bob); w.Code != http.StatusOK {
+		t.Errorf("expected separate key to be allowed but got %d", w.Code)
+	}
+	if w := doRequest(h, "GET", "/login", alice); w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "100" {
+		t.Errorf("expected default limit for GET but got %d %v", w.Code, w.Header())
+	}
+	if w := doRequest(h, "POST", "/login", nil); w.Header().Get("RateLimit-Limit") != "" {
+		t.Error("expected requests without a key not to be limited")
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	if key := CookieKey("session")(r); key != "abc" {
+		t.Errorf("expected cookie key 'abc' but got '%s'", key)
+	}
+	if key := RouteKey(CookieKey("session"))(r); key != "GET /\x00abc" {
+		t.Errorf("unexpected route key %q", key)
+	}
+}
+
+func Test_RateLimitValidation(t *testing.T) {
+	rl := NewRateLimiter(NewMemoryRateLimitStore(), RateLimit{})
+	for _, limit := range []RateLimit{
+		{Algorithm: SlidingWindow, Limit: 10},
+		{Limit: -1, Window: time.Minute},
+		{Limit: 10, Window: 5},
+	} {
+		if _, err := rl.Take("k", limit); err == nil {
+			t.Errorf("expected %+v to be rejected", limit)
+		}
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected a rule limited to %+v to panic", limit)
+				}
+			}()
+			rl.Rules = []RateLimitRule{{Path: "/", Limit: limit}}
+			rl.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		}()
+	}
+	if res, err := rl.Take("k", RateLimit{}); err != nil || !res.Allowed {
+		t.Errorf("expected the zero limit to allow requests but got %+v (%v)", res, err)
+	}
+}
+
+func Test_MemoryRateLimitStoreClock(t *testing.T) {
+	clock := &testClock{now: time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)}
+	store := NewMemoryRateLimitStore()
+	store.Now = clock.Now
+	store.Update("k", time.Minute, func(s RateLimitState, ok bool) RateLimitState {
+		return RateLimitState{Value: 1}
+	})
+	clock.Advance(2 * time.Minute)
+	var found bool
+	store.Update("k", time.Minute, func(s RateLimitState, ok bool) RateLimitState {
+		found = ok
+		return s
+	})
+	if found {
+		t.Errorf("expected the state to expire on the store's clock")
+	}
+}
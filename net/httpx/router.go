@@ -0,0 +1,569 @@
+package httpx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Router dispatches requests to handlers by method and path, using Rails'
+// route syntax:
+//
+//   - ":name" matches one segment, up to the next "/", "." or "?".
+//   - "*name" matches the rest of the path, slashes included.
+//   - Parentheses make part of the pattern optional, as in
+//     "/users/:id(.:format)", and may be nested.
+//
+// Routes are tried in the order they were added. Matched parameters are
+// available to handlers through Param and RouteParams, unescaped. Named
+// routes can be turned back into paths with URL.
+//
+//	rt := NewRouter()
+//	rt.Get("/users/:id(.:format)", showUser).Named("user").
+//		Constrain("id", regexp.MustCompile(`\d+`))
+//	rt.Get("/files/*path", serveFile)
+//	rt.Resources("photos", &Resource{Index: listPhotos, Show: showPhoto})
+//	rt.Mount("/admin", adminApp)
+//
+//	rt.URL("user", map[string]string{"id": "42", "format": "json"}) // "/users/42.json"
+type Router struct {
+	// NotFound handles requests no route matches. It defaults to
+	// http.NotFound.
+	NotFound http.Handler
+
+	// MethodNotAllowed handles requests whose path matches a route for
+	// other methods only. The Allow header is set before it is called.
+	// It defaults to a plain 405 Method Not Allowed.
+	MethodNotAllowed http.Handler
+
+	routes []*Route
+	named  map[string]*Route
+}
+
+// NewRouter returns an empty Router.
+func NewRouter() *Router {
+	return &Router{named: make(map[string]*Route)}
+}
+
+// A Route is a pattern bound to a handler. Its methods configure it and
+// return it, so that they can be chained after adding the route.
+type Route struct {
+	Method  string // "" matches every method
+	Pattern string
+	Name    string
+
+	router      *Router
+	handler     http.Handler
+	nodes       []routeNode
+	constraints map[string]*regexp.Regexp
+	re          *regexp.Regexp
+	// lazy is re with its constrained segments matched lazily, tried
+	// when the values re matched fail their constraints. It is nil for
+	// routes without constraints.
+	lazy  *regexp.Regexp
+	mount bool
+}
+
+// Handle adds a route for method and pattern. An empty method matches
+// every method. It panics if pattern is malformed, as http.ServeMux does.
+func (rt *Router) Handle(method, pattern string, handler http.Handler) *Route {
+	return rt.add(method, pattern, handler, false)
+}
+
+// HandleFunc adds a route for method and pattern.
+func (rt *Router) HandleFunc(method, pattern string, fn func(http.ResponseWriter, *http.Request)) *Route {
+	return rt.Handle(method, pattern, http.HandlerFunc(fn))
+}
+
+// Get adds a GET route, which also answers HEAD requests.
+func (rt *Router) Get(pattern string, fn http.HandlerFunc) *Route {
+	return rt.Handle(http.MethodGet, pattern, fn)
+}
+
+// Post adds a POST route.
+func (rt *Router) Post(pattern string, fn http.HandlerFunc) *Route {
+	return rt.Handle(http.MethodPost, pattern, fn)
+}
+
+// Put adds a PUT route.
+func (rt *Router) Put(pattern string, fn http.HandlerFunc) *Route {
+	return rt.Handle(http.MethodPut, pattern, fn)
+}
+
+// Patch adds a PATCH route.
+func (rt *Router) Patch(pattern string, fn http.HandlerFunc) *Route {
+	return rt.Handle(http.MethodPatch, pattern, fn)
+}
+
+// Delete adds a DELETE route.
+func (rt *Router) Delete(pattern string, fn http.HandlerFunc) *Route {
+	return rt.Handle(http.MethodDelete, pattern, fn)
+}
+
+// Mount hands every request under prefix to handler, whatever its method,
+// with prefix removed from the path. The prefix may contain parameters,
+// which are passed on to handler. Mounting another Router nests its
+// routes.
+func (rt *Router) Mount(prefix string, handler http.Handler) *Route {
+	return rt.add("", strings.TrimSuffix(prefix, "/"), handler, true)
+}
+
+func (rt *Router) add(method, pattern string, handler http.Handler, mount bool) *Route {
+	nodes, err := parseRoutePattern(pattern)
+	if err != nil {
+		panic(err)
+	}
+	route := &Route{
+		Method:  method,
+		Pattern: pattern,
+		router:  rt,
+		handler: handler,
+		nodes:   nodes,
+		mount:   mount,
+	}
+	route.compile()
+	rt.routes = append(rt.routes, route)
+	return route
+}
+
+// Named names the route for URL generation. It panics if the name is
+// already taken.
+func (r *Route) Named(name string) *Route {
+	if _, ok := r.router.named[name]; ok {
+		panic("httpx: duplicate route name " + name)
+	}
+	if r.router.named == nil {
+		r.router.named = make(map[string]*Route)
+	}
+	r.Name = name
+	r.router.named[name] = r
+	return r
+}
+
+// Constrain restricts the parameter name to values matching re in full.
+// The constraint applies to the unescaped value, so it may allow
+// characters such as "/" that only appear escaped in a path. It panics if
+// re is anchored, since the pattern already is.
+func (r *Route) Constrain(name string, re *regexp.Regexp) *Route {
+	src := re.String()
+	if strings.HasPrefix(src, "^") || strings.HasSuffix(src, "$") || strings.HasPrefix(src, `\A`) || strings.HasSuffix(src, `\z`) {
+		panic("httpx: route constraint for " + name + " must not be anchored")
+	}
+	if r.constraints == nil {
+		r.constraints = make(map[string]*regexp.Regexp)
+	}
+	r.constraints[name] = regexp.MustCompile(`^(?:` + src + `)$`)
+	r.compile()
+	return r
+}
+
+// URL returns the path of the named route with params filled in. Segments
+// are escaped with EscapePath, and dots in parameters too, so that any
+// value matches back; params the route does not use are added as a query
+// string. It fails if the route is unknown or a required parameter is
+// missing or does not satisfy its constraint.
+func (rt *Router) URL(name string, params map[string]string) (string, error) {
+	route, ok := rt.named[name]
+	if !ok {
+		return "", fmt.Errorf("httpx: no route named %q", name)
+	}
+	return route.URL(params)
+}
+
+// URL returns the route's path with params filled in, as Router.URL does.
+// An optional group is included when every parameter in it is given.
+func (r *Route) URL(params map[string]string) (string, error) {
+	used := make(map[string]bool)
+	var b strings.Builder
+	if _, err := r.generate(&b, r.nodes, params, used, false); err != nil {
+		return "", err
+	}
+	path := b.String()
+	if path == "" {
+		path = "/"
+	}
+
+	query := url.Values{}
+	for name, value := range params {
+		if !used[name] {
+			query.Set(name, value)
+		}
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return path, nil
+}
+
+// generate writes nodes to b. Inside an optional group a missing parameter
+// is not an error; generate reports false instead so the group is left
+// out.
+func (r *Route) generate(b *strings.Builder, nodes []routeNode, params map[string]string, used map[string]bool, optional bool) (bool, error) {
+	for _, n := range nodes {
+		switch n.kind {
+		case routeLiteral:
+			b.WriteString(n.text)
+		case routeParam, routeGlob:
+			value, ok := params[n.text]
+			if !ok || value == "" {
+				if optional {
+					return false, nil
+				}
+				return false, fmt.Errorf("httpx: missing parameter %q for route %s", n.text, r.Pattern)
+			}
+			if !r.satisfies(n.text, value) {
+				return false, fmt.Errorf("httpx: parameter %q does not match the constraint of route %s: %q", n.text, r.Pattern, value)
+			}
+			if n.kind == routeGlob {
+				segments := strings.Split(value, "/")
+				for i, s := range segments {
+					segments[i] = EscapePath(s)
+				}
+				b.WriteString(strings.Join(segments, "/"))
+			} else {
+				b.WriteString(strings.ReplaceAll(EscapePath(value), ".", "%2E"))
+			}
+			used[n.text] = true
+		case routeGroup:
+			if !n.hasParams() {
+				continue
+			}
+			var group strings.Builder
+			groupUsed := make(map[string]bool)
+			ok, err := r.generate(&group, n.nodes, params, groupUsed, true)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				b.WriteString(group.String())
+				for name := range groupUsed {
+					used[name] = true
+				}
+			}
+		}
+	}
+	return true, nil
+}
+
+// satisfies reports whether the unescaped value satisfies the constraint
+// on the parameter name, if it has one.
+func (r *Route) satisfies(name, value string) bool {
+	re, ok := r.constraints[name]
+	return !ok || re.MatchString(value)
+}
+
+func (r *Route) compile() {
+	r.re = r.compileRegexp(false)
+	r.lazy = nil
+	if len(r.constraints) > 0 {
+		r.lazy = r.compileRegexp(true)
+	}
+}
+
+func (r *Route) compileRegexp(lazy bool) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	r.writeRegexp(&b, r.nodes, lazy)
+	if r.mount {
+		b.WriteString("(/.*)?")
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+func (r *Route) writeRegexp(b *strings.Builder, nodes []routeNode, lazy bool) {
+	for _, n := range nodes {
+		switch n.kind {
+		case routeLiteral:
+			b.WriteString(regexp.QuoteMeta(n.text))
+		case routeParam, routeGlob:
+			// Constraints are checked on the unescaped values after
+			// matching. A constrained segment may contain dots, which
+			// the constraint decides about.
+			expr := `[^/.?]+`
+			if n.kind == routeGlob {
+				expr = `.+?`
+			} else if _, ok := r.constraints[n.text]; ok {
+				expr = `[^/?]+`
+				if lazy {
+					expr += "?"
+				}
+			}
+			b.WriteString("(?P<" + n.text + ">(?:" + expr + "))")
+		case routeGroup:
+			b.WriteString("(?:")
+			r.writeRegexp(b, n.nodes, lazy)
+			b.WriteString(")?")
+		}
+	}
+}
+
+// match matches the escaped path against the route, returning the
+// unescaped parameters and, for mounts, the remaining escaped path. A
+// constrained segment takes as much of the path as its constraint allows,
+// as with a backtracking matcher: if the longest values fail their
+// constraints, the shortest are tried, leaving the rest to a format
+// suffix.
+func (r *Route) match(path string) (map[string]string, string, bool) {
+	if !r.mount && len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	params, rest, ok := r.matchRegexp(r.re, path)
+	if !ok && r.lazy != nil {
+		params, rest, ok = r.matchRegexp(r.lazy, path)
+	}
+	return params, rest, ok
+}
+
+func (r *Route) matchRegexp(re *regexp.Regexp, path string) (map[string]string, string, bool) {
+	m := re.FindStringSubmatchIndex(path)
+	if m == nil {
+		return nil, "", false
+	}
+	params := make(map[string]string)
+	for i, name := range re.SubexpNames() {
+		if name != "" && m[2*i] >= 0 {
+			params[name] = UnescapePath(path[m[2*i]:m[2*i+1]])
+			if !r.satisfies(name, params[name]) {
+				return nil, "", false
+			}
+		}
+	}
+	var rest string
+	if r.mount {
+		last := len(m) - 2
+		if m[last] >= 0 {
+			rest = path[m[last]:m[last+1]]
+		}
+		if rest == "" {
+			rest = "/"
+		}
+	}
+	return params, rest, true
+}
+
+func (r *Route) allows(method string) bool {
+	return r.Method == "" || r.Method == method || method == http.MethodHead && r.Method == http.MethodGet
+}
+
+type routeParamsKey struct{}
+
+// ServeHTTP dispatches the request to the first matching route.
+func (rt *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	path := req.URL.EscapedPath()
+	var allowed []string
+	for _, route := range rt.routes {
+		params, rest, ok := route.match(path)
+		if !ok {
+			continue
+		}
+		if !route.allows(req.Method) {
+			allowed = append(allowed, route.Method)
+			continue
+		}
+
+		for name, value := range RouteParams(req) {
+			if _, ok := params[name]; !ok {
+				params[name] = value
+			}
+		}
+		req = req.WithContext(context.WithValue(req.Context(), routeParamsKey{}, params))
+		if route.mount {
+			req = req.Clone(req.Context())
+			req.URL.RawPath = rest
+			req.URL.Path = UnescapePath(rest)
+		}
+		route.handler.ServeHTTP(w, req)
+		return
+	}
+
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowHeader(allowed), ", "))
+		if rt.MethodNotAllowed != nil {
+			rt.MethodNotAllowed.ServeHTTP(w, req)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if rt.NotFound != nil {
+		rt.NotFound.ServeHTTP(w, req)
+		return
+	}
+	http.NotFound(w, req)
+}
+
+// allowHeader returns the sorted, deduplicated methods, with HEAD added
+// wherever GET is allowed.
+func allowHeader(methods []string) []string {
+	seen := make(map[string]bool)
+	var allow []string
+	for _, m := range methods {
+		ms := []string{m}
+		if m == http.MethodGet {
+			ms = append(ms, http.MethodHead)
+		}
+		for _, m := range ms {
+			if !seen[m] {
+				seen[m] = true
+				allow = append(allow, m)
+			}
+		}
+	}
+	sort.Strings(allow)
+	return allow
+}
+
+// RouteParams returns the parameters matched by the Router, or nil
+// outside of one.
+func RouteParams(r *http.Request) map[string]string {
+	params, _ := r.Context().Value(routeParamsKey{}).(map[string]string)
+	return params
+}
+
+// Param returns the route parameter name, or "" if it did not match.
+func Param(r *http.Request, name string) string {
+	return RouteParams(r)[name]
+}
+
+// Resource holds the handlers of a RESTful resource, as Rails' resources
+// routes them. Actions whose handler is nil are not routed.
+type Resource struct {
+	Index   http.Handler // GET    /photos
+	New     http.Handler // GET    /photos/new
+	Create  http.Handler // POST   /photos
+	Show    http.Handler // GET    /photos/:id
+	Edit    http.Handler // GET    /photos/:id/edit
+	Update  http.Handler // PATCH and PUT /photos/:id
+	Destroy http.Handler // DELETE /photos/:id
+
+	// Singular names single members in route names. It defaults to the
+	// resource name without a trailing "s", which suits regular plurals
+	// only: set it for names such as "addresses" or "people".
+	Singular string
+
+	// Param is the name of the member parameter. It defaults to "id".
+	Param string
+}
+
+// Resources adds the routes of res under "/name", each accepting an
+// optional format suffix. The routes are named as in Rails: "photos",
+// "new_photo", "photo" and "edit_photo", where the singular is taken from
+// res.Singular if the name is not a plain plural in "s".
+//
+//	rt.Resources("photos", &Resource{Index: listPhotos, Show: showPhoto})
+//	rt.URL("photo", map[string]string{"id": "7"}) // "/photos/7"
+func (rt *Router) Resources(name string, res *Resource) {
+	name = strings.Trim(name, "/")
+	singular := res.Singular
+	if singular == "" {
+		singular = strings.TrimSuffix(name, "s")
+	}
+	param := res.Param
+	if param == "" {
+		param = "id"
+	}
+	collection := "/" + name
+	member := collection + "/:" + param
+
+	routes := []struct {
+		method, pattern, name string
+		handler               http.Handler
+	}{
+		{http.MethodGet, collection, name, res.Index},
+		{http.MethodGet, collection + "/new", "new_" + singular, res.New},
+		{http.MethodPost, collection, name, res.Create},
+		{http.MethodGet, member + "/edit", "edit_" + singular, res.Edit},
+		{http.MethodGet, member, singular, res.Show},
+		{http.MethodPatch, member, singular, res.Update},
+		{http.MethodPut, member, singular, res.Update},
+		{http.MethodDelete, member, singular, res.Destroy},
+	}
+	for _, r := range routes {
+		if r.handler == nil {
+			continue
+		}
+		route := rt.Handle(r.method, r.pattern+"(.:format)", r.handler)
+		if _, ok := rt.named[r.name]; !ok {
+			route.Named(r.name)
+		}
+	}
+}
+
+type routeNodeKind int
+
+const (
+	routeLiteral routeNodeKind = iota
+	routeParam
+	routeGlob
+	routeGroup
+)
+
+type routeNode struct {
+	kind  routeNodeKind
+	text  string // the literal text or the parameter name
+	nodes []routeNode
+}
+
+func (n routeNode) hasParams() bool {
+	for _, c := range n.nodes {
+		if c.kind == routeParam || c.kind == routeGlob || c.kind == routeGroup && c.hasParams() {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRoutePattern parses a route pattern into a tree of nodes.
+func parseRoutePattern(pattern string) ([]routeNode, error) {
+	stack := [][]routeNode{nil}
+	for i := 0; i < len(pattern); {
+		top := len(stack) - 1
+		switch c := pattern[i]; c {
+		case ':', '*':
+			j := i + 1
+			for j < len(pattern) && isParamChar(pattern[j]) {
+				j++
+			}
+			if j == i+1 {
+				return nil, fmt.Errorf("httpx: missing parameter name at offset %d in route %q", i, pattern)
+			}
+			kind := routeParam
+			if c == '*' {
+				kind = routeGlob
+			}
+			stack[top] = append(stack[top], routeNode{kind: kind, text: pattern[i+1 : j]})
+			i = j
+		case '(':
+			stack = append(stack, nil)
+			i++
+		case ')':
+			if top == 0 {
+				return nil, fmt.Errorf("httpx: unbalanced ')' at offset %d in route %q", i, pattern)
+			}
+			group := routeNode{kind: routeGroup, nodes: stack[top]}
+			stack = stack[:top]
+			stack[top-1] = append(stack[top-1], group)
+			i++
+		default:
+			j := i + 1
+			for j < len(pattern) && !strings.ContainsRune(":*()", rune(pattern[j])) {
+				j++
+			}
+			stack[top] = append(stack[top], routeNode{kind: routeLiteral, text: pattern[i:j]})
+			i = j
+		}
+	}
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("httpx: unbalanced '(' in route %q", pattern)
+	}
+	return stack[0], nil
+}
+
+func isParamChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_'
+}
@@ -0,0 +1,204 @@
+package httpx
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// paramsHandler writes the route parameters in a stable order.
+func paramsHandler(name string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var parts []string
+		for k, v := range RouteParams(r) {
+			parts = append(parts, k+"="+v)
+		}
+		sort.Strings(parts)
+		fmt.Fprintf(w, "%s %s", name, strings.Join(parts, " "))
+	}
+}
+
+func Test_RouterMatch(t *testing.T) {
+	rt := NewRouter()
+	rt.Get("/", paramsHandler("root"))
+	rt.Get("/users/:id(.:format)", paramsHandler("user")).Constrain("id", regexp.MustCompile(`\d+`))
+	rt.Get("/users/:name", paramsHandler("user_by_name"))
+	rt.Get("/files/*path(.:format)", paramsHandler("file"))
+	rt.Get("/archive(/:year(/:month))", paramsHandler("archive"))
+	rt.Post("/users", paramsHandler("create"))
+
+	tests := []struct {
+		method, target, body string
+	}{
+		{"GET", "/", "root "},
+		{"GET", "/users/42", "user id=42"},
+		{"GET", "/users/42/", "user id=42"},
+		{"GET", "/users/42.json", "user format=json id=42"},
+		{"HEAD", "/users/42", "user id=42"},
+		{"GET", "/users/bob", "user_by_name name=bob"},
+		{"GET", "/users/b%2Fob", "user_by_name name=b/ob"},
+		{"GET", "/files/a/b/c.txt", "file format=txt path=a/b/c"},
+		{"GET", "/files/a/b", "file path=a/b"},
+		{"GET", "/archive", "archive "},
+		{"GET", "/archive/2023", "archive year=2023"},
+		{"GET", "/archive/2023/07", "archive month=07 year=2023"},
+		{"POST", "/users", "create "},
+	}
+	for _, test := range tests {
+		w := doRequest(rt, test.method, test.target, nil)
+		if w.Code != http.StatusOK || w.Body.String() != test.body {
+			t.Errorf("expected %s %s to give '%s' but got %d '%s'", test.method, test.target, test.body, w.Code, w.Body.String())
+		}
+	}
+
+	if w := doRequest(rt, "GET", "/nowhere", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 but got %d", w.Code)
+	}
+	w := doRequest(rt, "DELETE", "/users", nil)
+	if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != "POST" {
+		t.Errorf("expected 405 allowing POST but got %d '%s'", w.Code, w.Header().Get("Allow"))
+	}
+	w = doRequest(rt, "DELETE", "/users/42", nil)
+	if w.Header().Get("Allow") != "GET, HEAD" {
+		t.Errorf("expected Allow 'GET, HEAD' but got '%s'", w.Header().Get("Allow"))
+	}
+}
+
+func Test_RouterURL(t *testing.T) {
+	rt := NewRouter()
+	rt.Get("/users/:id(.:format)", paramsHandler("user")).Named("user").
+		Constrain("id", regexp.MustCompile(`\d+`))
+	rt.Get("/files/*path", paramsHandler("file")).Named("file")
+	rt.Get("/tags/:tag(/new)", paramsHandler("tag")).Named("tag")
+
+	tests := []struct {
+		name     string
+		params   map[string]string
+		expected string
+	}{
+		{"user", map[string]string{"id": "42"}, "/users/42"},
+		{"user", map[string]string{"id": "42", "format": "json"}, "/users/42.json"},
+		{"user", map[string]string{"id": "42", "page": "2", "q": "a b"}, "/users/42?page=2&q=a+b"},
+		{"file", map[string]string{"path": "docs/read me.txt"}, "/files/docs/read%20me.txt"},
+		{"tag", map[string]string{"tag": "go lang/x"}, "/tags/go%20lang%2Fx"},
+		{"tag", map[string]string{"tag": "v1.2?"}, "/tags/v1%2E2%3F"},
+		{"tag", map[string]string{"tag": "café"}, "/tags/caf%C3%A9"},
+	}
+	for _, test := range tests {
+		url, err := rt.URL(test.name, test.params)
+		if test.expected == "" {
+			if err == nil {
+				t.Errorf("expected an error for %v but got '%s'", test.params, url)
+			}
+			continue
+		}
+		if err != nil || url != test.expected {
+			t.Errorf("expected '%s' but got '%s' (%v)", test.expected, url, err)
+		}
+	}
+
+	for _, params := range []map[string]string{{}, {"id": "abc"}} {
+		if _, err := rt.URL("user", params); err == nil {
+			t.Errorf("expected an error for %v", params)
+		}
+	}
+	if _, err := rt.URL("nope", nil); err == nil {
+		t.Errorf("expected an error for an unknown route")
+	}
+
+	rt.Get("/versions/:v(.:format)", paramsHandler("version")).Named("version").
+		Constrain("v", regexp.MustCompile(`[\d. /]+`))
+	for _, v := range []string{"1.2", "1 2", "1/2"} {
+		url, err := rt.URL("version", map[string]string{"v": v, "format": "json"})
+		if w := doRequest(rt, "GET", url, nil); w.Body.String() != "version format=json v="+v {
+			t.Errorf("expected %s to match version %q but got %d '%s' (%v)", url, v, w.Code, w.Body.String(), err)
+		}
+	}
+	if w := doRequest(rt, "GET", "/versions/1.2", nil); w.Body.String() != "version v=1.2" {
+		t.Errorf("expected an unescaped dot to match the constraint but got %d '%s'", w.Code, w.Body.String())
+	}
+
+	for _, tag := range []string{"a/b", "v1.2", "what?"} {
+		url, _ := rt.URL("tag", map[string]string{"tag": tag})
+		if w := doRequest(rt, "GET", url, nil); w.Body.String() != "tag tag="+tag {
+			t.Errorf("expected %s to match tag %q but got %d '%s'", url, tag, w.Code, w.Body.String())
+		}
+	}
+}
+
+func Test_RouterMount(t *testing.T) {
+	admin := NewRouter()
+	admin.Get("/users/:id", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s %s %s", r.URL.Path, Param(r, "account"), Param(r, "id"))
+	})
+	rt := NewRouter()
+	rt.Mount("/accounts/:account/admin", admin)
+
+	w := doRequest(rt, "GET", "/accounts/7/admin/users/3", nil)
+	if body := w.Body.String(); body != "/users/3 7 3" {
+		t.Errorf("unexpected body '%s'", body)
+	}
+	if w := doRequest(rt, "GET", "/accounts/7/administrator", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 but got %d", w.Code)
+	}
+}
+
+func Test_RouterResources(t *testing.T) {
+	rt := NewRouter()
+	rt.Resources("photos", &Resource{
+		Index:   paramsHandler("index"),
+		New:     paramsHandler("new"),
+		Create:  paramsHandler("create"),
+		Show:    paramsHandler("show"),
+		Update:  paramsHandler("update"),
+		Destroy: paramsHandler("destroy"),
+	})
+
+	tests := []struct {
+		method, target, body string
+	}{
+		{"GET", "/photos", "index "},
+		{"GET", "/photos.json", "index format=json"},
+		{"GET", "/photos/new", "new "},
+		{"POST", "/photos", "create "},
+		{"GET", "/photos/5", "show id=5"},
+		{"PUT", "/photos/5", "update id=5"},
+		{"PATCH", "/photos/5.xml", "update format=xml id=5"},
+		{"DELETE", "/photos/5", "destroy id=5"},
+	}
+	for _, test := range tests {
+		w := doRequest(rt, test.method, test.target, nil)
+		if w.Body.String() != test.body {
+			t.Errorf("expected %s %s to give '%s' but got %d '%s'", test.method, test.target, test.body, w.Code, w.Body.String())
+		}
+	}
+	if w := doRequest(rt, "GET", "/photos/5/edit", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected unrouted edit to give 404 but got %d", w.Code)
+	}
+
+	for name, expected := range map[string]string{"photos": "/photos", "new_photo": "/photos/new", "photo": "/photos/5"} {
+		params := map[string]string{}
+		if name == "photo" {
+			params["id"] = "5"
+		}
+		if url, err := rt.URL(name, params); url != expected {
+			t.Errorf("expected %s to be '%s' but got '%s' (%v)", name, expected, url, err)
+		}
+	}
+}
+
+func Test_RouterBadPattern(t *testing.T) {
+	for _, pattern := range []string{"/users/(:id", "/users/:id)", "/users/:"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected %q to panic", pattern)
+				}
+			}()
+			NewRouter().Get(pattern, paramsHandler("x"))
+		}()
+	}
+}
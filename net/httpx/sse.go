@@ -0,0 +1,369 @@
+package httpx
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// An Event is a Server-Sent Event. An empty Event type means "message".
+type Event struct {
+	ID    string
+	Event string
+	Data  string
+
+	// Retry, if not zero, tells the client how long to wait before
+	// reconnecting.
+	Retry time.Duration
+}
+
+// ErrInvalidEventField is returned when an event's ID or type contains a
+// line break, or its ID contains a NUL, which the format cannot carry.
+var ErrInvalidEventField = errors.New("httpx: event id and type must not contain line breaks")
+
+// SSEWriter writes a text/event-stream response. It is safe for
+// concurrent use, so heartbeats can run alongside the goroutine sending
+// events.
+//
+//	func progress(w http.ResponseWriter, r *http.Request) {
+//		sse, err := NewSSEWriter(w)
+//		if err != nil {
+//			http.Error(w, err.Error(), http.StatusInternalServerError)
+//			return
+//		}
+//		go sse.Heartbeat(r.Context(), 15*time.Second)
+//		for p := range job.Progress() {
+//			sse.Send(Event{Event: "progress", Data: strconv.Itoa(p)})
+//		}
+//	}
+type SSEWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+	rc *http.ResponseController
+
+	// AutoFlush flushes after every event and comment. It is on by
+	// default; turn it off to batch events and call Flush yourself.
+	AutoFlush bool
+}
+
+// NewSSEWriter prepares w for streaming events, setting the
+// text/event-stream content type and disabling caching and proxy
+// buffering. It fails if w cannot be flushed.
+func NewSSEWriter(w http.ResponseWriter) (*SSEWriter, error) {
+	rc := http.NewResponseController(w)
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream; charset=utf-8")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return nil, err
+	}
+	return &SSEWriter{w: w, rc: rc, AutoFlush: true}, nil
+}
+
+// Send writes e. Data spanning several lines is split into one data field
+// per line, whatever the line endings.
+func (s *SSEWriter) Send(e Event) error {
+	if strings.ContainsAny(e.ID, "\r\n\x00") || strings.ContainsAny(e.Event, "\r\n") {
+		return ErrInvalidEventField
+	}
+	var b strings.Builder
+	writeEvent(&b, e)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(b.String())
+}
+
+// Comment writes a comment line, which clients ignore. Each line of text
+// becomes its own comment.
+func (s *SSEWriter) Comment(text string) error {
+	var b strings.Builder
+	for _, line := range splitLines(text) {
+		b.WriteString(":")
+		if line != "" {
+			b.WriteString(" ")
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(b.String())
+}
+
+// Flush sends any buffered data to the client.
+func (s *SSEWriter) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rc.Flush()
+}
+
+// Heartbeat writes an empty comment every interval until ctx is done or
+// a write fails, keeping idle connections from being closed by proxies.
+// It is meant to run in its own goroutine.
+func (s *SSEWriter) Heartbeat(ctx context.Context, interval time.Duration) error {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+			s.mu.Lock()
+			err := s.write(":\n\n")
+			if err == nil && !s.AutoFlush {
+				err = s.rc.Flush()
+			}
+			s.mu.Unlock()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Replay sends the events buf holds after lastEventID, so that a client
+// reconnecting with Last-Event-ID misses nothing. It returns false,
+// sending nothing, if lastEventID is no longer in the buffer.
+func (s *SSEWriter) Replay(buf *SSEReplayBuffer, lastEventID string) (bool, error) {
+	events, ok := buf.Since(lastEventID)
+	if !ok {
+		return false, nil
+	}
+	for _, e := range events {
+		if err := s.Send(e); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+func (s *SSEWriter) write(str string) error {
+	if _, err := io.WriteString(s.w, str); err != nil {
+		return err
+	}
+	if s.AutoFlush {
+		return s.rc.Flush()
+	}
+	return nil
+}
+
+// LastEventID returns the ID of the last event a reconnecting client saw.
+func LastEventID(r *http.Request) string {
+	return r.Header.Get("Last-Event-ID")
+}
+
+func writeEvent(b *strings.Builder, e Event) {
+	if e.ID != "" {
+		b.WriteString("id: " + e.ID + "\n")
+	}
+	if e.Event != "" {
+		b.WriteString("event: " + e.Event + "\n")
+	}
+	if e.Retry > 0 {
+		b.WriteString("retry: " + strconv.FormatInt(int64(e.Retry/time.Millisecond), 10) + "\n")
+	}
+	for _, line := range splitLines(e.Data) {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteString("\n")
+}
+
+// splitLines splits s on CRLF, CR and LF.
+func splitLines(s string) []string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	return strings.Split(s, "\n")
+}
+
+// SSEReplayBuffer keeps the most recent events so they can be replayed to
+// reconnecting clients. It is safe for concurrent use and is typically
+// shared by all the connections of one stream.
+type SSEReplayBuffer struct {
+	mu     sync.Mutex
+	size   int
+	events []Event
+}
+
+// NewSSEReplayBuffer returns a buffer holding up to size events.
+func NewSSEReplayBuffer(size int) *SSEReplayBuffer {
+	return &SSEReplayBuffer{size: size}
+}
+
+// Add records e, dropping the oldest event if the buffer is full. Events
+// without an ID cannot be resumed from and are not recorded.
+func (b *SSEReplayBuffer) Add(e Event) {
+	if e.ID == "" || b.size <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.events) == b.size {
+		copy(b.events, b.events[1:])
+		b.events = b.events[:len(b.events)-1]
+	}
+	b.events = append(b.events, e)
+}
+
+// Since returns the events recorded after the one with the given ID. An
+// empty ID returns every event. It returns false if the ID is unknown,
+// either because it was never sent or because it has been dropped.
+func (b *SSEReplayBuffer) Since(id string) ([]Event, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if id == "" {
+		return append([]Event(nil), b.events...), true
+	}
+	for i := len(b.events) - 1; i >= 0; i-- {
+		if b.events[i].ID == id {
+			return append([]Event(nil), b.events[i+1:]...), true
+		}
+	}
+	return nil, false
+}
+
+// maxSSELine bounds the length of a single line read by SSEReader.
+const maxSSELine = 1 << 20
+
+// SSEReader parses a text/event-stream body into events, following the
+// HTML Living Standard's interpretation rules: lines may end in CRLF, CR or
+// LF, unknown fields and comments are ignored, and the last event ID
+// carries over to later events that do not set one.
+//
+//	resp, _ := http.Get("https://example.com/jobs/42/progress")
+//	events := NewSSEReader(resp.Body)
+//	for {
+//		e, err := events.Next()
+//		if err != nil {
+//			break
+//		}
+//		fmt.Println(e.Event, e.Data)
+//	}
+type SSEReader struct {
+	scanner *bufio.Scanner
+	lastID  string
+	retry   time.Duration
+	first   bool
+}
+
+// NewSSEReader returns an SSEReader reading from r.
+func NewSSEReader(r io.Reader) *SSEReader {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, maxSSELine)
+	s.Split(scanSSELines)
+	return &SSEReader{scanner: s, first: true}
+}
+
+// Next returns the next event. It returns io.EOF when the stream ends;
+// an event left incomplete at the end of the stream is discarded.
+func (p *SSEReader) Next() (Event, error) {
+	var (
+		e       Event
+		data    strings.Builder
+		hasData bool
+	)
+	for p.scanner.Scan() {
+		line := p.scanner.Text()
+		if p.first {
+			line = strings.TrimPrefix(line, "\uFEFF")
+			p.first = false
+		}
+
+		if line == "" {
+			if !hasData {
+				e = Event{}
+				continue
+			}
+			e.ID = p.lastID
+			e.Data = strings.TrimSuffix(data.String(), "\n")
+			return e, nil
+		}
+		if line[0] == ':' {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			e.Event = value
+		case "data":
+			data.WriteString(value)
+			data.WriteString("\n")
+			hasData = true
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				p.lastID = value
+			}
+		case "retry":
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil && n >= 0 && isDigits(value) {
+				e.Retry = time.Duration(n) * time.Millisecond
+				p.retry = e.Retry
+			}
+		}
+	}
+	if err := p.scanner.Err(); err != nil {
+		return Event{}, err
+	}
+	return Event{}, io.EOF
+}
+
+// LastEventID returns the last event ID seen, to send as Last-Event-ID
+// when reconnecting.
+func (p *SSEReader) LastEventID() string {
+	return p.lastID
+}
+
+// Retry returns the last reconnection time the server set, or 0 if it
+// set none. It counts retry fields in blocks without data, which Next
+// doesn't return as events.
+func (p *SSEReader) Retry() time.Duration {
+	return p.retry
+}
+
+// scanSSELines is a bufio.SplitFunc for lines ending in CRLF, CR or LF.
+func scanSSELines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		if data[i] == '\n' {
+			return i + 1, data[:i], nil
+		}
+		if i+1 < len(data) {
+			if data[i+1] == '\n' {
+				return i + 2, data[:i], nil
+			}
+			return i + 1, data[:i], nil
+		}
+		if atEOF {
+			return i + 1, data[:i], nil
+		}
+		// A CR at the end of the buffer may be the start of a CRLF.
+		return 0, nil, nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
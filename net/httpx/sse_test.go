@@ -0,0 +1,146 @@
+package httpx
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_SSEWriterSend(t *testing.T) {
+	w := httptest.NewRecorder()
+	sse, err := NewSSEWriter(w)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream; charset=utf-8" {
+		t.Errorf("unexpected Content-Type '%s'", ct)
+	}
+
+	sse.Send(Event{ID: "1", Event: "progress", Data: "50", Retry: 3 * time.Second})
+	sse.Send(Event{Data: "one\r\ntwo\rthree\nfour"})
+	sse.Comment("hello")
+	expected := "id: 1\nevent: progress\nretry: 3000\ndata: 50\n\n" +
+		"data: one\ndata: two\ndata: three\ndata: four\n\n" +
+		": hello\n\n"
+	if body := w.Body.String(); body != expected {
+		t.Errorf("expected %q but got %q", expected, body)
+	}
+	if !w.Flushed {
+		t.Errorf("expected the response to be flushed")
+	}
+
+	if err := sse.Send(Event{ID: "a\nb"}); err != ErrInvalidEventField {
+		t.Errorf("expected ErrInvalidEventField but got %v", err)
+	}
+	if err := sse.Send(Event{Event: "a\rb"}); err != ErrInvalidEventField {
+		t.Errorf("expected ErrInvalidEventField but got %v", err)
+	}
+}
+
+func Test_SSEWriterHeartbeat(t *testing.T) {
+	w := httptest.NewRecorder()
+	sse, _ := NewSSEWriter(w)
+	ctx, cancel := context.WithTimeout(context.Background(), 35*time.Millisecond)
+	defer cancel()
+	if err := sse.Heartbeat(ctx, 10*time.Millisecond); err != context.DeadlineExceeded {
+		t.Errorf("expected DeadlineExceeded but got %v", err)
+	}
+	if n := strings.Count(w.Body.String(), ":\n\n"); n < 1 {
+		t.Errorf("expected heartbeats but got %q", w.Body.String())
+	}
+}
+
+func Test_SSEReplay(t *testing.T) {
+	buf := NewSSEReplayBuffer(3)
+	for _, id := range []string{"1", "2", "3", "4"} {
+		buf.Add(Event{ID: id, Data: "event " + id})
+	}
+	buf.Add(Event{Data: "no id"})
+
+	events, ok := buf.Since("2")
+	if !ok || len(events) != 2 || events[0].ID != "3" || events[1].ID != "4" {
+		t.Errorf("expected events 3 and 4 but got %v (%v)", events, ok)
+	}
+	if _, ok := buf.Since("1"); ok {
+		t.Errorf("expected dropped event 1 to be unknown")
+	}
+	if events, _ := buf.Since(""); len(events) != 3 {
+		t.Errorf("expected all 3 events but got %d", len(events))
+	}
+
+	w := httptest.NewRecorder()
+	sse, _ := NewSSEWriter(w)
+	ok, err := sse.Replay(buf, "3")
+	if !ok || err != nil {
+		t.Errorf("expected replay to succeed but got %v, %v", ok, err)
+	}
+	if body := w.Body.String(); body != "id: 4\ndata: event 4\n\n" {
+		t.Errorf("unexpected replay %q", body)
+	}
+}
+
+func Test_SSEReader(t *testing.T) {
+	stream := "\uFEFF: comment\r\n" +
+		"event: progress\r\nid: 7\r\nretry: 1500\r\ndata: first\r\ndata:second\r\n\r\n" +
+		"data: no event\rdata\r\r" +
+		"id\nevent: empty\n\n" +
+		"id: bad\x00\nretry: 1x\nunknown: field\ndata:  spaced\n\n" +
+		"data: incomplete"
+	r := NewSSEReader(strings.NewReader(stream))
+
+	expected := []Event{
+		{ID: "7", Event: "progress", Data: "first\nsecond", Retry: 1500 * time.Millisecond},
+		{ID: "7", Data: "no event\n"},
+		{Data: " spaced"},
+	}
+	for _, e := range expected {
+		got, err := r.Next()
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if got != e {
+			t.Errorf("expected %+v but got %+v", e, got)
+		}
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Errorf("expected io.EOF but got %v", err)
+	}
+	if id := r.LastEventID(); id != "" {
+		t.Errorf("expected last event ID to be reset but got '%s'", id)
+	}
+}
+
+func Test_SSEReaderRetry(t *testing.T) {
+	r := NewSSEReader(strings.NewReader("retry: 1000\n\ndata: a\n\nretry: 2500\n\n"))
+	if retry := r.Retry(); retry != 0 {
+		t.Errorf("expected no retry before reading but got %v", retry)
+	}
+	got, err := r.Next()
+	if err != nil || got != (Event{Data: "a"}) {
+		t.Errorf("expected the data-less block to be skipped but got %+v (%v)", got, err)
+	}
+	if retry := r.Retry(); retry != time.Second {
+		t.Errorf("expected a retry of 1s but got %v", retry)
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Errorf("expected io.EOF but got %v", err)
+	}
+	if retry := r.Retry(); retry != 2500*time.Millisecond {
+		t.Errorf("expected a retry of 2.5s but got %v", retry)
+	}
+}
+
+func Test_SSERoundTrip(t *testing.T) {
+	w := httptest.NewRecorder()
+	sse, _ := NewSSEWriter(w)
+	sent := Event{ID: "42", Event: "update", Data: "a\n\nb"}
+	sse.Send(sent)
+
+	got, err := NewSSEReader(w.Body).Next()
+	if err != nil || got != sent {
+		t.Errorf("expected %+v but got %+v (%v)", sent, got, err)
+	}
+}
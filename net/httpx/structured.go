@@ -0,0 +1,662 @@
+package httpx
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// This file implements Structured Field Values for HTTP (RFC 8941), the
+// syntax of newer headers such as Priority, Cache-Status and RateLimit.
+//
+// The bare item values are represented as:
+//
+//	Integer       int64 (int is also accepted when serializing)
+//	Decimal       float64
+//	String        string
+//	Token         Token
+//	Byte Sequence []byte
+//	Boolean       bool
+
+// A Token is a structured field token, as opposed to a string.
+type Token string
+
+// A Parameter is a parameter of an item or an inner list.
+type Parameter struct {
+	Name  string
+	Value interface{}
+}
+
+// Params are ordered parameters.
+type Params []Parameter
+
+// Get returns the value of the parameter name.
+func (p Params) Get(name string) (interface{}, bool) {
+	for _, param := range p {
+		if param.Name == name {
+			return param.Value, true
+		}
+	}
+	return nil, false
+}
+
+// A Member is a member of a list or a dictionary: an Item or an
+// InnerList.
+type Member interface {
+	isMember()
+}
+
+// An Item is a bare item with parameters.
+type Item struct {
+	Value  interface{}
+	Params Params
+}
+
+// An InnerList is a parenthesized list of items, with parameters.
+type InnerList struct {
+	Items  []Item
+	Params Params
+}
+
+func (Item) isMember()      {}
+func (InnerList) isMember() {}
+
+// A List is a structured field list.
+type List []Member
+
+// A DictMember is a named member of a Dictionary.
+type DictMember struct {
+	Name  string
+	Value Member
+}
+
+// A Dictionary is an ordered structured field dictionary.
+type Dictionary []DictMember
+
+// Get returns the member name.
+func (d Dictionary) Get(name string) (Member, bool) {
+	for _, m := range d {
+		if m.Name == name {
+			return m.Value, true
+		}
+	}
+	return nil, false
+}
+
+// A StructuredFieldError reports where parsing a structured field failed.
+type StructuredFieldError struct {
+	Value  string
+	Offset int
+	Reason string
+}
+
+func (e *StructuredFieldError) Error() string {
+	return fmt.Sprintf("httpx: invalid structured field %q at offset %d: %s", e.Value, e.Offset, e.Reason)
+}
+
+// ParseList parses a List field, such as Cache-Status. A field sent on
+// several lines must be joined with commas first.
+//
+//	l, _ := ParseList(`ExampleCache; hit, "other"; fwd=miss`)
+//	l[0].(Item).Value  // Token("ExampleCache")
+func ParseList(value string) (List, error) {
+	p := &sfParser{s: value}
+	var list List
+	err := p.top(func() error {
+		var err error
+		list, err = p.list()
+		return err
+	})
+	return list, err
+}
+
+// ParseDictionary parses a Dictionary field, such as Priority.
+//
+//	d, _ := ParseDictionary("u=1, i")
+//	m, _ := d.Get("i")
+//	m.(Item).Value  // true
+func ParseDictionary(value string) (Dictionary, error) {
+	p := &sfParser{s: value}
+	var dict Dictionary
+	err := p.top(func() error {
+		var err error
+		dict, err = p.dictionary()
+		return err
+	})
+	return dict, err
+}
+
+// ParseItem parses an Item field.
+func ParseItem(value string) (Item, error) {
+	p := &sfParser{s: value}
+	var item Item
+	err := p.top(func() error {
+		var err error
+		item, err = p.item()
+		return err
+	})
+	return item, err
+}
+
+type sfParser struct {
+	s string
+	i int
+}
+
+func (p *sfParser) fail(reason string) error {
+	return &StructuredFieldError{Value: p.s, Offset: p.i, Reason: reason}
+}
+
+func (p *sfParser) eof() bool {
+	return p.i >= len(p.s)
+}
+
+func (p *sfParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.s[p.i]
+}
+
+func (p *sfParser) skipSP() {
+	for !p.eof() && p.s[p.i] == ' ' {
+		p.i++
+	}
+}
+
+func (p *sfParser) skipOWS() {
+	for !p.eof() && (p.s[p.i] == ' ' || p.s[p.i] == '\t') {
+		p.i++
+	}
+}
+
+// top runs parse on the whole value, which may have surrounding spaces
+// but nothing else.
+func (p *sfParser) top(parse func() error) error {
+	p.skipSP()
+	if err := parse(); err != nil {
+		return err
+	}
+	p.skipSP()
+	if !p.eof() {
+		return p.fail("unexpected trailing characters")
+	}
+	return nil
+}
+
+func (p *sfParser) list() (List, error) {
+	var list List
+	for !p.eof() {
+		m, err := p.member()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, m)
+		if p.next() {
+			return list, nil
+		}
+		if err := p.comma(); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+func (p *sfParser) dictionary() (Dictionary, error) {
+	var dict Dictionary
+	for !p.eof() {
+		name, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+		var m Member
+		if p.peek() == '=' {
+			p.i++
+			if m, err = p.member(); err != nil {
+				return nil, err
+			}
+		} else {
+			params, err := p.params()
+			if err != nil {
+				return nil, err
+			}
+			m = Item{Value: true, Params: params}
+		}
+		dict = dict.set(name, m)
+		if p.next() {
+			return dict, nil
+		}
+		if err := p.comma(); err != nil {
+			return nil, err
+		}
+	}
+	return dict, nil
+}
+
+// set replaces the member name in place, or appends it.
+func (d Dictionary) set(name string, m Member) Dictionary {
+	for i := range d {
+		if d[i].Name == name {
+			d[i].Value = m
+			return d
+		}
+	}
+	return append(d, DictMember{name, m})
+}
+
+// next skips whitespace after a member and reports whether the input is
+// done.
+func (p *sfParser) next() bool {
+	p.skipOWS()
+	return p.eof()
+}
+
+// comma consumes the separator between members.
+func (p *sfParser) comma() error {
+	if p.peek() != ',' {
+		return p.fail("expected ','")
+	}
+	p.i++
+	p.skipOWS()
+	if p.eof() {
+		return p.fail("trailing ','")
+	}
+	return nil
+}
+
+func (p *sfParser) member() (Member, error) {
+	if p.peek() == '(' {
+		return p.innerList()
+	}
+	return p.item()
+}
+
+func (p *sfParser) innerList() (InnerList, error) {
+	p.i++
+	var items []Item
+	for !p.eof() {
+		p.skipSP()
+		if p.peek() == ')' {
+			p.i++
+			params, err := p.params()
+			return InnerList{Items: items, Params: params}, err
+		}
+		item, err := p.item()
+		if err != nil {
+			return InnerList{}, err
+		}
+		items = append(items, item)
+		if c := p.peek(); c != ' ' && c != ')' {
+			return InnerList{}, p.fail("expected ' ' or ')' in inner list")
+		}
+	}
+	return InnerList{}, p.fail("unterminated inner list")
+}
+
+func (p *sfParser) item() (Item, error) {
+	value, err := p.bareItem()
+	if err != nil {
+		return Item{}, err
+	}
+	params, err := p.params()
+	return Item{Value: value, Params: params}, err
+}
+
+func (p *sfParser) params() (Params, error) {
+	var params Params
+	for p.peek() == ';' {
+		p.i++
+		p.skipSP()
+		name, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+		var value interface{} = true
+		if p.peek() == '=' {
+			p.i++
+			if value, err = p.bareItem(); err != nil {
+				return nil, err
+			}
+		}
+		replaced := false
+		for i := range params {
+			if params[i].Name == name {
+				params[i].Value = value
+				replaced = true
+			}
+		}
+		if !replaced {
+			params = append(params, Parameter{name, value})
+		}
+	}
+	return params, nil
+}
+
+func (p *sfParser) key() (string, error) {
+	start := p.i
+	if c := p.peek(); !(c >= 'a' && c <= 'z' || c == '*') {
+		return "", p.fail("expected a key")
+	}
+	for !p.eof() && isKeyChar(p.s[p.i]) {
+		p.i++
+	}
+	return p.s[start:p.i], nil
+}
+
+func isKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '_' || c == '-' || c == '.' || c == '*'
+}
+
+func (p *sfParser) bareItem() (interface{}, error) {
+	switch c := p.peek(); {
+	case c == '-' || c >= '0' && c <= '9':
+		return p.number()
+	case c == '"':
+		return p.string()
+	case c == '*' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+		return p.token(), nil
+	case c == ':':
+		return p.byteSequence()
+	case c == '?':
+		return p.boolean()
+	}
+	return nil, p.fail("expected an item")
+}
+
+func (p *sfParser) number() (interface{}, error) {
+	start := p.i
+	if p.peek() == '-' {
+		p.i++
+	}
+	if c := p.peek(); c < '0' || c > '9' {
+		return nil, p.fail("expected a digit")
+	}
+	digitsStart, dot := p.i, -1
+	for !p.eof() {
+		c := p.s[p.i]
+		if c == '.' && dot == -1 {
+			if p.i-digitsStart > 12 {
+				return nil, p.fail("decimal has more than 12 integer digits")
+			}
+			dot = p.i
+		} else if c < '0' || c > '9' {
+			break
+		}
+		p.i++
+		if dot == -1 && p.i-digitsStart > 15 {
+			return nil, p.fail("integer has more than 15 digits")
+		}
+		if dot != -1 && p.i-dot-1 > 3 {
+			return nil, p.fail("decimal has more than 3 fractional digits")
+		}
+	}
+	num := p.s[start:p.i]
+	if dot == -1 {
+		n, err := strconv.ParseInt(num, 10, 64)
+		if err != nil {
+			return nil, p.fail("invalid integer")
+		}
+		return n, nil
+	}
+	if dot == p.i-1 {
+		return nil, p.fail("decimal ends with '.'")
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return nil, p.fail("invalid decimal")
+	}
+	return f, nil
+}
+
+func (p *sfParser) string() (string, error) {
+	p.i++
+	var b strings.Builder
+	for !p.eof() {
+		c := p.s[p.i]
+		p.i++
+		switch {
+		case c == '\\':
+			if p.eof() || p.s[p.i] != '"' && p.s[p.i] != '\\' {
+				return "", p.fail("invalid escape in string")
+			}
+			b.WriteByte(p.s[p.i])
+			p.i++
+		case c == '"':
+			return b.String(), nil
+		case c < 0x20 || c > 0x7e:
+			p.i--
+			return "", p.fail("invalid character in string")
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.fail("unterminated string")
+}
+
+func (p *sfParser) token() Token {
+	start := p.i
+	p.i++
+	for !p.eof() && (isTokenChar(p.s[p.i]) || p.s[p.i] == ':' || p.s[p.i] == '/') {
+		p.i++
+	}
+	return Token(p.s[start:p.i])
+}
+
+func (p *sfParser) byteSequence() ([]byte, error) {
+	p.i++
+	end := strings.IndexByte(p.s[p.i:], ':')
+	if end == -1 {
+		return nil, p.fail("unterminated byte sequence")
+	}
+	enc := p.s[p.i : p.i+end]
+	for i := 0; i < len(enc); i++ {
+		c := enc[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '+' || c == '/' || c == '=') {
+			p.i += i
+			return nil, p.fail("invalid character in byte sequence")
+		}
+	}
+	b, err := base64.StdEncoding.DecodeString(enc)
+	if err != nil {
+		// Padding is optional on the wire.
+		b, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(enc, "="))
+	}
+	if err != nil {
+		return nil, p.fail("invalid base64 in byte sequence")
+	}
+	p.i += end + 1
+	return b, nil
+}
+
+func (p *sfParser) boolean() (bool, error) {
+	p.i++
+	switch p.peek() {
+	case '1':
+		p.i++
+		return true, nil
+	case '0':
+		p.i++
+		return false, nil
+	}
+	return false, p.fail("expected '0' or '1' in boolean")
+}
+
+// SerializeList serializes a List. It fails if any value cannot be
+// represented, such as an integer out of range or a string with
+// non-ASCII characters.
+func SerializeList(list List) (string, error) {
+	var b strings.Builder
+	for i, m := range list {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		if err := serializeMember(&b, m); err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
+
+// SerializeDictionary serializes a Dictionary. Members whose value is
+// true are written as their bare name.
+func SerializeDictionary(dict Dictionary) (string, error) {
+	var b strings.Builder
+	for i, m := range dict {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		if err := serializeKey(&b, m.Name); err != nil {
+			return "", err
+		}
+		if item, ok := m.Value.(Item); ok && item.Value == true {
+			if err := serializeParams(&b, item.Params); err != nil {
+				return "", err
+			}
+			continue
+		}
+		b.WriteByte('=')
+		if err := serializeMember(&b, m.Value); err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
+
+// SerializeItem serializes an Item.
+func SerializeItem(item Item) (string, error) {
+	var b strings.Builder
+	if err := serializeItem(&b, item); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func serializeMember(b *strings.Builder, m Member) error {
+	switch m := m.(type) {
+	case Item:
+		return serializeItem(b, m)
+	case InnerList:
+		b.WriteByte('(')
+		for i, item := range m.Items {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			if err := serializeItem(b, item); err != nil {
+				return err
+			}
+		}
+		b.WriteByte(')')
+		return serializeParams(b, m.Params)
+	}
+	return fmt.Errorf("httpx: cannot serialize structured field member %T", m)
+}
+
+func serializeItem(b *strings.Builder, item Item) error {
+	if err := serializeBareItem(b, item.Value); err != nil {
+		return err
+	}
+	return serializeParams(b, item.Params)
+}
+
+func serializeParams(b *strings.Builder, params Params) error {
+	for _, p := range params {
+		b.WriteByte(';')
+		if err := serializeKey(b, p.Name); err != nil {
+			return err
+		}
+		if p.Value == true {
+			continue
+		}
+		b.WriteByte('=')
+		if err := serializeBareItem(b, p.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func serializeKey(b *strings.Builder, key string) error {
+	if key == "" || !(key[0] >= 'a' && key[0] <= 'z' || key[0] == '*') {
+		return fmt.Errorf("httpx: invalid structured field key %q", key)
+	}
+	for i := 1; i < len(key); i++ {
+		if !isKeyChar(key[i]) {
+			return fmt.Errorf("httpx: invalid structured field key %q", key)
+		}
+	}
+	b.WriteString(key)
+	return nil
+}
+
+func serializeBareItem(b *strings.Builder, v interface{}) error {
+	switch v := v.(type) {
+	case int:
+		return serializeInteger(b, int64(v))
+	case int64:
+		return serializeInteger(b, v)
+	case float64:
+		return serializeDecimal(b, v)
+	case string:
+		b.WriteByte('"')
+		for i := 0; i < len(v); i++ {
+			c := v[i]
+			if c < 0x20 || c > 0x7e {
+				return fmt.Errorf("httpx: structured field string %q has a non-printable or non-ASCII character", v)
+			}
+			if c == '"' || c == '\\' {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(c)
+		}
+		b.WriteByte('"')
+	case Token:
+		if v == "" || !(v[0] == '*' || v[0] >= 'a' && v[0] <= 'z' || v[0] >= 'A' && v[0] <= 'Z') {
+			return fmt.Errorf("httpx: invalid structured field token %q", v)
+		}
+		for i := 1; i < len(v); i++ {
+			if !isTokenChar(v[i]) && v[i] != ':' && v[i] != '/' {
+				return fmt.Errorf("httpx: invalid structured field token %q", v)
+			}
+		}
+		b.WriteString(string(v))
+	case []byte:
+		b.WriteByte(':')
+		b.WriteString(base64.StdEncoding.EncodeToString(v))
+		b.WriteByte(':')
+	case bool:
+		if v {
+			b.WriteString("?1")
+		} else {
+			b.WriteString("?0")
+		}
+	default:
+		return fmt.Errorf("httpx: cannot serialize %T as a structured field item", v)
+	}
+	return nil
+}
+
+func serializeInteger(b *strings.Builder, n int64) error {
+	if n < -999999999999999 || n > 999999999999999 {
+		return fmt.Errorf("httpx: structured field integer %d out of range", n)
+	}
+	b.WriteString(strconv.FormatInt(n, 10))
+	return nil
+}
+
+// serializeDecimal writes f rounded to three decimal places, ties to
+// even, with trailing zeros removed but at least one fractional digit.
+func serializeDecimal(b *strings.Builder, f float64) error {
+	rounded := math.RoundToEven(f*1000) / 1000
+	if math.IsNaN(f) || math.IsInf(f, 0) || math.Abs(rounded) >= 1e12 {
+		return fmt.Errorf("httpx: structured field decimal %v out of range", f)
+	}
+	s := strconv.FormatFloat(rounded, 'f', 3, 64)
+	s = strings.TrimRight(s, "0")
+	if strings.HasSuffix(s, ".") {
+		s += "0"
+	}
+	b.WriteString(s)
+	return nil
+}
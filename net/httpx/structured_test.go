@@ -0,0 +1,117 @@
+package httpx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_ParseList(t *testing.T) {
+	list, err := ParseList(`  sugar, "tea"; hot, (:YWJj: ?0 -1.5);lvl=5, 42;q=0.9  `)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	expected := List{
+		Item{Value: Token("sugar")},
+		Item{Value: "tea", Params: Params{{"hot", true}}},
+		InnerList{Items: []Item{{Value: []byte("abc")}, {Value: false}, {Value: -1.5}}, Params: Params{{"lvl", int64(5)}}},
+		Item{Value: int64(42), Params: Params{{"q", 0.9}}},
+	}
+	if !reflect.DeepEqual(list, expected) {
+		t.Errorf("expected %#v but got %#v", expected, list)
+	}
+
+	if list, err := ParseList(""); err != nil || len(list) != 0 {
+		t.Errorf("expected an empty list but got %v (%v)", list, err)
+	}
+
+	for _, value := range []string{
+		"a,", "a,,b", "a b", "(a b", "(a,b)", `"unterminated`, `"bad \n escape"`,
+		"1234567890123456", "1234567890123.0", "1.2345", "1.", "-", "?2", ":YWJj", ":Y!Jj:",
+		"a;B=1", "é", "\ta",
+	} {
+		if list, err := ParseList(value); err == nil {
+			t.Errorf("expected %q to fail but got %#v", value, list)
+		}
+	}
+}
+
+func Test_ParseDictionary(t *testing.T) {
+	dict, err := ParseDictionary("u=1, i, a=(1 2), u=3;x")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(dict) != 3 || dict[0].Name != "u" {
+		t.Errorf("expected duplicate keys to be replaced in place but got %#v", dict)
+	}
+	if m, _ := dict.Get("u"); !reflect.DeepEqual(m, Item{Value: int64(3), Params: Params{{"x", true}}}) {
+		t.Errorf("unexpected member u %#v", m)
+	}
+	if m, _ := dict.Get("i"); !reflect.DeepEqual(m, Item{Value: true}) {
+		t.Errorf("unexpected member i %#v", m)
+	}
+	if _, ok := dict.Get("missing"); ok {
+		t.Errorf("expected missing member to be absent")
+	}
+
+	if _, err := ParseDictionary("A=1"); err == nil {
+		t.Errorf("expected an uppercase key to fail")
+	}
+}
+
+func Test_ParseItem(t *testing.T) {
+	tests := map[string]Item{
+		`"a \"quoted\" \\ string"`: {Value: `a "quoted" \ string`},
+		"*foo/bar:baz":             {Value: Token("*foo/bar:baz")},
+		"?1;a;b=?0":                {Value: true, Params: Params{{"a", true}, {"b", false}}},
+		"-999999999999999":         {Value: int64(-999999999999999)},
+		"123456789012.123":         {Value: 123456789012.123},
+		":cHJldGVuZCB0aGlzIGlzIGJpbmFyeSBjb250ZW50Lg==:": {Value: []byte("pretend this is binary content.")},
+		":YWI:": {Value: []byte("ab")},
+	}
+	for value, expected := range tests {
+		item, err := ParseItem(value)
+		if err != nil || !reflect.DeepEqual(item, expected) {
+			t.Errorf("expected %q to give %#v but got %#v (%v)", value, expected, item, err)
+		}
+	}
+}
+
+func Test_SerializeStructuredFields(t *testing.T) {
+	list := List{
+		Item{Value: Token("sugar")},
+		Item{Value: `say "hi"`, Params: Params{{"hot", true}, {"n", 5}}},
+		InnerList{Items: []Item{{Value: []byte("abc")}, {Value: false}}, Params: Params{{"lvl", 1.0}}},
+		Item{Value: 0.12345},
+		Item{Value: 0.0625},
+	}
+	s, err := SerializeList(list)
+	expected := `sugar, "say \"hi\"";hot;n=5, (:YWJj: ?0);lvl=1.0, 0.123, 0.062`
+	if err != nil || s != expected {
+		t.Errorf("expected '%s' but got '%s' (%v)", expected, s, err)
+	}
+
+	dict := Dictionary{{"u", Item{Value: 1}}, {"i", Item{Value: true}}, {"a", InnerList{}}}
+	if s, err := SerializeDictionary(dict); err != nil || s != "u=1, i, a=()" {
+		t.Errorf("unexpected dictionary '%s' (%v)", s, err)
+	}
+
+	for _, item := range []Item{
+		{Value: int64(1e15)}, {Value: 1e12}, {Value: "café"}, {Value: Token("1a")},
+		{Value: Token("a b")}, {Value: uint8(1)}, {Value: 1, Params: Params{{"Key", true}}},
+	} {
+		if s, err := SerializeItem(item); err == nil {
+			t.Errorf("expected %#v to fail but got '%s'", item, s)
+		}
+	}
+
+	for _, value := range []string{`a;b=1, ("x" *y);z`, "u, i;p=:AA==:;q=?0"} {
+		list, err := ParseList(value)
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+			continue
+		}
+		if s, _ := SerializeList(list); s != value {
+			t.Errorf("expected '%s' to round-trip but got '%s'", value, s)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package stringx
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// caseOptions holds the options accepted by the case-mapping functions,
+// which mirror the symbols accepted by Ruby's String#upcase and friends:
+//
+//	"ascii"       only ASCII letters are mapped
+//	"turkic"      Turkic and Azeri rules for dotted and dotless i
+//	"lithuanian"  accepted for compatibility; currently has no effect
+//	"fold"        full Unicode case folding (Downcase only)
+type caseOptions struct {
+	ascii, turkic, fold bool
+}
+
+func parseCaseOptions(fn string, options []string) caseOptions {
+	var o caseOptions
+	for _, option := range options {
+		switch option {
+		case "ascii":
+			o.ascii = true
+		case "turkic":
+			o.turkic = true
+		case "lithuanian":
+		case "fold":
+			if fn != "Downcase" {
+				panic(fmt.Sprintf("stringx: %s: option \"fold\" only allowed for Downcase", fn))
+			}
+			o.fold = true
+		default:
+			panic(fmt.Sprintf("stringx: %s: invalid option %q", fn, option))
+		}
+	}
+	if o.ascii && len(options) > 1 {
+		panic(fmt.Sprintf("stringx: %s: option \"ascii\" can't be combined with others", fn))
+	}
+	return o
+}
+
+// Capitalize returns a copy of str with the first character converted to
+// titlecase and the remainder to lowercase. Options are as for Upcase.
+//
+//	Capitalize("hello")               // "Hello"
+//	Capitalize("HELLO WORLD")         // "Hello world"
+//	Capitalize("ßtraße")              // "Sstraße"
+//	Capitalize("ǆemal")               // "ǅemal"
+//	Capitalize("iSTANBUL", "turkic")  // "İstanbul"
+func Capitalize(str string, options ...string) string {
+	o := parseCaseOptions("Capitalize", options)
+	rs := []rune(str)
+	var sb strings.Builder
+	for i, r := range rs {
+		if i == 0 {
+			sb.WriteString(titleRune(r, o))
+		} else {
+			sb.WriteString(lowerRune(rs, i, o))
+		}
+	}
+	return sb.String()
+}
+
+// CaseCmpFold returns true if str and other are equal after Unicode case
+// folding, and false otherwise, like Ruby's casecmp?.
+//
+//	CaseCmpFold("aBcDeF", "abcdef")  // true
+//	CaseCmpFold("aBcDeF", "abcdeg")  // false
+//	CaseCmpFold("äöü", "ÄÖÜ")        // true
+//	CaseCmpFold("straße", "STRASSE") // true
+func CaseCmpFold(str, other string) bool {
+	return Downcase(str, "fold") == Downcase(other, "fold")
+}
+
+// Downcase returns a copy of str with all uppercase characters replaced
+// by their lowercase counterparts, using full Unicode case mapping: a
+// capital sigma becomes a final sigma at the end of a word, and a capital
+// I with dot above becomes an i followed by a combining dot.
+//
+// Pass "ascii" to map only ASCII letters, "turkic" to map I to dotless ı
+// and İ to i, or "fold" to apply full Unicode case folding instead, which
+// is suited to caseless comparison.
+//
+//	Downcase("hEllO")              // "hello"
+//	Downcase("ὈΔΥΣΣΕΎΣ")           // "ὀδυσσεύς"
+//	Downcase("ÄÖÜ", "ascii")       // "ÄÖÜ"
+//	Downcase("ISPARTA", "turkic")  // "ısparta"
+//	Downcase("Straße", "fold")     // "strasse"
+func Downcase(str string, options ...string) string {
+	o := parseCaseOptions("Downcase", options)
+	rs := []rune(str)
+	var sb strings.Builder
+	for i, r := range rs {
+		if o.fold {
+			sb.WriteString(foldRune(r, o))
+		} else {
+			sb.WriteString(lowerRune(rs, i, o))
+		}
+	}
+	return sb.String()
+}
+
+// Swapcase returns a copy of str with uppercase (and titlecase)
+// characters converted to lowercase and lowercase characters converted to
+// uppercase. Options are as for Upcase.
+//
+//	Swapcase("Hello")          // "hELLO"
+//	Swapcase("cYbEr_PuNk11")   // "CyBeR_pUnK11"
+//	Swapcase("Straße")         // "sTRASSE"
+func Swapcase(str string, options ...string) string {
+	o := parseCaseOptions("Swapcase", options)
+	rs := []rune(str)
+	var sb strings.Builder
+	for i, r := range rs {
+		switch {
+		case unicode.IsLower(r):
+			sb.WriteString(upperRune(r, o))
+		case unicode.IsUpper(r) || unicode.IsTitle(r):
+			sb.WriteString(lowerRune(rs, i, o))
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// Upcase returns a copy of str with all lowercase characters replaced by
+// their uppercase counterparts, using full Unicode case mapping, so that
+// some characters expand (ß becomes SS).
+//
+// Pass "ascii" to map only ASCII letters, or "turkic" to map i to İ.
+//
+//	Upcase("hEllO")              // "HELLO"
+//	Upcase("straße")             // "STRASSE"
+//	Upcase("äöü", "ascii")       // "äöü"
+//	Upcase("istanbul", "turkic") // "İSTANBUL"
+func Upcase(str string, options ...string) string {
+	o := parseCaseOptions("Upcase", options)
+	var sb strings.Builder
+	for _, r := range str {
+		sb.WriteString(upperRune(r, o))
+	}
+	return sb.String()
+}
+
+func upperRune(r rune, o caseOptions) string {
+	switch {
+	case o.ascii:
+		if r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		return string(r)
+	case o.turkic && r == 'i':
+		return "İ"
+	}
+	if s, ok := specialUpper[r]; ok {
+		return s
+	}
+	return string(unicode.ToUpper(r))
+}
+
+func titleRune(r rune, o caseOptions) string {
+	if o.ascii || (o.turkic && r == 'i') {
+		return upperRune(r, o)
+	}
+	if s, ok := specialTitle[r]; ok {
+		return s
+	}
+	return string(unicode.ToTitle(r))
+}
+
+// lowerRune lowercases rs[i], looking at the runes around it to decide
+// whether a capital sigma is final.
+func lowerRune(rs []rune, i int, o caseOptions) string {
+	r := rs[i]
+	switch {
+	case o.ascii:
+		if r >= 'A' && r <= 'Z' {
+			r += 'a' - 'A'
+		}
+		return string(r)
+	case o.turkic && r == 'I':
+		return "ı"
+	case o.turkic && r == 'İ':
+		return "i"
+	case r == 'Σ' && isFinalSigma(rs, i):
+		return "ς"
+	}
+	if s, ok := specialLower[r]; ok {
+		return s
+	}
+	return string(unicode.ToLower(r))
+}
+
+func foldRune(r rune, o caseOptions) string {
+	switch {
+	case o.turkic && r == 'I':
+		return "ı"
+	case o.turkic && r == 'İ':
+		return "i"
+	case r == 'ı':
+		return string(r)
+	case r >= 0x13A0 && r <= 0x13F5:
+		// Cherokee folds to its uppercase letters, which came first.
+		return string(r)
+	case (r >= 0x13F8 && r <= 0x13FD) || (r >= 0xAB70 && r <= 0xABBF):
+		return string(unicode.ToUpper(r))
+	}
+	if s, ok := specialFold[r]; ok {
+		return s
+	}
+	if u := unicode.ToUpper(r); u != r {
+		return string(unicode.ToLower(u))
+	}
+	return string(unicode.ToLower(r))
+}
+
+// isFinalSigma implements the Final_Sigma condition of the Unicode
+// standard: the sigma at rs[i] follows a cased letter and isn't followed
+// by one, ignoring case-ignorable characters in between.
+func isFinalSigma(rs []rune, i int) bool {
+	j := i - 1
+	for j >= 0 && isCaseIgnorable(rs[j]) {
+		j--
+	}
+	if j < 0 || !isCased(rs[j]) {
+		return false
+	}
+	j = i + 1
+	for j < len(rs) && isCaseIgnorable(rs[j]) {
+		j++
+	}
+	return j == len(rs) || !isCased(rs[j])
+}
+
+func isCased(r rune) bool {
+	return unicode.IsUpper(r) || unicode.IsLower(r) || unicode.IsTitle(r) ||
+		unicode.In(r, unicode.Other_Lowercase, unicode.Other_Uppercase)
+}
+
+func isCaseIgnorable(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf, unicode.Lm, unicode.Sk) ||
+		strings.ContainsRune("'.:\u00AD\u00B7\u0387\u055F\u05F4\u2018\u2019\u2024\u2027\uFE13\uFE52\uFE55\uFF07\uFF0E\uFF1A", r)
+}
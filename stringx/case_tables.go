@@ -0,0 +1,278 @@
+package stringx
+
+// The tables below hold the case mappings that expand a rune into several
+// runes, which package unicode doesn't provide. They are taken from the
+// unconditional mappings in SpecialCasing.txt and the full (F) mappings in
+// CaseFolding.txt of Unicode 14.0.0. Single-rune mappings come from package
+// unicode.
+
+// specialUpper maps runes whose uppercase form is more than one rune.
+var specialUpper = map[rune]string{
+	0x00DF: "\u0053\u0053",       // Latin Small Letter Sharp S
+	0x0149: "\u02BC\u004E",       // Latin Small Letter N Preceded By Apostrophe
+	0x01F0: "\u004A\u030C",       // Latin Small Letter J With Caron
+	0x0390: "\u0399\u0308\u0301", // Greek Small Letter Iota With Dialytika And Tonos
+	0x03B0: "\u03A5\u0308\u0301", // Greek Small Letter Upsilon With Dialytika And Tonos
+	0x0587: "\u0535\u0552",       // Armenian Small Ligature Ech Yiwn
+	0x1E96: "\u0048\u0331",       // Latin Small Letter H With Line Below
+	0x1E97: "\u0054\u0308",       // Latin Small Letter T With Diaeresis
+	0x1E98: "\u0057\u030A",       // Latin Small Letter W With Ring Above
+	0x1E99: "\u0059\u030A",       // Latin Small Letter Y With Ring Above
+	0x1E9A: "\u0041\u02BE",       // Latin Small Letter A With Right Half Ring
+	0x1F50: "\u03A5\u0313",       // Greek Small Letter Upsilon With Psili
+	0x1F52: "\u03A5\u0313\u0300", // Greek Small Letter Upsilon With Psili And Varia
+	0x1F54: "\u03A5\u0313\u0301", // Greek Small Letter Upsilon With Psili And Oxia
+	0x1F56: "\u03A5\u0313\u0342", // Greek Small Letter Upsilon With Psili And Perispomeni
+	0x1F80: "\u1F08\u0399",       // Greek Small Letter Alpha With Psili And Ypogegrammeni
+	0x1F81: "\u1F09\u0399",       // Greek Small Letter Alpha With Dasia And Ypogegrammeni
+	0x1F82: "\u1F0A\u0399",       // Greek Small Letter Alpha With Psili And Varia And Ypogegrammeni
+	0x1F83: "\u1F0B\u0399",       // Greek Small Letter Alpha With Dasia And Varia And Ypogegrammeni
+	0x1F84: "\u1F0C\u0399",       // Greek Small Letter Alpha With Psili And Oxia And Ypogegrammeni
+	0x1F85: "\u1F0D\u0399",       // Greek Small Letter Alpha With Dasia And Oxia And Ypogegrammeni
+	0x1F86: "\u1F0E\u0399",       // Greek Small Letter Alpha With Psili And Perispomeni And Ypogegrammeni
+	0x1F87: "\u1F0F\u0399",       // Greek Small Letter Alpha With Dasia And Perispomeni And Ypogegrammeni
+	0x1F88: "\u1F08\u0399",       // Greek Capital Letter Alpha With Psili And Prosgegrammeni
+	0x1F89: "\u1F09\u0399",       // Greek Capital Letter Alpha With Dasia And Prosgegrammeni
+	0x1F8A: "\u1F0A\u0399",       // Greek Capital Letter Alpha With Psili And Varia And Prosgegrammeni
+	0x1F8B: "\u1F0B\u0399",       // Greek Capital Letter Alpha With Dasia And Varia And Prosgegrammeni
+	0x1F8C: "\u1F0C\u0399",       // Greek Capital Letter Alpha With Psili And Oxia And Prosgegrammeni
+	0x1F8D: "\u1F0D\u0399",       // Greek Capital Letter Alpha With Dasia And Oxia And Prosgegrammeni
+	0x1F8E: "\u1F0E\u0399",       // Greek Capital Letter Alpha With Psili And Perispomeni And Prosgegrammeni
+	0x1F8F: "\u1F0F\u0399",       // Greek Capital Letter Alpha With Dasia And Perispomeni And Prosgegrammeni
+	0x1F90: "\u1F28\u0399",       // Greek Small Letter Eta With Psili And Ypogegrammeni
+	0x1F91: "\u1F29\u0399",       // Greek Small Letter Eta With Dasia And Ypogegrammeni
+	0x1F92: "\u1F2A\u0399",       // Greek Small Letter Eta With Psili And Varia And Ypogegrammeni
+	0x1F93: "\u1F2B\u0399",       // Greek Small Letter Eta With Dasia And Varia And Ypogegrammeni
+	0x1F94: "\u1F2C\u0399",       // Greek Small Letter Eta With Psili And Oxia And Ypogegrammeni
+	0x1F95: "\u1F2D\u0399",       // Greek Small Letter Eta With Dasia And Oxia And Ypogegrammeni
+	0x1F96: "\u1F2E\u0399",       // Greek Small Letter Eta With Psili And Perispomeni And Ypogegrammeni
+	0x1F97: "\u1F2F\u0399",       // Greek Small Letter Eta With Dasia And Perispomeni And Ypogegrammeni
+	0x1F98: "\u1F28\u0399",       // Greek Capital Letter Eta With Psili And Prosgegrammeni
+	0x1F99: "\u1F29\u0399",       // Greek Capital Letter Eta With Dasia And Prosgegrammeni
+	0x1F9A: "\u1F2A\u0399",       // Greek Capital Letter Eta With Psili And Varia And Prosgegrammeni
+	0x1F9B: "\u1F2B\u0399",       // Greek Capital Letter Eta With Dasia And Varia And Prosgegrammeni
+	0x1F9C: "\u1F2C\u0399",       // Greek Capital Letter Eta With Psili And Oxia And Prosgegrammeni
+	0x1F9D: "\u1F2D\u0399",       // Greek Capital Letter Eta With Dasia And Oxia And Prosgegrammeni
+	0x1F9E: "\u1F2E\u0399",       // Greek Capital Letter Eta With Psili And Perispomeni And Prosgegrammeni
+	0x1F9F: "\u1F2F\u0399",       // Greek Capital Letter Eta With Dasia And Perispomeni And Prosgegrammeni
+	0x1FA0: "\u1F68\u0399",       // Greek Small Letter Omega With Psili And Ypogegrammeni
+	0x1FA1: "\u1F69\u0399",       // Greek Small Letter Omega With Dasia And Ypogegrammeni
+	0x1FA2: "\u1F6A\u0399",       // Greek Small Letter Omega With Psili And Varia And Ypogegrammeni
+	0x1FA3: "\u1F6B\u0399",       // Greek Small Letter Omega With Dasia And Varia And Ypogegrammeni
+	0x1FA4: "\u1F6C\u0399",       // Greek Small Letter Omega With Psili And Oxia And Ypogegrammeni
+	0x1FA5: "\u1F6D\u0399",       // Greek Small Letter Omega With Dasia And Oxia And Ypogegrammeni
+	0x1FA6: "\u1F6E\u0399",       // Greek Small Letter Omega With Psili And Perispomeni And Ypogegrammeni
+	0x1FA7: "\u1F6F\u0399",       // Greek Small Letter Omega With Dasia And Perispomeni And Ypogegrammeni
+	0x1FA8: "\u1F68\u0399",       // Greek Capital Letter Omega With Psili And Prosgegrammeni
+	0x1FA9: "\u1F69\u0399",       // Greek Capital Letter Omega With Dasia And Prosgegrammeni
+	0x1FAA: "\u1F6A\u0399",       // Greek Capital Letter Omega With Psili And Varia And Prosgegrammeni
+	0x1FAB: "\u1F6B\u0399",       // Greek Capital Letter Omega With Dasia And Varia And Prosgegrammeni
+	0x1FAC: "\u1F6C\u0399",       // Greek Capital Letter Omega With Psili And Oxia And Prosgegrammeni
+	0x1FAD: "\u1F6D\u0399",       // Greek Capital Letter Omega With Dasia And Oxia And Prosgegrammeni
+	0x1FAE: "\u1F6E\u0399",       // Greek Capital Letter Omega With Psili And Perispomeni And Prosgegrammeni
+	0x1FAF: "\u1F6F\u0399",       // Greek Capital Letter Omega With Dasia And Perispomeni And Prosgegrammeni
+	0x1FB2: "\u1FBA\u0399",       // Greek Small Letter Alpha With Varia And Ypogegrammeni
+	0x1FB3: "\u0391\u0399",       // Greek Small Letter Alpha With Ypogegrammeni
+	0x1FB4: "\u0386\u0399",       // Greek Small Letter Alpha With Oxia And Ypogegrammeni
+	0x1FB6: "\u0391\u0342",       // Greek Small Letter Alpha With Perispomeni
+	0x1FB7: "\u0391\u0342\u0399", // Greek Small Letter Alpha With Perispomeni And Ypogegrammeni
+	0x1FBC: "\u0391\u0399",       // Greek Capital Letter Alpha With Prosgegrammeni
+	0x1FC2: "\u1FCA\u0399",       // Greek Small Letter Eta With Varia And Ypogegrammeni
+	0x1FC3: "\u0397\u0399",       // Greek Small Letter Eta With Ypogegrammeni
+	0x1FC4: "\u0389\u0399",       // Greek Small Letter Eta With Oxia And Ypogegrammeni
+	0x1FC6: "\u0397\u0342",       // Greek Small Letter Eta With Perispomeni
+	0x1FC7: "\u0397\u0342\u0399", // Greek Small Letter Eta With Perispomeni And Ypogegrammeni
+	0x1FCC: "\u0397\u0399",       // Greek Capital Letter Eta With Prosgegrammeni
+	0x1FD2: "\u0399\u0308\u0300", // Greek Small Letter Iota With Dialytika And Varia
+	0x1FD3: "\u0399\u0308\u0301", // Greek Small Letter Iota With Dialytika And Oxia
+	0x1FD6: "\u0399\u0342",       // Greek Small Letter Iota With Perispomeni
+	0x1FD7: "\u0399\u0308\u0342", // Greek Small Letter Iota With Dialytika And Perispomeni
+	0x1FE2: "\u03A5\u0308\u0300", // Greek Small Letter Upsilon With Dialytika And Varia
+	0x1FE3: "\u03A5\u0308\u0301", // Greek Small Letter Upsilon With Dialytika And Oxia
+	0x1FE4: "\u03A1\u0313",       // Greek Small Letter Rho With Psili
+	0x1FE6: "\u03A5\u0342",       // Greek Small Letter Upsilon With Perispomeni
+	0x1FE7: "\u03A5\u0308\u0342", // Greek Small Letter Upsilon With Dialytika And Perispomeni
+	0x1FF2: "\u1FFA\u0399",       // Greek Small Letter Omega With Varia And Ypogegrammeni
+	0x1FF3: "\u03A9\u0399",       // Greek Small Letter Omega With Ypogegrammeni
+	0x1FF4: "\u038F\u0399",       // Greek Small Letter Omega With Oxia And Ypogegrammeni
+	0x1FF6: "\u03A9\u0342",       // Greek Small Letter Omega With Perispomeni
+	0x1FF7: "\u03A9\u0342\u0399", // Greek Small Letter Omega With Perispomeni And Ypogegrammeni
+	0x1FFC: "\u03A9\u0399",       // Greek Capital Letter Omega With Prosgegrammeni
+	0xFB00: "\u0046\u0046",       // Latin Small Ligature Ff
+	0xFB01: "\u0046\u0049",       // Latin Small Ligature Fi
+	0xFB02: "\u0046\u004C",       // Latin Small Ligature Fl
+	0xFB03: "\u0046\u0046\u0049", // Latin Small Ligature Ffi
+	0xFB04: "\u0046\u0046\u004C", // Latin Small Ligature Ffl
+	0xFB05: "\u0053\u0054",       // Latin Small Ligature Long S T
+	0xFB06: "\u0053\u0054",       // Latin Small Ligature St
+	0xFB13: "\u0544\u0546",       // Armenian Small Ligature Men Now
+	0xFB14: "\u0544\u0535",       // Armenian Small Ligature Men Ech
+	0xFB15: "\u0544\u053B",       // Armenian Small Ligature Men Ini
+	0xFB16: "\u054E\u0546",       // Armenian Small Ligature Vew Now
+	0xFB17: "\u0544\u053D",       // Armenian Small Ligature Men Xeh
+}
+
+// specialTitle maps runes whose titlecase form is more than one rune.
+var specialTitle = map[rune]string{
+	0x00DF: "\u0053\u0073",       // Latin Small Letter Sharp S
+	0x0149: "\u02BC\u004E",       // Latin Small Letter N Preceded By Apostrophe
+	0x01F0: "\u004A\u030C",       // Latin Small Letter J With Caron
+	0x0390: "\u0399\u0308\u0301", // Greek Small Letter Iota With Dialytika And Tonos
+	0x03B0: "\u03A5\u0308\u0301", // Greek Small Letter Upsilon With Dialytika And Tonos
+	0x0587: "\u0535\u0582",       // Armenian Small Ligature Ech Yiwn
+	0x1E96: "\u0048\u0331",       // Latin Small Letter H With Line Below
+	0x1E97: "\u0054\u0308",       // Latin Small Letter T With Diaeresis
+	0x1E98: "\u0057\u030A",       // Latin Small Letter W With Ring Above
+	0x1E99: "\u0059\u030A",       // Latin Small Letter Y With Ring Above
+	0x1E9A: "\u0041\u02BE",       // Latin Small Letter A With Right Half Ring
+	0x1F50: "\u03A5\u0313",       // Greek Small Letter Upsilon With Psili
+	0x1F52: "\u03A5\u0313\u0300", // Greek Small Letter Upsilon With Psili And Varia
+	0x1F54: "\u03A5\u0313\u0301", // Greek Small Letter Upsilon With Psili And Oxia
+	0x1F56: "\u03A5\u0313\u0342", // Greek Small Letter Upsilon With Psili And Perispomeni
+	0x1FB2: "\u1FBA\u0345",       // Greek Small Letter Alpha With Varia And Ypogegrammeni
+	0x1FB4: "\u0386\u0345",       // Greek Small Letter Alpha With Oxia And Ypogegrammeni
+	0x1FB6: "\u0391\u0342",       // Greek Small Letter Alpha With Perispomeni
+	0x1FB7: "\u0391\u0342\u0345", // Greek Small Letter Alpha With Perispomeni And Ypogegrammeni
+	0x1FC2: "\u1FCA\u0345",       // Greek Small Letter Eta With Varia And Ypogegrammeni
+	0x1FC4: "\u0389\u0345",       // Greek Small Letter Eta With Oxia And Ypogegrammeni
+	0x1FC6: "\u0397\u0342",       // Greek Small Letter Eta With Perispomeni
+	0x1FC7: "\u0397\u0342\u0345", // Greek Small Letter Eta With Perispomeni And Ypogegrammeni
+	0x1FD2: "\u0399\u0308\u0300", // Greek Small Letter Iota With Dialytika And Varia
+	0x1FD3: "\u0399\u0308\u0301", // Greek Small Letter Iota With Dialytika And Oxia
+	0x1FD6: "\u0399\u0342",       // Greek Small Letter Iota With Perispomeni
+	0x1FD7: "\u0399\u0308\u0342", // Greek Small Letter Iota With Dialytika And Perispomeni
+	0x1FE2: "\u03A5\u0308\u0300", // Greek Small Letter Upsilon With Dialytika And Varia
+	0x1FE3: "\u03A5\u0308\u0301", // Greek Small Letter Upsilon With Dialytika And Oxia
+	0x1FE4: "\u03A1\u0313",       // Greek Small Letter Rho With Psili
+	0x1FE6: "\u03A5\u0342",       // Greek Small Letter Upsilon With Perispomeni
+	0x1FE7: "\u03A5\u0308\u0342", // Greek Small Letter Upsilon With Dialytika And Perispomeni
+	0x1FF2: "\u1FFA\u0345",       // Greek Small Letter Omega With Varia And Ypogegrammeni
+	0x1FF4: "\u038F\u0345",       // Greek Small Letter Omega With Oxia And Ypogegrammeni
+	0x1FF6: "\u03A9\u0342",       // Greek Small Letter Omega With Perispomeni
+	0x1FF7: "\u03A9\u0342\u0345", // Greek Small Letter Omega With Perispomeni And Ypogegrammeni
+	0xFB00: "\u0046\u0066",       // Latin Small Ligature Ff
+	0xFB01: "\u0046\u0069",       // Latin Small Ligature Fi
+	0xFB02: "\u0046\u006C",       // Latin Small Ligature Fl
+	0xFB03: "\u0046\u0066\u0069", // Latin Small Ligature Ffi
+	0xFB04: "\u0046\u0066\u006C", // Latin Small Ligature Ffl
+	0xFB05: "\u0053\u0074",       // Latin Small Ligature Long S T
+	0xFB06: "\u0053\u0074",       // Latin Small Ligature St
+	0xFB13: "\u0544\u0576",       // Armenian Small Ligature Men Now
+	0xFB14: "\u0544\u0565",       // Armenian Small Ligature Men Ech
+	0xFB15: "\u0544\u056B",       // Armenian Small Ligature Men Ini
+	0xFB16: "\u054E\u0576",       // Armenian Small Ligature Vew Now
+	0xFB17: "\u0544\u056D",       // Armenian Small Ligature Men Xeh
+}
+
+// specialLower maps runes whose lowercase form is more than one rune.
+var specialLower = map[rune]string{
+	0x0130: "\u0069\u0307", // Latin Capital Letter I With Dot Above
+}
+
+// specialFold maps runes whose full case folding is more than one rune.
+var specialFold = map[rune]string{
+	0x00DF: "\u0073\u0073",       // Latin Small Letter Sharp S
+	0x0130: "\u0069\u0307",       // Latin Capital Letter I With Dot Above
+	0x0149: "\u02BC\u006E",       // Latin Small Letter N Preceded By Apostrophe
+	0x01F0: "\u006A\u030C",       // Latin Small Letter J With Caron
+	0x0390: "\u03B9\u0308\u0301", // Greek Small Letter Iota With Dialytika And Tonos
+	0x03B0: "\u03C5\u0308\u0301", // Greek Small Letter Upsilon With Dialytika And Tonos
+	0x0587: "\u0565\u0582",       // Armenian Small Ligature Ech Yiwn
+	0x1E96: "\u0068\u0331",       // Latin Small Letter H With Line Below
+	0x1E97: "\u0074\u0308",       // Latin Small Letter T With Diaeresis
+	0x1E98: "\u0077\u030A",       // Latin Small Letter W With Ring Above
+	0x1E99: "\u0079\u030A",       // Latin Small Letter Y With Ring Above
+	0x1E9A: "\u0061\u02BE",       // Latin Small Letter A With Right Half Ring
+	0x1E9E: "\u0073\u0073",       // Latin Capital Letter Sharp S
+	0x1F50: "\u03C5\u0313",       // Greek Small Letter Upsilon With Psili
+	0x1F52: "\u03C5\u0313\u0300", // Greek Small Letter Upsilon With Psili And Varia
+	0x1F54: "\u03C5\u0313\u0301", // Greek Small Letter Upsilon With Psili And Oxia
+	0x1F56: "\u03C5\u0313\u0342", // Greek Small Letter Upsilon With Psili And Perispomeni
+	0x1F80: "\u1F00\u03B9",       // Greek Small Letter Alpha With Psili And Ypogegrammeni
+	0x1F81: "\u1F01\u03B9",       // Greek Small Letter Alpha With Dasia And Ypogegrammeni
+	0x1F82: "\u1F02\u03B9",       // Greek Small Letter Alpha With Psili And Varia And Ypogegrammeni
+	0x1F83: "\u1F03\u03B9",       // Greek Small Letter Alpha With Dasia And Varia And Ypogegrammeni
+	0x1F84: "\u1F04\u03B9",       // Greek Small Letter Alpha With Psili And Oxia And Ypogegrammeni
+	0x1F85: "\u1F05\u03B9",       // Greek Small Letter Alpha With Dasia And Oxia And Ypogegrammeni
+	0x1F86: "\u1F06\u03B9",       // Greek Small Letter Alpha With Psili And Perispomeni And Ypogegrammeni
+	0x1F87: "\u1F07\u03B9",       // Greek Small Letter Alpha With Dasia And Perispomeni And Ypogegrammeni
+	0x1F88: "\u1F00\u03B9",       // Greek Capital Letter Alpha With Psili And Prosgegrammeni
+	0x1F89: "\u1F01\u03B9",       // Greek Capital Letter Alpha With Dasia And Prosgegrammeni
+	0x1F8A: "\u1F02\u03B9",       // Greek Capital Letter Alpha With Psili And Varia And Prosgegrammeni
+	0x1F8B: "\u1F03\u03B9",       // Greek Capital Letter Alpha With Dasia And Varia And Prosgegrammeni
+	0x1F8C: "\u1F04\u03B9",       // Greek Capital Letter Alpha With Psili And Oxia And Prosgegrammeni
+	0x1F8D: "\u1F05\u03B9",       // Greek Capital Letter Alpha With Dasia And Oxia And Prosgegrammeni
+	0x1F8E: "\u1F06\u03B9",       // Greek Capital Letter Alpha With Psili And Perispomeni And Prosgegrammeni
+	0x1F8F: "\u1F07\u03B9",       // Greek Capital Letter Alpha With Dasia And Perispomeni And Prosgegrammeni
+	0x1F90: "\u1F20\u03B9",       // Greek Small Letter Eta With Psili And Ypogegrammeni
+	0x1F91: "\u1F21\u03B9",       // Greek Small Letter Eta With Dasia And Ypogegrammeni
+	0x1F92: "\u1F22\u03B9",       // Greek Small Letter Eta With Psili And Varia And Ypogegrammeni
+	0x1F93: "\u1F23\u03B9",       // Greek Small Letter Eta With Dasia And Varia And Ypogegrammeni
+	0x1F94: "\u1F24\u03B9",       // Greek Small Letter Eta With Psili And Oxia And Ypogegrammeni
+	0x1F95: "\u1F25\u03B9",       // Greek Small Letter Eta With Dasia And Oxia And Ypogegrammeni
+	0x1F96: "\u1F26\u03B9",       // Greek Small Letter Eta With Psili And Perispomeni And Ypogegrammeni
+	0x1F97: "\u1F27\u03B9",       // Greek Small Letter Eta With Dasia And Perispomeni And Ypogegrammeni
+	0x1F98: "\u1F20\u03B9",       // Greek Capital Letter Eta With Psili And Prosgegrammeni
+	0x1F99: "\u1F21\u03B9",       // Greek Capital Letter Eta With Dasia And Prosgegrammeni
+	0x1F9A: "\u1F22\u03B9",       // Greek Capital Letter Eta With Psili And Varia And Prosgegrammeni
+	0x1F9B: "\u1F23\u03B9",       // Greek Capital Letter Eta With Dasia And Varia And Prosgegrammeni
+	0x1F9C: "\u1F24\u03B9",       // Greek Capital Letter Eta With Psili And Oxia And Prosgegrammeni
+	0x1F9D: "\u1F25\u03B9",       // Greek Capital Letter Eta With Dasia And Oxia And Prosgegrammeni
+	0x1F9E: "\u1F26\u03B9",       // Greek Capital Letter Eta With Psili And Perispomeni And Prosgegrammeni
+	0x1F9F: "\u1F27\u03B9",       // Greek Capital Letter Eta With Dasia And Perispomeni And Prosgegrammeni
+	0x1FA0: "\u1F60\u03B9",       // Greek Small Letter Omega With Psili And Ypogegrammeni
+	0x1FA1: "\u1F61\u03B9",       // Greek Small Letter Omega With Dasia And Ypogegrammeni
+	0x1FA2: "\u1F62\u03B9",       // Greek Small Letter Omega With Psili And Varia And Ypogegrammeni
+	0x1FA3: "\u1F63\u03B9",       // Greek Small Letter Omega With Dasia And Varia And Ypogegrammeni
+	0x1FA4: "\u1F64\u03B9",       // Greek Small Letter Omega With Psili And Oxia And Ypogegrammeni
+	0x1FA5: "\u1F65\u03B9",       // Greek Small Letter Omega With Dasia And Oxia And Ypogegrammeni
+	0x1FA6: "\u1F66\u03B9",       // Greek Small Letter Omega With Psili And Perispomeni And Ypogegrammeni
+	0x1FA7: "\u1F67\u03B9",       // Greek Small Letter Omega With Dasia And Perispomeni And Ypogegrammeni
+	0x1FA8: "\u1F60\u03B9",       // Greek Capital Letter Omega With Psili And Prosgegrammeni
+	0x1FA9: "\u1F61\u03B9",       // Greek Capital Letter Omega With Dasia And Prosgegrammeni
+	0x1FAA: "\u1F62\u03B9",       // Greek Capital Letter Omega With Psili And Varia And Prosgegrammeni
+	0x1FAB: "\u1F63\u03B9",       // Greek Capital Letter Omega With Dasia And Varia And Prosgegrammeni
+	0x1FAC: "\u1F64\u03B9",       // Greek Capital Letter Omega With Psili And Oxia And Prosgegrammeni
+	0x1FAD: "\u1F65\u03B9",       // Greek Capital Letter Omega With Dasia And Oxia And Prosgegrammeni
+	0x1FAE: "\u1F66\u03B9",       // Greek Capital Letter Omega With Psili And Perispomeni And Prosgegrammeni
+	0x1FAF: "\u1F67\u03B9",       // Greek Capital Letter Omega With Dasia And Perispomeni And Prosgegrammeni
+	0x1FB2: "\u1F70\u03B9",       // Greek Small Letter Alpha With Varia And Ypogegrammeni
+	0x1FB3: "\u03B1\u03B9",       // Greek Small Letter Alpha With Ypogegrammeni
+	0x1FB4: "\u03AC\u03B9",       // Greek Small Letter Alpha With Oxia And Ypogegrammeni
+	0x1FB6: "\u03B1\u0342",       // Greek Small Letter Alpha With Perispomeni
+	0x1FB7: "\u03B1\u0342\u03B9", // Greek Small Letter Alpha With Perispomeni And Ypogegrammeni
+	0x1FBC: "\u03B1\u03B9",       // Greek Capital Letter Alpha With Prosgegrammeni
+	0x1FC2: "\u1F74\u03B9",       // Greek Small Letter Eta With Varia And Ypogegrammeni
+	0x1FC3: "\u03B7\u03B9",       // Greek Small Letter Eta With Ypogegrammeni
+	0x1FC4: "\u03AE\u03B9",       // Greek Small Letter Eta With Oxia And Ypogegrammeni
+	0x1FC6: "\u03B7\u0342",       // Greek Small Letter Eta With Perispomeni
+	0x1FC7: "\u03B7\u0342\u03B9", // Greek Small Letter Eta With Perispomeni And Ypogegrammeni
+	0x1FCC: "\u03B7\u03B9",       // Greek Capital Letter Eta With Prosgegrammeni
+	0x1FD2: "\u03B9\u0308\u0300", // Greek Small Letter Iota With Dialytika And Varia
+	0x1FD3: "\u03B9\u0308\u0301", // Greek Small Letter Iota With Dialytika And Oxia
+	0x1FD6: "\u03B9\u0342",       // Greek Small Letter Iota With Perispomeni
+	0x1FD7: "\u03B9\u0308\u0342", // Greek Small Letter Iota With Dialytika And Perispomeni
+	0x1FE2: "\u03C5\u0308\u0300", // Greek Small Letter Upsilon With Dialytika And Varia
+	0x1FE3: "\u03C5\u0308\u0301", // Greek Small Letter Upsilon With Dialytika And Oxia
+	0x1FE4: "\u03C1\u0313",       // Greek Small Letter Rho With Psili
+	0x1FE6: "\u03C5\u0342",       // Greek Small Letter Upsilon With Perispomeni
+	0x1FE7: "\u03C5\u0308\u0342", // Greek Small Letter Upsilon With Dialytika And Perispomeni
+	0x1FF2: "\u1F7C\u03B9",       // Greek Small Letter Omega With Varia And Ypogegrammeni
+	0x1FF3: "\u03C9\u03B9",       // Greek Small Letter Omega With Ypogegrammeni
+	0x1FF4: "\u03CE\u03B9",       // Greek Small Letter Omega With Oxia And Ypogegrammeni
+	0x1FF6: "\u03C9\u0342",       // Greek Small Letter Omega With Perispomeni
+	0x1FF7: "\u03C9\u0342\u03B9", // Greek Small Letter Omega With Perispomeni And Ypogegrammeni
+	0x1FFC: "\u03C9\u03B9",       // Greek Capital Letter Omega With Prosgegrammeni
+	0xFB00: "\u0066\u0066",       // Latin Small Ligature Ff
+	0xFB01: "\u0066\u0069",       // Latin Small Ligature Fi
+	0xFB02: "\u0066\u006C",       // Latin Small Ligature Fl
+	0xFB03: "\u0066\u0066\u0069", // Latin Small Ligature Ffi
+	0xFB04: "\u0066\u0066\u006C", // Latin Small Ligature Ffl
+	0xFB05: "\u0073\u0074",       // Latin Small Ligature Long S T
+	0xFB06: "\u0073\u0074",       // Latin Small Ligature St
+	0xFB13: "\u0574\u0576",       // Armenian Small Ligature Men Now
+	0xFB14: "\u0574\u0565",       // Armenian Small Ligature Men Ech
+	0xFB15: "\u0574\u056B",       // Armenian Small Ligature Men Ini
+	0xFB16: "\u057E\u0576",       // Armenian Small Ligature Vew Now
+	0xFB17: "\u0574\u056D",       // Armenian Small Ligature Men Xeh
+}
@@ -0,0 +1,85 @@
+package stringx
+
+import "testing"
+
+func Test_CaseMapping(t *testing.T) {
+	tests := []struct {
+		fn       func(string, ...string) string
+		name     string
+		str      string
+		options  []string
+		expected string
+	}{
+		{Upcase, "Upcase", "hEllO", nil, "HELLO"},
+		{Upcase, "Upcase", "straße ﬁne ŉ", nil, "STRASSE FINE ʼN"},
+		{Upcase, "Upcase", "äöü", []string{"ascii"}, "äöü"},
+		{Upcase, "Upcase", "istanbul", []string{"turkic"}, "İSTANBUL"},
+		{Upcase, "Upcase", "ıi", nil, "II"},
+		{Downcase, "Downcase", "hEllO", nil, "hello"},
+		{Downcase, "Downcase", "ÄÖÜ", nil, "äöü"},
+		{Downcase, "Downcase", "ÄÖÜ", []string{"ascii"}, "ÄÖÜ"},
+		{Downcase, "Downcase", "ὈΔΥΣΣΕΎΣ", nil, "ὀδυσσεύς"},
+		{Downcase, "Downcase", "ΣΑΣ ΣΑΣ. Σ", nil, "σας σας. σ"},
+		{Downcase, "Downcase", "ΑΣ'Α", nil, "ασ'α"},
+		{Downcase, "Downcase", "İI", nil, "i̇i"},
+		{Downcase, "Downcase", "İSPARTA", []string{"turkic"}, "isparta"},
+		{Downcase, "Downcase", "ISPARTA", []string{"turkic", "lithuanian"}, "ısparta"},
+		{Downcase, "Downcase", "Straße ΣΑΣ ﬁ ꭰ Ꭰ ſ", []string{"fold"}, "strasse σασ fi Ꭰ Ꭰ s"},
+		{Downcase, "Downcase", "IİK", []string{"fold", "turkic"}, "ıik"},
+		{Capitalize, "Capitalize", "hello", nil, "Hello"},
+		{Capitalize, "Capitalize", "HELLO WORLD", nil, "Hello world"},
+		{Capitalize, "Capitalize", "ßtraße", nil, "Sstraße"},
+		{Capitalize, "Capitalize", "ǆEMAL", nil, "ǅemal"},
+		{Capitalize, "Capitalize", "ﬁne", nil, "Fine"},
+		{Capitalize, "Capitalize", "iSTANBUL", []string{"turkic"}, "İstanbul"},
+		{Capitalize, "Capitalize", "éCOLE", []string{"ascii"}, "école"},
+		{Capitalize, "Capitalize", "", nil, ""},
+		{Swapcase, "Swapcase", "Hello", nil, "hELLO"},
+		{Swapcase, "Swapcase", "cYbEr_PuNk11", nil, "CyBeR_pUnK11"},
+		{Swapcase, "Swapcase", "Straße ǅ", nil, "sTRASSE ǆ"},
+		{Swapcase, "Swapcase", "ÄbC", []string{"ascii"}, "ÄBc"},
+		{Swapcase, "Swapcase", "Iı", []string{"turkic"}, "ıI"},
+	}
+	for _, test := range tests {
+		if got := test.fn(test.str, test.options...); got != test.expected {
+			t.Errorf("expected %s(%q, %q) to return %q but got %q", test.name, test.str, test.options, test.expected, got)
+		}
+	}
+
+	for _, options := range [][]string{{"fold"}, {"bogus"}, {"ascii", "turkic"}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected Upcase with %q to panic", options)
+				}
+			}()
+			Upcase("x", options...)
+		}()
+	}
+}
+
+func Test_CaseCmpFold(t *testing.T) {
+	tests := []struct {
+		str, other string
+		expected   bool
+	}{
+		{"aBcDeF", "abcdef", true},
+		{"aBcDeF", "abcdeg", false},
+		{"äöü", "ÄÖÜ", true},
+		{"straße", "STRASSE", true},
+		{"ΣΑΣ", "σας", true},
+		{"ﬃ", "FFI", true},
+		{"abc", "abcd", false},
+	}
+	for _, test := range tests {
+		if got := CaseCmpFold(test.str, test.other); got != test.expected {
+			t.Errorf("expected CaseCmpFold(%q, %q) to return %v but got %v", test.str, test.other, test.expected, got)
+		}
+	}
+	if result := CaseCmp("äöü", "ÄÖÜ"); result == 0 {
+		t.Errorf("expected CaseCmp to fold only ASCII but got %d", result)
+	}
+	if result := CaseCmp("abc", "B"); result != -1 {
+		t.Errorf("expected CaseCmp(\"abc\", \"B\") to return -1 but got %d", result)
+	}
+}
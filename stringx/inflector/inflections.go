@@ -0,0 +1,384 @@
+// Package inflector is a port of Rails' ActiveSupport::Inflector. It
+// pluralizes and singularizes English nouns, converts between the naming
+// styles of classes, tables, files and URLs, and lets callers register
+// inflection rules for other locales.
+package inflector
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/robicode/stdx/stringx/rubyregexp"
+)
+
+// rule is a pattern and the replacement substituted for its first match.
+// Replacements may use Ruby-style back-references such as \1.
+type rule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// Inflections holds the inflection rules of a locale. Rules added later
+// take precedence over earlier ones, so the most specific rules should be
+// added last. An Inflections is safe for concurrent use.
+type Inflections struct {
+	mu           sync.RWMutex
+	plurals      []rule
+	singulars    []rule
+	uncountables []string
+	uncountRes   []*regexp.Regexp
+	humans       []rule
+	acronyms     map[string]string
+}
+
+var (
+	localesMu sync.Mutex
+	locales   = map[string]*Inflections{}
+)
+
+// Locale returns the inflection rules for locale, creating an empty set
+// the first time a locale other than "en" is asked for. The "en" locale
+// starts out with Rails' English rules. Register rules for a locale by
+// calling methods on the result:
+//
+//	inflector.Locale("es").Plural(regexp.MustCompile(`(?i)([^aeiou])$`), `\1es`)
+//	inflector.Pluralize("papel", "es")  // "papeles"
+func Locale(locale string) *Inflections {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	in, ok := locales[locale]
+	if !ok {
+		in = &Inflections{acronyms: map[string]string{}}
+		if locale == "en" {
+			loadEnglish(in)
+		}
+		locales[locale] = in
+	}
+	return in
+}
+
+// localeOf returns the inflections for the optional locale argument of the
+// package functions, which defaults to "en".
+func localeOf(locale []string) *Inflections {
+	if len(locale) > 0 && locale[0] != "" {
+		return Locale(locale[0])
+	}
+	return Locale("en")
+}
+
+// newRule builds a rule from a *regexp.Regexp or *rubyregexp.Regexp, or
+// from a string which is matched literally.
+func newRule(pattern interface{}, replacement string) rule {
+	switch p := pattern.(type) {
+	case *regexp.Regexp:
+		return rule{p, replacement}
+	case *rubyregexp.Regexp:
+		return rule{p.Regexp, replacement}
+	case string:
+		return rule{regexp.MustCompile(regexp.QuoteMeta(p)), replacement}
+	}
+	panic(fmt.Sprintf("inflector: invalid rule type %T", pattern))
+}
+
+// Plural adds a rule for turning singular words into plurals. The pattern
+// may be a string, which is matched literally, a *regexp.Regexp or a
+// *rubyregexp.Regexp, and the replacement may refer to its groups as \1,
+// \2 and so on.
+//
+//	Locale("en").Plural(regexp.MustCompile(`(?i)(quiz)$`), `\1zes`)
+//	Locale("en").Plural(rubyregexp.MustCompile(`(?i)(quiz)\z`), `\1zes`)
+func (in *Inflections) Plural(pattern interface{}, replacement string) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if s, ok := pattern.(string); ok {
+		in.removeUncountable(s)
+	}
+	in.removeUncountable(replacement)
+	in.plurals = append([]rule{newRule(pattern, replacement)}, in.plurals...)
+}
+
+// Singular adds a rule for turning plural words into their singular form,
+// like Plural.
+//
+//	Locale("en").Singular(regexp.MustCompile(`(?i)(quiz)zes$`), `\1`)
+func (in *Inflections) Singular(pattern interface{}, replacement string) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if s, ok := pattern.(string); ok {
+		in.removeUncountable(s)
+	}
+	in.removeUncountable(replacement)
+	in.singulars = append([]rule{newRule(pattern, replacement)}, in.singulars...)
+}
+
+// Irregular adds rules for a word whose plural doesn't follow the regular
+// rules, in both directions. The first letter keeps its case, and the
+// words also match at the end of compounds.
+//
+//	Locale("en").Irregular("octopus", "octopi")
+//	Pluralize("giant_octopus")  // "giant_octopi"
+func (in *Inflections) Irregular(singular, plural string) {
+	in.mu.Lock()
+	in.removeUncountable(singular)
+	in.removeUncountable(plural)
+	in.mu.Unlock()
+
+	s0, srest := splitFirst(singular)
+	p0, prest := splitFirst(plural)
+	if strings.ToUpper(s0) == strings.ToUpper(p0) {
+		in.Plural(regexp.MustCompile(`(?i)(`+regexp.QuoteMeta(s0)+`)`+regexp.QuoteMeta(srest)+`$`), `\1`+prest)
+		in.Plural(regexp.MustCompile(`(?i)(`+regexp.QuoteMeta(p0)+`)`+regexp.QuoteMeta(prest)+`$`), `\1`+prest)
+		in.Singular(regexp.MustCompile(`(?i)(`+regexp.QuoteMeta(s0)+`)`+regexp.QuoteMeta(srest)+`$`), `\1`+srest)
+		in.Singular(regexp.MustCompile(`(?i)(`+regexp.QuoteMeta(p0)+`)`+regexp.QuoteMeta(prest)+`$`), `\1`+srest)
+		return
+	}
+	for _, first := range []func(string) string{strings.ToUpper, strings.ToLower} {
+		in.Plural(regexp.MustCompile(regexp.QuoteMeta(first(s0))+`(?i)`+regexp.QuoteMeta(srest)+`$`), first(p0)+prest)
+		in.Plural(regexp.MustCompile(regexp.QuoteMeta(first(p0))+`(?i)`+regexp.QuoteMeta(prest)+`$`), first(p0)+prest)
+		in.Singular(regexp.MustCompile(regexp.QuoteMeta(first(s0))+`(?i)`+regexp.QuoteMeta(srest)+`$`), first(s0)+srest)
+		in.Singular(regexp.MustCompile(regexp.QuoteMeta(first(p0))+`(?i)`+regexp.QuoteMeta(prest)+`$`), first(s0)+srest)
+	}
+}
+
+// Uncountable marks words that have no separate plural form, such as
+// "sheep". A word also matches at the end of a phrase.
+//
+//	Locale("en").Uncountable("fish", "rice")
+//	Pluralize("fresh fish")  // "fresh fish"
+func (in *Inflections) Uncountable(words ...string) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	for _, word := range words {
+		word = strings.ToLower(word)
+		in.removeUncountable(word)
+		in.uncountables = append(in.uncountables, word)
+		in.uncountRes = append(in.uncountRes, regexp.MustCompile(`(?i)\b`+regexp.QuoteMeta(word)+`$`))
+	}
+}
+
+// removeUncountable forgets word as uncountable. The caller must hold
+// in.mu.
+func (in *Inflections) removeUncountable(word string) {
+	word = strings.ToLower(word)
+	for i, w := range in.uncountables {
+		if w == word {
+			in.uncountables = append(in.uncountables[:i], in.uncountables[i+1:]...)
+			in.uncountRes = append(in.uncountRes[:i], in.uncountRes[i+1:]...)
+			return
+		}
+	}
+}
+
+// Human adds a rule used by Humanize, for words whose human form isn't
+// derived from the underscored one.
+//
+//	Locale("en").Human(regexp.MustCompile(`_cnt$`), `_count`)
+//	Locale("en").Human("legacy_col_person_name", "Name")
+func (in *Inflections) Human(pattern interface{}, replacement string) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.humans = append([]rule{newRule(pattern, replacement)}, in.humans...)
+}
+
+// Acronym registers word as an acronym, which Camelize, Underscore,
+// Humanize and Titleize keep in the given case. The acronym must appear
+// as a delimited unit and not be part of another word.
+//
+//	Locale("en").Acronym("HTML")
+//	Camelize("html_tidy")  // "HTMLTidy"
+//	Underscore("HTMLTidy") // "html_tidy"
+func (in *Inflections) Acronym(word string) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.acronyms[strings.ToLower(word)] = word
+}
+
+// Clear removes the rules of the given scopes, which may be "plurals",
+// "singulars", "uncountables", "humans" or "acronyms", or all rules if no
+// scope (or "all") is given.
+func (in *Inflections) Clear(scopes ...string) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if len(scopes) == 0 {
+		scopes = []string{"all"}
+	}
+	for _, scope := range scopes {
+		all := scope == "all"
+		if all || scope == "plurals" {
+			in.plurals = nil
+		}
+		if all || scope == "singulars" {
+			in.singulars = nil
+		}
+		if all || scope == "uncountables" {
+			in.uncountables, in.uncountRes = nil, nil
+		}
+		if all || scope == "humans" {
+			in.humans = nil
+		}
+		if all || scope == "acronyms" {
+			in.acronyms = map[string]string{}
+		}
+	}
+}
+
+// Uncountables returns the words marked as uncountable.
+func (in *Inflections) Uncountables() []string {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return append([]string(nil), in.uncountables...)
+}
+
+func (in *Inflections) isUncountable(word string) bool {
+	for _, re := range in.uncountRes {
+		if re.MatchString(word) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply substitutes the first matching rule in word, unless word is empty
+// or uncountable.
+func (in *Inflections) apply(word string, rules func(*Inflections) []rule) string {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	if word == "" || in.isUncountable(word) {
+		return word
+	}
+	for _, r := range rules(in) {
+		if s, ok := sub(word, r); ok {
+			return s
+		}
+	}
+	return word
+}
+
+// acronymList returns the registered acronyms, longest first.
+func (in *Inflections) acronymList() []string {
+	list := make([]string, 0, len(in.acronyms))
+	for _, a := range in.acronyms {
+		list = append(list, a)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if len(list[i]) != len(list[j]) {
+			return len(list[i]) > len(list[j])
+		}
+		return list[i] < list[j]
+	})
+	return list
+}
+
+// sub replaces the first match of r in s, expanding back-references in
+// its replacement, and reports whether there was a match.
+func sub(s string, r rule) (string, bool) {
+	loc := r.pattern.FindStringSubmatchIndex(s)
+	if loc == nil {
+		return s, false
+	}
+	var sb strings.Builder
+	sb.WriteString(s[:loc[0]])
+	repl := r.replacement
+	for i := 0; i < len(repl); i++ {
+		c := repl[i]
+		if c != '\\' || i+1 == len(repl) {
+			sb.WriteByte(c)
+			continue
+		}
+		i++
+		switch d := repl[i]; {
+		case d >= '0' && d <= '9':
+			if n := int(d - '0'); 2*n+1 < len(loc) && loc[2*n] >= 0 {
+				sb.WriteString(s[loc[2*n]:loc[2*n+1]])
+			}
+		case d == '&':
+			sb.WriteString(s[loc[0]:loc[1]])
+		default:
+			sb.WriteByte(d)
+		}
+	}
+	sb.WriteString(s[loc[1]:])
+	return sb.String(), true
+}
+
+func splitFirst(s string) (string, string) {
+	for i := range s {
+		if i > 0 {
+			return s[:i], s[i:]
+		}
+	}
+	return s, ""
+}
+
+// loadEnglish adds Rails' English inflection rules to in.
+func loadEnglish(in *Inflections) {
+	for _, r := range [][2]string{
+		{`$`, `s`},
+		{`(?i)s$`, `s`},
+		{`(?i)^(ax|test)is$`, `\1es`},
+		{`(?i)(octop|vir)us$`, `\1i`},
+		{`(?i)(octop|vir)i$`, `\1i`},
+		{`(?i)(alias|status)$`, `\1es`},
+		{`(?i)(bu)s$`, `\1ses`},
+		{`(?i)(buffal|tomat)o$`, `\1oes`},
+		{`(?i)([ti])um$`, `\1a`},
+		{`(?i)([ti])a$`, `\1a`},
+		{`(?i)sis$`, `ses`},
+		{`(?i)(?:([^f])fe|([lr])f)$`, `\1\2ves`},
+		{`(?i)(hive)$`, `\1s`},
+		{`(?i)([^aeiouy]|qu)y$`, `\1ies`},
+		{`(?i)(x|ch|ss|sh)$`, `\1es`},
+		{`(?i)(matr|vert|ind)(?:ix|ex)$`, `\1ices`},
+		{`(?i)^(m|l)ouse$`, `\1ice`},
+		{`(?i)^(m|l)ice$`, `\1ice`},
+		{`(?i)^(ox)$`, `\1en`},
+		{`(?i)^(oxen)$`, `\1`},
+		{`(?i)(quiz)$`, `\1zes`},
+	} {
+		in.Plural(regexp.MustCompile(r[0]), r[1])
+	}
+
+	for _, r := range [][2]string{
+		{`(?i)s$`, ``},
+		{`(?i)(ss)$`, `\1`},
+		{`(?i)(n)ews$`, `\1ews`},
+		{`(?i)([ti])a$`, `\1um`},
+		{`(?i)((a)naly|(b)a|(d)iagno|(p)arenthe|(p)rogno|(s)ynop|(t)he)(sis|ses)$`, `\1sis`},
+		{`(?i)(^analy)(sis|ses)$`, `\1sis`},
+		{`(?i)([^f])ves$`, `\1fe`},
+		{`(?i)(hive)s$`, `\1`},
+		{`(?i)(tive)s$`, `\1`},
+		{`(?i)([lr])ves$`, `\1f`},
+		{`(?i)([^aeiouy]|qu)ies$`, `\1y`},
+		{`(?i)(s)eries$`, `\1eries`},
+		{`(?i)(m)ovies$`, `\1ovie`},
+		{`(?i)(x|ch|ss|sh)es$`, `\1`},
+		{`(?i)^(m|l)ice$`, `\1ouse`},
+		{`(?i)(bus)(es)?$`, `\1`},
+		{`(?i)(o)es$`, `\1`},
+		{`(?i)(shoe)s$`, `\1`},
+		{`(?i)(cris|test)(is|es)$`, `\1is`},
+		{`(?i)^(a)x[ie]s$`, `\1xis`},
+		{`(?i)(octop|vir)(us|i)$`, `\1us`},
+		{`(?i)(alias|status)(es)?$`, `\1`},
+		{`(?i)^(ox)en`, `\1`},
+		{`(?i)(vert|ind)ices$`, `\1ex`},
+		{`(?i)(matr)ices$`, `\1ix`},
+		{`(?i)(quiz)zes$`, `\1`},
+		{`(?i)(database)s$`, `\1`},
+	} {
+		in.Singular(regexp.MustCompile(r[0]), r[1])
+	}
+
+	in.Irregular("person", "people")
+	in.Irregular("man", "men")
+	in.Irregular("child", "children")
+	in.Irregular("sex", "sexes")
+	in.Irregular("move", "moves")
+	in.Irregular("zombie", "zombies")
+
+	in.Uncountable("equipment", "information", "rice", "money", "species", "series", "fish", "sheep", "jeans", "police")
+}
@@ -0,0 +1,343 @@
+package inflector
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/robicode/stdx/stringx"
+)
+
+var (
+	camelizeSegment  = regexp.MustCompile(`(?i)(?:_|(/))([a-z\d]*)`)
+	leadingAlnum     = regexp.MustCompile(`^[a-z\d]*`)
+	upperBeforeWord  = regexp.MustCompile(`([A-Z\d]+)([A-Z][a-z])`)
+	lowerBeforeUpper = regexp.MustCompile(`([a-z\d])([A-Z])`)
+	alnumRun         = regexp.MustCompile(`(?i)[a-z\d]+`)
+)
+
+// Pluralize returns the plural form of the word, using the rules of the
+// given locale (which defaults to "en").
+//
+//	Pluralize("post")             // "posts"
+//	Pluralize("octopus")          // "octopi"
+//	Pluralize("sheep")            // "sheep"
+//	Pluralize("words")            // "words"
+//	Pluralize("CamelOctopus")     // "CamelOctopi"
+func Pluralize(word string, locale ...string) string {
+	return localeOf(locale).apply(word, func(in *Inflections) []rule { return in.plurals })
+}
+
+// Singularize returns the singular form of the word, the reverse of
+// Pluralize.
+//
+//	Singularize("posts")          // "post"
+//	Singularize("octopi")         // "octopus"
+//	Singularize("sheep")          // "sheep"
+//	Singularize("CamelOctopi")    // "CamelOctopus"
+func Singularize(word string, locale ...string) string {
+	return localeOf(locale).apply(word, func(in *Inflections) []rule { return in.singulars })
+}
+
+// Camelize converts an underscored string to UpperCamelCase, and "/" to
+// "::", which is useful for converting paths to namespaces.
+//
+//	Camelize("active_model")         // "ActiveModel"
+//	Camelize("active_model/errors")  // "ActiveModel::Errors"
+func Camelize(term string) string {
+	in := Locale("en")
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	loc := leadingAlnum.FindStringIndex(term)
+	first := term[:loc[1]]
+	if a, ok := in.acronyms[first]; ok {
+		first = a
+	} else {
+		first = stringx.Capitalize(first)
+	}
+	return first + in.camelizeSegments(term[loc[1]:])
+}
+
+// CamelizeLower is like Camelize, but leaves the first word in lowercase.
+//
+//	CamelizeLower("active_model")         // "activeModel"
+//	CamelizeLower("active_model/errors")  // "activeModel::Errors"
+func CamelizeLower(term string) string {
+	in := Locale("en")
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	n := 0
+	for _, a := range in.acronymList() {
+		if strings.HasPrefix(term, a) {
+			rest := term[len(a):]
+			if rest == "" || !isWordByte(rest[0]) || (rest[0] >= 'A' && rest[0] <= 'Z') || rest[0] == '_' {
+				n = len(a)
+				break
+			}
+		}
+	}
+	if n == 0 && term != "" && isWordByte(term[0]) {
+		n = 1
+	}
+	return strings.ToLower(term[:n]) + in.camelizeSegments(term[n:])
+}
+
+// camelizeSegments capitalizes the words following underscores and
+// slashes in s. The caller must hold in.mu.
+func (in *Inflections) camelizeSegments(s string) string {
+	var sb strings.Builder
+	last := 0
+	for _, m := range camelizeSegment.FindAllStringSubmatchIndex(s, -1) {
+		sb.WriteString(s[last:m[0]])
+		if m[2] >= 0 {
+			sb.WriteString("::")
+		}
+		word := s[m[4]:m[5]]
+		if a, ok := in.acronyms[word]; ok {
+			sb.WriteString(a)
+		} else {
+			sb.WriteString(stringx.Capitalize(word))
+		}
+		last = m[1]
+	}
+	sb.WriteString(s[last:])
+	return sb.String()
+}
+
+// Underscore makes an underscored, lowercase form from the expression in
+// the string, changing "::" to "/" to convert namespaces to paths. It is
+// the reverse of Camelize, though some strings don't round-trip.
+//
+//	Underscore("ActiveModel")          // "active_model"
+//	Underscore("ActiveModel::Errors")  // "active_model/errors"
+//	Underscore("SSLError")             // "ssl_error"
+func Underscore(camelCasedWord string) string {
+	if !strings.ContainsAny(camelCasedWord, "ABCDEFGHIJKLMNOPQRSTUVWXYZ-") && !strings.Contains(camelCasedWord, "::") {
+		return camelCasedWord
+	}
+	word := strings.Replace(camelCasedWord, "::", "/", -1)
+
+	in := Locale("en")
+	in.mu.RLock()
+	acronyms := in.acronymList()
+	in.mu.RUnlock()
+	if len(acronyms) > 0 {
+		word = underscoreAcronyms(word, acronyms)
+	}
+
+	word = upperBeforeWord.ReplaceAllString(word, "${1}_${2}")
+	word = lowerBeforeUpper.ReplaceAllString(word, "${1}_${2}")
+	word = strings.Replace(word, "-", "_", -1)
+	return strings.ToLower(word)
+}
+
+// underscoreAcronyms lowercases the acronyms in word that stand alone,
+// putting an underscore before those that follow a letter or digit.
+func underscoreAcronyms(word string, acronyms []string) string {
+	var sb strings.Builder
+	for i := 0; i < len(word); {
+		matched := false
+		// An acronym may start anywhere but straight after an underscore,
+		// and must not run into a lowercase letter.
+		if i == 0 || word[i-1] != '_' {
+			for _, a := range acronyms {
+				if !strings.HasPrefix(word[i:], a) {
+					continue
+				}
+				end := i + len(a)
+				if end < len(word) && word[end] >= 'a' && word[end] <= 'z' {
+					continue
+				}
+				if i > 0 && isAlnumByte(word[i-1]) {
+					sb.WriteByte('_')
+				}
+				sb.WriteString(strings.ToLower(a))
+				i, matched = end, true
+				break
+			}
+		}
+		if !matched {
+			sb.WriteByte(word[i])
+			i++
+		}
+	}
+	return sb.String()
+}
+
+// Dasherize replaces underscores with dashes in the string.
+//
+//	Dasherize("puni_puni")  // "puni-puni"
+func Dasherize(underscoredWord string) string {
+	return strings.Replace(underscoredWord, "_", "-", -1)
+}
+
+// Humanize tweaks an attribute name for display to end users: it applies
+// human rules, turns underscores into spaces, drops a trailing "_id",
+// downcases all words except acronyms and capitalizes the first word.
+// Pass "lowercase" to leave the first word alone, or "keep_id_suffix" to
+// keep a trailing "_id".
+//
+//	Humanize("employee_salary")                 // "Employee salary"
+//	Humanize("author_id")                       // "Author"
+//	Humanize("author_id", "lowercase")          // "author"
+//	Humanize("_id")                             // "Id"
+//	Humanize("author_id", "keep_id_suffix")     // "Author id"
+func Humanize(word string, options ...string) string {
+	capitalize, keepID := true, false
+	for _, option := range options {
+		switch option {
+		case "lowercase":
+			capitalize = false
+		case "keep_id_suffix":
+			keepID = true
+		default:
+			panic("inflector: Humanize: invalid option " + strconv.Quote(option))
+		}
+	}
+
+	in := Locale("en")
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	result := word
+	for _, r := range in.humans {
+		if s, ok := sub(result, r); ok {
+			result = s
+			break
+		}
+	}
+	result = strings.Replace(result, "_", " ", -1)
+	result = strings.TrimLeft(result, " \t\n\v\f\r\x00")
+	if !keepID && strings.HasSuffix(word, "_id") {
+		result = strings.TrimSuffix(result, " id")
+	}
+	result = alnumRun.ReplaceAllStringFunc(result, func(m string) string {
+		if a, ok := in.acronyms[strings.ToLower(m)]; ok {
+			return a
+		}
+		return strings.ToLower(m)
+	})
+	if capitalize && result != "" && isWordByte(result[0]) {
+		result = strings.ToUpper(result[:1]) + result[1:]
+	}
+	return result
+}
+
+// Titleize capitalizes all the words and replaces some characters in the
+// string to create a nicer looking title. It is meant for creating pretty
+// output. Pass "keep_id_suffix" to keep a trailing "_id".
+//
+//	Titleize("man from the boondocks")   // "Man From The Boondocks"
+//	Titleize("x-men: the last stand")    // "X Men: The Last Stand"
+//	Titleize("TheManWithoutAPast")       // "The Man Without A Past"
+//	Titleize("raiders_of_the_lost_ark")  // "Raiders Of The Lost Ark"
+//	Titleize("string_ending_with_id", "keep_id_suffix")
+//	  // "String Ending With Id"
+func Titleize(word string, options ...string) string {
+	humanizeOptions := []string{}
+	for _, option := range options {
+		if option != "keep_id_suffix" {
+			panic("inflector: Titleize: invalid option " + strconv.Quote(option))
+		}
+		humanizeOptions = append(humanizeOptions, option)
+	}
+	b := []byte(Humanize(Underscore(word), humanizeOptions...))
+	for i, c := range b {
+		if c < 'a' || c > 'z' || (i > 0 && isWordByte(b[i-1])) {
+			continue
+		}
+		// Words following an apostrophe or bracket straight after a word,
+		// like the s of "don't", are left alone.
+		if i > 1 && strings.IndexByte("'`()", b[i-1]) >= 0 && isWordByte(b[i-2]) {
+			continue
+		}
+		if i > 3 && string(b[i-3:i]) == "’" && isWordByte(b[i-4]) {
+			continue
+		}
+		b[i] = c - 'a' + 'A'
+	}
+	return string(b)
+}
+
+// Tableize creates the name of a table like Rails does for models: the
+// underscored, pluralized form of the class name.
+//
+//	Tableize("RawScaledScorer")  // "raw_scaled_scorers"
+//	Tableize("ham_and_egg")      // "ham_and_eggs"
+//	Tableize("fancyCategory")    // "fancy_categories"
+func Tableize(className string) string {
+	return Pluralize(Underscore(className))
+}
+
+// Classify creates a class name from a plural table name like Rails does
+// for table names to models, dropping any schema prefix.
+//
+//	Classify("ham_and_eggs")  // "HamAndEgg"
+//	Classify("posts")         // "Post"
+//	Classify("schema.posts")  // "Post"
+func Classify(tableName string) string {
+	if i := strings.LastIndexByte(tableName, '.'); i >= 0 {
+		tableName = tableName[i+1:]
+	}
+	return Camelize(Singularize(tableName))
+}
+
+// Ordinal returns the suffix that should be added to a number to denote
+// its position in an ordered sequence, such as 1st or 2nd.
+//
+//	Ordinal(1)     // "st"
+//	Ordinal(2)     // "nd"
+//	Ordinal(1002)  // "nd"
+//	Ordinal(1003)  // "rd"
+//	Ordinal(-11)   // "th"
+//	Ordinal(-1021) // "st"
+func Ordinal(number int) string {
+	if number < 0 {
+		number = -number
+	}
+	if n := number % 100; n >= 11 && n <= 13 {
+		return "th"
+	}
+	switch number % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+	return "th"
+}
+
+// Ordinalize turns a number into an ordinal string used to denote its
+// position in an ordered sequence.
+//
+//	Ordinalize(1)     // "1st"
+//	Ordinalize(1002)  // "1002nd"
+//	Ordinalize(-11)   // "-11th"
+func Ordinalize(number int) string {
+	return strconv.Itoa(number) + Ordinal(number)
+}
+
+// ParameterizeOptions configures Parameterize; see
+// stringx.ParameterizeOptions.
+type ParameterizeOptions = stringx.ParameterizeOptions
+
+// Parameterize replaces special characters in a string so that it may be
+// used as part of a pretty URL. It is stringx.Parameterize, provided here
+// for symmetry with ActiveSupport.
+//
+//	Parameterize("Donald E. Knuth")  // "donald-e-knuth"
+//	Parameterize("^très|Jolie-- ")   // "tres-jolie"
+//	Parameterize("Donald E. Knuth", ParameterizeOptions{Separator: "_"})
+//	  // "donald_e_knuth"
+func Parameterize(str string, options ...ParameterizeOptions) string {
+	return stringx.Parameterize(str, options...)
+}
+
+func isWordByte(c byte) bool {
+	return isAlnumByte(c) || c == '_'
+}
+
+func isAlnumByte(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
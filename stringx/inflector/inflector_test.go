@@ -0,0 +1,370 @@
+package inflector
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/robicode/stdx/stringx/rubyregexp"
+)
+
+var singularToPlural = map[string]string{
+	"search":      "searches",
+	"switch":      "switches",
+	"fix":         "fixes",
+	"box":         "boxes",
+	"process":     "processes",
+	"address":     "addresses",
+	"case":        "cases",
+	"stack":       "stacks",
+	"wish":        "wishes",
+	"fish":        "fish",
+	"jeans":       "jeans",
+	"category":    "categories",
+	"query":       "queries",
+	"ability":     "abilities",
+	"agency":      "agencies",
+	"movie":       "movies",
+	"archive":     "archives",
+	"index":       "indices",
+	"wife":        "wives",
+	"safe":        "saves",
+	"half":        "halves",
+	"move":        "moves",
+	"salesperson": "salespeople",
+	"person":      "people",
+	"spokesman":   "spokesmen",
+	"man":         "men",
+	"woman":       "women",
+	"basis":       "bases",
+	"diagnosis":   "diagnoses",
+	"datum":       "data",
+	"medium":      "media",
+	"analysis":    "analyses",
+	"node_child":  "node_children",
+	"child":       "children",
+	"experience":  "experiences",
+	"day":         "days",
+	"comment":     "comments",
+	"foobar":      "foobars",
+	"newsletter":  "newsletters",
+	"old_news":    "old_news",
+	"news":        "news",
+	"series":      "series",
+	"species":     "species",
+	"quiz":        "quizzes",
+	"perspective": "perspectives",
+	"ox":          "oxen",
+	"photo":       "photos",
+	"buffalo":     "buffaloes",
+	"tomato":      "tomatoes",
+	"dwarf":       "dwarves",
+	"elf":         "elves",
+	"information": "information",
+	"equipment":   "equipment",
+	"bus":         "buses",
+	"status":      "statuses",
+	"mouse":       "mice",
+	"louse":       "lice",
+	"house":       "houses",
+	"octopus":     "octopi",
+	"virus":       "viri",
+	"alias":       "aliases",
+	"portfolio":   "portfolios",
+	"vertex":      "vertices",
+	"matrix":      "matrices",
+	"axis":        "axes",
+	"testis":      "testes",
+	"crisis":      "crises",
+	"rice":        "rice",
+	"shoe":        "shoes",
+	"horse":       "horses",
+	"prize":       "prizes",
+	"edge":        "edges",
+	"database":    "databases",
+	"|ice":        "|ices",
+	"|ouse":       "|ouses",
+	"slice":       "slices",
+	"police":      "police",
+}
+
+func Test_Pluralize(t *testing.T) {
+	for singular, plural := range singularToPlural {
+		if got := Pluralize(singular); got != plural {
+			t.Errorf("expected Pluralize(%q) to return %q but got %q", singular, plural, got)
+		}
+		if got := Pluralize(plural); got != plural {
+			t.Errorf("expected Pluralize(%q) to return %q but got %q", plural, plural, got)
+		}
+		if got := Singularize(plural); got != singular {
+			t.Errorf("expected Singularize(%q) to return %q but got %q", plural, singular, got)
+		}
+		if got := Singularize(singular); got != singular {
+			t.Errorf("expected Singularize(%q) to return %q but got %q", singular, singular, got)
+		}
+	}
+
+	for word, expected := range map[string]string{"": "", "Person": "People", "CamelOctopus": "CamelOctopi", "fresh fish": "fresh fish"} {
+		if got := Pluralize(word); got != expected {
+			t.Errorf("expected Pluralize(%q) to return %q but got %q", word, expected, got)
+		}
+	}
+}
+
+func Test_Locale(t *testing.T) {
+	es := Locale("es")
+	es.Plural(regexp.MustCompile(`(?i)$`), "s")
+	es.Plural(regexp.MustCompile(`(?i)([^aeéiou])$`), `\1es`)
+	es.Singular(regexp.MustCompile(`(?i)s$`), "")
+	es.Singular(regexp.MustCompile(`(?i)es$`), "")
+	es.Irregular("carácter", "caracteres")
+	es.Uncountable("lunes")
+
+	tests := map[string]string{"papel": "papeles", "casa": "casas", "carácter": "caracteres", "lunes": "lunes"}
+	for singular, plural := range tests {
+		if got := Pluralize(singular, "es"); got != plural {
+			t.Errorf("expected Pluralize(%q, \"es\") to return %q but got %q", singular, plural, got)
+		}
+	}
+	if got := Singularize("papeles", "es"); got != "papel" {
+		t.Errorf("expected Singularize(\"papeles\", \"es\") to return \"papel\" but got %q", got)
+	}
+	if got := Pluralize("papel"); got != "papels" {
+		t.Errorf("expected the en locale to be unaffected but got %q", got)
+	}
+	if Locale("es") != es {
+		t.Errorf("expected Locale to return the same rule set")
+	}
+
+	es.Clear("uncountables")
+	if got := Pluralize("lunes", "es"); got != "luneses" || len(es.Uncountables()) != 0 {
+		t.Errorf("expected uncountables to be cleared but got %q", got)
+	}
+	es.Clear()
+	if got := Pluralize("papel", "es"); got != "papel" {
+		t.Errorf("expected all rules to be cleared but got %q", got)
+	}
+	if got := Pluralize("x", "fr"); got != "x" {
+		t.Errorf("expected an unregistered locale to have no rules but got %q", got)
+	}
+}
+
+func Test_Camelize(t *testing.T) {
+	tests := map[string]string{
+		"product":                     "Product",
+		"special_guest":               "SpecialGuest",
+		"application_controller":      "ApplicationController",
+		"area51_controller":           "Area51Controller",
+		"admin/product":               "Admin::Product",
+		"users/commission/department": "Users::Commission::Department",
+		"Camel_Case":                  "CamelCase",
+	}
+	for word, expected := range tests {
+		if got := Camelize(word); got != expected {
+			t.Errorf("expected Camelize(%q) to return %q but got %q", word, expected, got)
+		}
+	}
+	if got := CamelizeLower("capital_city"); got != "capitalCity" {
+		t.Errorf("expected CamelizeLower to return \"capitalCity\" but got %q", got)
+	}
+	if got := CamelizeLower("Capital"); got != "capital" {
+		t.Errorf("expected CamelizeLower to return \"capital\" but got %q", got)
+	}
+}
+
+func Test_Underscore(t *testing.T) {
+	tests := map[string]string{
+		"Product":                       "product",
+		"SpecialGuest":                  "special_guest",
+		"ApplicationController":         "application_controller",
+		"Area51Controller":              "area51_controller",
+		"Admin::Product":                "admin/product",
+		"Users::Commission::Department": "users/commission/department",
+		"HTMLTidyGenerator":             "html_tidy_generator",
+		"FreeBSD":                       "free_bsd",
+		"already_underscored":           "already_underscored",
+		"dashed-word":                   "dashed_word",
+	}
+	for word, expected := range tests {
+		if got := Underscore(word); got != expected {
+			t.Errorf("expected Underscore(%q) to return %q but got %q", word, expected, got)
+		}
+	}
+}
+
+func Test_Acronyms(t *testing.T) {
+	en := Locale("en")
+	defer en.Clear("acronyms")
+	for _, acronym := range []string{"API", "HTML", "HTTP", "RESTful", "W3C", "PhD", "RoR", "SSL"} {
+		en.Acronym(acronym)
+	}
+
+	tests := []struct{ camel, under, human, title string }{
+		{"API", "api", "API", "API"},
+		{"APIController", "api_controller", "API controller", "API Controller"},
+		{"Nokogiri::HTML", "nokogiri/html", "Nokogiri/HTML", "Nokogiri/HTML"},
+		{"HTTPAPI", "http_api", "HTTP API", "HTTP API"},
+		{"HTTP::Get", "http/get", "HTTP/get", "HTTP/Get"},
+		{"SSLError", "ssl_error", "SSL error", "SSL Error"},
+		{"RESTful", "restful", "RESTful", "RESTful"},
+		{"RESTfulController", "restful_controller", "RESTful controller", "RESTful Controller"},
+		{"Nested::RESTful", "nested/restful", "Nested/RESTful", "Nested/RESTful"},
+		{"IHeartW3C", "i_heart_w3c", "I heart W3C", "I Heart W3C"},
+		{"PhDRequired", "phd_required", "PhD required", "PhD Required"},
+		{"IRoRU", "i_ror_u", "I RoR u", "I RoR U"},
+		{"RESTfulHTTPAPI", "restful_http_api", "RESTful HTTP API", "RESTful HTTP API"},
+		{"HTTP::RESTful", "http/restful", "HTTP/RESTful", "HTTP/RESTful"},
+		{"HTTP::RESTfulAPI", "http/restful_api", "HTTP/RESTful API", "HTTP/RESTful API"},
+		{"APIRESTful", "api_restful", "API RESTful", "API RESTful"},
+		{"Capistrano", "capistrano", "Capistrano", "Capistrano"},
+		{"CapiController", "capi_controller", "Capi controller", "Capi Controller"},
+		{"HttpsApis", "https_apis", "Https apis", "Https Apis"},
+		{"Html5", "html5", "Html5", "Html5"},
+		{"Restfully", "restfully", "Restfully", "Restfully"},
+		{"RoRails", "ro_rails", "Ro rails", "Ro Rails"},
+	}
+	for _, test := range tests {
+		if got := Camelize(test.under); got != test.camel {
+			t.Errorf("expected Camelize(%q) to return %q but got %q", test.under, test.camel, got)
+		}
+		if got := Underscore(test.camel); got != test.under {
+			t.Errorf("expected Underscore(%q) to return %q but got %q", test.camel, test.under, got)
+		}
+		if got := Humanize(test.under); got != test.human {
+			t.Errorf("expected Humanize(%q) to return %q but got %q", test.under, test.human, got)
+		}
+		if got := Titleize(test.under); got != test.title {
+			t.Errorf("expected Titleize(%q) to return %q but got %q", test.under, test.title, got)
+		}
+	}
+
+	lower := map[string]string{"HTMLAPI": "htmlAPI", "HTMLTidy": "htmlTidy", "HTML": "html", "Html": "html", "API_Controller": "apiController"}
+	for word, expected := range lower {
+		if got := CamelizeLower(word); got != expected {
+			t.Errorf("expected CamelizeLower(%q) to return %q but got %q", word, expected, got)
+		}
+	}
+}
+
+func Test_Humanize(t *testing.T) {
+	tests := []struct {
+		word     string
+		options  []string
+		expected string
+	}{
+		{"employee_salary", nil, "Employee salary"},
+		{"employee_id", nil, "Employee"},
+		{"underground", nil, "Underground"},
+		{"_id", nil, "Id"},
+		{"author_id", []string{"lowercase"}, "author"},
+		{"author_id", []string{"keep_id_suffix"}, "Author id"},
+		{"  leading_space", nil, "Leading space"},
+	}
+	for _, test := range tests {
+		if got := Humanize(test.word, test.options...); got != test.expected {
+			t.Errorf("expected Humanize(%q, %q) to return %q but got %q", test.word, test.options, test.expected, got)
+		}
+	}
+
+	en := Locale("en")
+	defer en.Clear("humans")
+	en.Human(regexp.MustCompile(`_cnt$`), `_count`)
+	en.Human("col_rpted_bugs", "Reported bugs")
+	if got := Humanize("jargon_cnt"); got != "Jargon count" {
+		t.Errorf("expected a human rule to apply but got %q", got)
+	}
+	if got := Humanize("col_rpted_bugs"); got != "Reported bugs" {
+		t.Errorf("expected a human rule to apply but got %q", got)
+	}
+	en.Human(rubyregexp.MustCompile(`\Acolor_(\h{6})\z`), `Color #\1`)
+	if got := Humanize("color_ff00aa"); got != "Color #ff00aa" {
+		t.Errorf("expected a Ruby regexp human rule to apply but got %q", got)
+	}
+}
+
+func Test_Titleize(t *testing.T) {
+	tests := map[string]string{
+		"active_record":           "Active Record",
+		"ActiveRecord":            "Active Record",
+		"action web service":      "Action Web Service",
+		"Action Web Service":      "Action Web Service",
+		"x-men: the last stand":   "X Men: The Last Stand",
+		"TheManWithoutAPast":      "The Man Without A Past",
+		"raiders_of_the_lost_ark": "Raiders Of The Lost Ark",
+		"i love ruby's syntax":    "I Love Ruby's Syntax",
+		"don’t stop":              "Don’t Stop",
+		"sponsor_id":              "Sponsor",
+		"(the world)":             "(The World)",
+	}
+	for word, expected := range tests {
+		if got := Titleize(word); got != expected {
+			t.Errorf("expected Titleize(%q) to return %q but got %q", word, expected, got)
+		}
+	}
+	if got := Titleize("string_ending_with_id", "keep_id_suffix"); got != "String Ending With Id" {
+		t.Errorf("expected the id suffix to be kept but got %q", got)
+	}
+}
+
+func Test_TableizeClassify(t *testing.T) {
+	tests := map[string]string{
+		"PrimarySpokesman": "primary_spokesmen",
+		"NodeChild":        "node_children",
+		"FancyCategory":    "fancy_categories",
+	}
+	for class, table := range tests {
+		if got := Tableize(class); got != table {
+			t.Errorf("expected Tableize(%q) to return %q but got %q", class, table, got)
+		}
+		if got := Classify(table); got != class {
+			t.Errorf("expected Classify(%q) to return %q but got %q", table, class, got)
+		}
+	}
+	if got := Classify("schema.posts"); got != "Post" {
+		t.Errorf("expected Classify to drop the schema but got %q", got)
+	}
+	if got := Dasherize("street_address"); got != "street-address" {
+		t.Errorf("expected Dasherize to return \"street-address\" but got %q", got)
+	}
+}
+
+func Test_Ordinalize(t *testing.T) {
+	tests := map[int]string{
+		-1: "-1st", -2: "-2nd", -3: "-3rd", -4: "-4th", -11: "-11th", -12: "-12th", -13: "-13th",
+		-101: "-101st", -1021: "-1021st",
+		0: "0th", 1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 5: "5th", 10: "10th", 11: "11th", 12: "12th",
+		13: "13th", 14: "14th", 20: "20th", 21: "21st", 22: "22nd", 23: "23rd", 24: "24th", 100: "100th",
+		101: "101st", 102: "102nd", 103: "103rd", 111: "111th", 112: "112th", 113: "113th", 1000: "1000th",
+		1001: "1001st",
+	}
+	for number, expected := range tests {
+		if got := Ordinalize(number); got != expected {
+			t.Errorf("expected Ordinalize(%d) to return %q but got %q", number, expected, got)
+		}
+	}
+}
+
+func Test_Parameterize(t *testing.T) {
+	tests := []struct {
+		str      string
+		options  []ParameterizeOptions
+		expected string
+	}{
+		{"Donald E. Knuth", nil, "donald-e-knuth"},
+		{"Random text with *(bad)* characters", nil, "random-text-with-bad-characters"},
+		{"Allow_Under_Scores", nil, "allow_under_scores"},
+		{"Trailing bad characters!@#", nil, "trailing-bad-characters"},
+		{"!@#Leading bad characters", nil, "leading-bad-characters"},
+		{"Squeeze   separators", nil, "squeeze-separators"},
+		{"^très|Jolie-- ", nil, "tres-jolie"},
+		{"Test with + sign", nil, "test-with-sign"},
+		{"Donald E. Knuth", []ParameterizeOptions{{Separator: "_"}}, "donald_e_knuth"},
+		{"Trailing bad characters!@#", []ParameterizeOptions{{Separator: "__sep__"}}, "trailing__sep__bad__sep__characters"},
+		{"Donald E. Knuth", []ParameterizeOptions{{PreserveCase: true}}, "Donald-E-Knuth"},
+		{"Random text with *(bad)* characters", []ParameterizeOptions{{Separator: "_", PreserveCase: true}}, "Random_text_with_bad_characters"},
+	}
+	for _, test := range tests {
+		if got := Parameterize(test.str, test.options...); got != test.expected {
+			t.Errorf("expected Parameterize(%q, %+v) to return %q but got %q", test.str, test.options, test.expected, got)
+		}
+	}
+}
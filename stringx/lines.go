@@ -0,0 +1,200 @@
+package stringx
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// lineOptions are the options shared by Lines, EachLine, EachLineReader and
+// LineSplitter.
+type lineOptions struct {
+	separator string
+	chomp     bool
+}
+
+// parseLineOptions reads the separator and chomp flag from options, in the
+// manner of Ruby's each_line(separator = $/, chomp: false).
+func parseLineOptions(options []interface{}) lineOptions {
+	opts := lineOptions{separator: "\n"}
+	for _, option := range options {
+		switch o := option.(type) {
+		case string:
+			opts.separator = o
+		case bool:
+			opts.chomp = o
+		default:
+			panic(fmt.Sprintf("stringx: line options must be a string separator or a bool chomp flag, not %T", option))
+		}
+	}
+	return opts
+}
+
+// split is a bufio.SplitFunc returning the next line of data.
+func (o lineOptions) split(data []byte, atEOF bool) (int, []byte, error) {
+	if o.separator == "" {
+		return o.splitParagraph(data, atEOF)
+	}
+	if len(data) == 0 {
+		return 0, nil, nil
+	}
+	i := bytes.Index(data, []byte(o.separator))
+	if i < 0 {
+		if !atEOF {
+			return 0, nil, nil
+		}
+		return len(data), data, nil
+	}
+	end := i + len(o.separator)
+	line := data[:end]
+	if o.chomp {
+		if o.separator == "\n" {
+			line = line[:len(Chomp(string(line)))]
+		} else {
+			line = line[:len(Chomp(string(line), o.separator))]
+		}
+	}
+	return end, line, nil
+}
+
+// splitParagraph is split in paragraph mode: lines are separated by one or
+// more blank lines, and blank lines before the first one are skipped.
+func (o lineOptions) splitParagraph(data []byte, atEOF bool) (int, []byte, error) {
+	start := 0
+	for {
+		if n := newlineAt(data, start); n > 0 {
+			start += n
+		} else {
+			break
+		}
+	}
+	if start == len(data) || (!atEOF && data[start] == '\r' && start+1 == len(data)) {
+		// Only blank lines so far; drop them and wait for more.
+		return start, nil, nil
+	}
+
+	for i := start; i < len(data); i++ {
+		if data[i] != '\n' {
+			continue
+		}
+		if i+1 == len(data) || (data[i+1] == '\r' && i+2 == len(data)) {
+			if !atEOF {
+				return start, nil, nil
+			}
+			break
+		}
+		if n := newlineAt(data, i+1); n > 0 {
+			end := i + 1 + n
+			line := data[start:end]
+			if o.chomp {
+				line = chompNewline(line)
+			}
+			return end, line, nil
+		}
+	}
+	if !atEOF {
+		return start, nil, nil
+	}
+	line := data[start:]
+	if o.chomp {
+		line = chompNewline(line)
+	}
+	return len(data), line, nil
+}
+
+// chompNewline removes one trailing "\n" or "\r\n" from line, which is all
+// Ruby chomps off a paragraph. Chomp can't be used, as it removes every
+// trailing "\r\n".
+func chompNewline(line []byte) []byte {
+	if bytes.HasSuffix(line, []byte("\r\n")) {
+		return line[:len(line)-2]
+	}
+	return bytes.TrimSuffix(line, []byte("\n"))
+}
+
+// newlineAt returns the length of the "\n" or "\r\n" at data[i], or 0 if
+// there is no newline there.
+func newlineAt(data []byte, i int) int {
+	switch {
+	case i < len(data) && data[i] == '\n':
+		return 1
+	case i+1 < len(data) && data[i] == '\r' && data[i+1] == '\n':
+		return 2
+	}
+	return 0
+}
+
+// Lines returns the lines of str, as EachLine would pass them.
+//
+//	Lines("hello\nworld\n")               // ["hello\n", "world\n"]
+//	Lines("hello\nworld\n", true)         // ["hello", "world"]
+//	Lines("hello world", " ")             // ["hello ", "world"]
+//	Lines("a\nb\n\n\nc", "")              // ["a\nb\n\n", "c"]
+//	Lines("a\nb\n\n\nc", "", true)        // ["a\nb\n", "c"]
+func Lines(str string, options ...interface{}) []string {
+	lines := []string{}
+	EachLine(str, func(line string) {
+		lines = append(lines, line)
+	}, options...)
+	return lines
+}
+
+// EachLine passes each line of str to block. Options, in any order, are a
+// string separator, which defaults to "\n", and a bool that chomps the
+// separator off each line (with Chomp) when true. Each line includes its
+// separator otherwise, and the last line may have none.
+//
+// An empty separator selects paragraph mode, where lines are separated by
+// one or more blank lines. Each paragraph includes the first blank line
+// after it, less one newline if chomped.
+//
+//	EachLine("one\ntwo", func(line string) {
+//		fmt.Printf("%q ", line)
+//	})  // "one\n" "two"
+func EachLine(str string, block func(line string), options ...interface{}) {
+	opts := parseLineOptions(options)
+	if block == nil {
+		return
+	}
+	data := []byte(str)
+	for len(data) > 0 {
+		advance, line, _ := opts.split(data, true)
+		if line != nil {
+			block(string(line))
+		}
+		data = data[advance:]
+	}
+}
+
+// EachLineReader is like EachLine, but reads the lines from r as it goes
+// rather than holding all of its contents in memory. Lines longer than
+// bufio.MaxScanTokenSize fail with bufio.ErrTooLong; use LineSplitter with a
+// bufio.Scanner of a larger buffer to read those. It returns the first
+// error other than io.EOF encountered while reading.
+//
+//	err := EachLineReader(file, func(line string) {
+//		fmt.Println(line)
+//	}, true)
+func EachLineReader(r io.Reader, block func(line string), options ...interface{}) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(LineSplitter(options...))
+	for scanner.Scan() {
+		if block != nil {
+			block(scanner.Text())
+		}
+	}
+	return scanner.Err()
+}
+
+// LineSplitter returns a bufio.SplitFunc that splits its input into lines
+// as EachLine does, taking the same options.
+//
+//	scanner := bufio.NewScanner(strings.NewReader("a--b--c"))
+//	scanner.Split(LineSplitter("--", true))
+//	for scanner.Scan() {
+//		scanner.Text()  // "a", "b", "c"
+//	}
+func LineSplitter(options ...interface{}) bufio.SplitFunc {
+	return parseLineOptions(options).split
+}
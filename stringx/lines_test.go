@@ -0,0 +1,91 @@
+package stringx
+
+import (
+	"bufio"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var lineTests = []struct {
+	str      string
+	options  []interface{}
+	expected []string
+}{
+	{"", nil, []string{}},
+	{"hello", nil, []string{"hello"}},
+	{"hello\nworld\n", nil, []string{"hello\n", "world\n"}},
+	{"hello\nworld", nil, []string{"hello\n", "world"}},
+	{"hello\r\nworld\r\n", []interface{}{true}, []string{"hello", "world"}},
+	{"hello\n\nworld", []interface{}{true}, []string{"hello", "", "world"}},
+	{"hello\rworld\r", []interface{}{true}, []string{"hello\rworld\r"}},
+	{"hello world", []interface{}{" "}, []string{"hello ", "world"}},
+	{"a--b--c--", []interface{}{"--", true}, []string{"a", "b", "c"}},
+	{"a--b--c--", []interface{}{true, "--"}, []string{"a", "b", "c"}},
+	{"a--b\n", []interface{}{"--", true}, []string{"a", "b\n"}},
+	{"a\nb\n\n\nc", []interface{}{""}, []string{"a\nb\n\n", "c"}},
+	{"a\nb\n\n\nc\n", []interface{}{"", true}, []string{"a\nb\n", "c"}},
+	{"hello\n\n\nworld", []interface{}{"", true}, []string{"hello\n", "world"}},
+	{"\n\na\r\n\r\nb\n\n", []interface{}{""}, []string{"a\r\n\r\n", "b\n\n"}},
+	{"\n\na\r\n\r\nb\n\n", []interface{}{"", true}, []string{"a\r\n", "b\n"}},
+	{"\n\n\n", []interface{}{""}, []string{}},
+}
+
+func Test_Lines(t *testing.T) {
+	for _, test := range lineTests {
+		if got := Lines(test.str, test.options...); !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("expected Lines(%q, %v) to return %q but got %q", test.str, test.options, test.expected, got)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected an invalid option to panic")
+		}
+	}()
+	Lines("a", 1)
+}
+
+func Test_EachLine(t *testing.T) {
+	var got []string
+	EachLine("one\ntwo", func(line string) {
+		got = append(got, line)
+	})
+	if expected := []string{"one\n", "two"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %q but got %q", expected, got)
+	}
+	EachLine("one", nil)
+}
+
+func Test_EachLineReader(t *testing.T) {
+	for _, test := range lineTests {
+		got := []string{}
+		err := EachLineReader(iotest.OneByteReader(strings.NewReader(test.str)), func(line string) {
+			got = append(got, line)
+		}, test.options...)
+		if err != nil || !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("expected EachLineReader(%q, %v) to read %q but got %q (%v)", test.str, test.options, test.expected, got, err)
+		}
+	}
+
+	long := strings.Repeat("x", bufio.MaxScanTokenSize+1)
+	if err := EachLineReader(strings.NewReader(long), nil); err != bufio.ErrTooLong {
+		t.Errorf("expected a long line to fail with bufio.ErrTooLong but got %v", err)
+	}
+	if err := EachLineReader(iotest.TimeoutReader(strings.NewReader("a\nb")), nil); err != iotest.ErrTimeout {
+		t.Errorf("expected the read error to be returned but got %v", err)
+	}
+}
+
+func Test_LineSplitter(t *testing.T) {
+	scanner := bufio.NewScanner(strings.NewReader("a--b--c"))
+	scanner.Split(LineSplitter("--", true))
+	var got []string
+	for scanner.Scan() {
+		got = append(got, scanner.Text())
+	}
+	if expected := []string{"a", "b", "c"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %q but got %q", expected, got)
+	}
+}
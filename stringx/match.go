@@ -0,0 +1,225 @@
+package stringx
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/robicode/stdx/stringx/rubyregexp"
+)
+
+// MatchData describes a single match of a pattern against a string, as
+// passed to the replacement callbacks of Sub and Gsub and kept by
+// StringScanner. Offsets are byte offsets into the searched string.
+type MatchData struct {
+	str   string
+	loc   []int
+	names []string
+}
+
+// String returns the matched text.
+func (m *MatchData) String() string {
+	s, _ := m.Group(0)
+	return s
+}
+
+// Group returns capture group i, where group 0 is the whole match. It
+// returns "" and false if i is out of range or the group didn't
+// participate in the match.
+func (m *MatchData) Group(i int) (string, bool) {
+	if i < 0 || 2*i+1 >= len(m.loc) || m.loc[2*i] < 0 {
+		return "", false
+	}
+	return m.str[m.loc[2*i]:m.loc[2*i+1]], true
+}
+
+// Named returns the capture group called name, or "" and false if there
+// is no such group or it didn't participate in the match. If several
+// groups share the name, the last one that participated is used.
+func (m *MatchData) Named(name string) (string, bool) {
+	for i := len(m.names) - 1; i > 0; i-- {
+		if m.names[i] == name {
+			if s, ok := m.Group(i); ok {
+				return s, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Size returns the number of groups, including the whole match.
+func (m *MatchData) Size() int {
+	return len(m.loc) / 2
+}
+
+// Captures returns the capture groups, excluding the whole match. Groups
+// that didn't participate in the match are "".
+func (m *MatchData) Captures() []string {
+	captures := make([]string, 0, m.Size()-1)
+	for i := 1; i < m.Size(); i++ {
+		s, _ := m.Group(i)
+		captures = append(captures, s)
+	}
+	return captures
+}
+
+// NamedCaptures returns the named capture groups by name.
+func (m *MatchData) NamedCaptures() map[string]string {
+	captures := make(map[string]string)
+	for _, name := range m.names {
+		if name != "" {
+			captures[name], _ = m.Named(name)
+		}
+	}
+	return captures
+}
+
+// Begin returns the offset of the start of group i, or -1 if i is out of
+// range or the group didn't participate in the match.
+func (m *MatchData) Begin(i int) int {
+	if i < 0 || 2*i >= len(m.loc) {
+		return -1
+	}
+	return m.loc[2*i]
+}
+
+// End returns the offset just past the end of group i, or -1 if i is out
+// of range or the group didn't participate in the match.
+func (m *MatchData) End(i int) int {
+	if i < 0 || 2*i+1 >= len(m.loc) {
+		return -1
+	}
+	return m.loc[2*i+1]
+}
+
+// PreMatch returns the part of the searched string before the match.
+func (m *MatchData) PreMatch() string {
+	return m.str[:m.loc[0]]
+}
+
+// PostMatch returns the part of the searched string after the match.
+func (m *MatchData) PostMatch() string {
+	return m.str[m.loc[1]:]
+}
+
+// unwrapPattern returns the *regexp.Regexp compiled from a
+// *rubyregexp.Regexp pattern argument, and any other pattern unchanged, so
+// that functions taking a pattern need only handle strings and
+// *regexp.Regexp.
+func unwrapPattern(pattern interface{}) interface{} {
+	if re, ok := pattern.(*rubyregexp.Regexp); ok && re != nil {
+		return re.Regexp
+	}
+	return pattern
+}
+
+// findMatches returns the locations of at most n matches of pattern in
+// str, or all of them if n is negative, with the names of the groups. A
+// *regexp.Regexp or *rubyregexp.Regexp is used as is and a string is
+// matched literally, without compiling a regular expression. It returns
+// false for any other pattern type.
+func findMatches(str string, pattern interface{}, n int) ([][]int, []string, bool) {
+	switch p := unwrapPattern(pattern).(type) {
+	case *regexp.Regexp:
+		return p.FindAllStringSubmatchIndex(str, n), p.SubexpNames(), true
+	case string:
+		return findLiteral(str, p, n), nil, true
+	}
+	return nil, nil, false
+}
+
+// findLiteral returns the locations of at most n occurrences of literal
+// in str, as a regular expression matching it would. An empty literal
+// matches at every character boundary.
+func findLiteral(str, literal string, n int) [][]int {
+	var matches [][]int
+	for i := 0; i <= len(str) && (n < 0 || len(matches) < n); {
+		j := strings.Index(str[i:], literal)
+		if j < 0 {
+			break
+		}
+		start := i + j
+		matches = append(matches, []int{start, start + len(literal)})
+		if literal != "" {
+			i = start + len(literal)
+		} else if start < len(str) {
+			_, size := utf8.DecodeRuneInString(str[start:])
+			i = start + size
+		} else {
+			break
+		}
+	}
+	return matches
+}
+
+// substitute implements Sub and Gsub, replacing at most n matches of
+// pattern in str, or all of them if n is negative.
+func substitute(str string, pattern, replacement interface{}, n int) string {
+	matches, names, ok := findMatches(str, pattern, n)
+	if !ok {
+		return str
+	}
+	switch replacement.(type) {
+	case string, map[string]string, func(string) string, func(*MatchData) string:
+	default:
+		return str
+	}
+	if matches == nil {
+		return str
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, loc := range matches {
+		m := &MatchData{str: str, loc: loc, names: names}
+		sb.WriteString(str[last:loc[0]])
+		switch r := replacement.(type) {
+		case string:
+			expandReplacement(&sb, r, m)
+		case map[string]string:
+			sb.WriteString(r[m.String()])
+		case func(string) string:
+			sb.WriteString(r(m.String()))
+		case func(*MatchData) string:
+			sb.WriteString(r(m))
+		}
+		last = loc[1]
+	}
+	sb.WriteString(str[last:])
+	return sb.String()
+}
+
+// expandReplacement writes template to sb, expanding Ruby's backslash
+// sequences with the text of m. Unlike Ruby, which raises an IndexError,
+// it expands \k<name> to "" when there is no group called name.
+func expandReplacement(sb *strings.Builder, template string, m *MatchData) {
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		if c != '\\' || i+1 == len(template) {
+			sb.WriteByte(c)
+			continue
+		}
+		i++
+		switch c = template[i]; {
+		case c >= '0' && c <= '9':
+			s, _ := m.Group(int(c - '0'))
+			sb.WriteString(s)
+		case c == '&':
+			sb.WriteString(m.String())
+		case c == '`':
+			sb.WriteString(m.PreMatch())
+		case c == '\'':
+			sb.WriteString(m.PostMatch())
+		case c == '\\':
+			sb.WriteByte('\\')
+		case c == 'k' && strings.HasPrefix(template[i+1:], "<") && strings.Contains(template[i+1:], ">"):
+			end := i + 1 + strings.IndexByte(template[i+1:], '>')
+			s, _ := m.Named(template[i+2 : end])
+			sb.WriteString(s)
+			i = end
+		default:
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		}
+	}
+}
@@ -0,0 +1,918 @@
+package stringx
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"math/big"
+	"runtime"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// packDirective is a single directive of a Pack or Unpack template.
+type packDirective struct {
+	op       byte
+	count    int
+	hasCount bool
+	star     bool
+	native   bool // '_' or '!'
+	little   bool // '<'
+	big      bool // '>'
+}
+
+// n returns the directive's count, or def if none was given.
+func (d packDirective) n(def int) int {
+	if d.hasCount {
+		return d.count
+	}
+	return def
+}
+
+var nativeBigEndian = func() bool {
+	x := uint16(1)
+	return *(*byte)(unsafe.Pointer(&x)) == 0
+}()
+
+// longSize is the size of a C long, used by the l and L directives with
+// the _ or ! modifier.
+var longSize = func() int {
+	if strconv.IntSize == 64 && runtime.GOOS != "windows" {
+		return 8
+	}
+	return 4
+}()
+
+const uuTable = "`!\"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_"
+
+// parsePackTemplate splits template into directives, skipping whitespace
+// and # comments.
+func parsePackTemplate(template string) ([]packDirective, error) {
+	var ds []packDirective
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f' || c == 0:
+			continue
+		case c == '#':
+			for i < len(template) && template[i] != '\n' {
+				i++
+			}
+			continue
+		case !strings.ContainsRune("aAZbBhHcCsSlLqQiIjJnNvVUwdDfFeEgGmMuxX@", rune(c)):
+			return nil, fmt.Errorf("stringx: unknown pack directive '%c' in '%s'", c, template)
+		}
+
+		d := packDirective{op: c}
+		for i+1 < len(template) && strings.IndexByte("_!<>", template[i+1]) >= 0 {
+			i++
+			m := template[i]
+			if strings.IndexByte("sSiIlLqQjJ", c) < 0 {
+				return nil, fmt.Errorf("stringx: '%c' allowed only after types sSiIlLqQjJ", m)
+			}
+			switch m {
+			case '_', '!':
+				d.native = true
+			case '<':
+				d.little = true
+			case '>':
+				d.big = true
+			}
+			if d.little && d.big {
+				return nil, fmt.Errorf("stringx: can't use both '<' and '>'")
+			}
+		}
+		if i+1 < len(template) && template[i+1] == '*' {
+			d.star = true
+			i++
+		} else if n, l := parseDigits(template[i+1:]); l > 0 {
+			d.count, d.hasCount = n, true
+			i += l
+		}
+		ds = append(ds, d)
+	}
+	return ds, nil
+}
+
+// intLayout returns the size in bytes, signedness and byte order of an
+// integer directive.
+func (d packDirective) intLayout() (size int, signed, bigEndian bool) {
+	bigEndian = nativeBigEndian
+	switch d.op {
+	case 'c', 'C':
+		size = 1
+	case 's', 'S':
+		size = 2
+	case 'i', 'I':
+		size = 4
+	case 'l', 'L':
+		size = 4
+		if d.native {
+			size = longSize
+		}
+	case 'q', 'Q':
+		size = 8
+	case 'j', 'J':
+		size = strconv.IntSize / 8
+	case 'n':
+		return 2, false, true
+	case 'N':
+		return 4, false, true
+	case 'v':
+		return 2, false, false
+	case 'V':
+		return 4, false, false
+	}
+	if d.little {
+		bigEndian = false
+	} else if d.big {
+		bigEndian = true
+	}
+	return size, strings.IndexByte("csilqj", d.op) >= 0, bigEndian
+}
+
+// floatLayout returns the size in bytes and byte order of a float
+// directive.
+func (d packDirective) floatLayout() (size int, bigEndian bool) {
+	switch d.op {
+	case 'd', 'D':
+		return 8, nativeBigEndian
+	case 'f', 'F':
+		return 4, nativeBigEndian
+	case 'E':
+		return 8, false
+	case 'e':
+		return 4, false
+	case 'G':
+		return 8, true
+	}
+	return 4, true
+}
+
+// Pack packs items into a binary string according to the directives in
+// template, the way Ruby's Array#pack does. Each directive may be followed
+// by a count, or * to use all remaining items (or, for string directives,
+// the whole string).
+//
+//	Integer   | Bytes | Meaning
+//	----------+-------+---------------------------------------------------
+//	C c       | 1     | unsigned, signed 8-bit integer
+//	S s       | 2     | unsigned, signed 16-bit integer, native endian
+//	L l       | 4     | unsigned, signed 32-bit integer, native endian
+//	Q q       | 8     | unsigned, signed 64-bit integer, native endian
+//	I i       | 4     | unsigned, signed C int, native endian
+//	J j       | ptr   | unsigned, signed pointer-sized integer
+//	n N       | 2 4   | unsigned big-endian (network) 16 and 32-bit integer
+//	v V       | 2 4   | unsigned little-endian (VAX) 16 and 32-bit integer
+//	U         |       | UTF-8 character
+//	w         |       | BER-compressed integer
+//
+//	Float     | Bytes | Meaning
+//	----------+-------+---------------------------------------------------
+//	D d       | 8     | double-precision, native endian
+//	F f       | 4     | single-precision, native endian
+//	E e       | 8 4   | double, single-precision, little-endian
+//	G g       | 8 4   | double, single-precision, big-endian
+//
+//	String    | Meaning
+//	----------+-----------------------------------------------------------
+//	a A Z     | arbitrary binary string, padded with null (a), space (A),
+//	          | or null with a trailing null for Z*
+//	B b       | bit string, descending or ascending bit order
+//	H h       | hex string, high or low nibble first
+//	m         | base64 encoded string; m0 omits line feeds
+//	M         | quoted-printable string
+//	u         | uuencoded string
+//
+//	Misc.     | Meaning
+//	----------+-----------------------------------------------------------
+//	x         | null byte
+//	X         | back up a byte
+//	@         | null fill or truncate to absolute position
+//
+// The integer directives s, S, i, I, l, L, q, Q, j and J may be followed by
+// _ or ! to use the native size (which only affects l and L), and by < or >
+// to force little or big-endian byte order.
+//
+//	Pack("CCC", 65, 66, 67)        // "ABC"
+//	Pack("s>l<", -2, 1)            // "\xff\xfe\x01\x00\x00\x00"
+//	Pack("a3a3a3", "a", "b", "c")  // "a\x00\x00b\x00\x00c\x00\x00"
+//	Pack("A3Z*", "a", "b")         // "a  b\x00"
+//	Pack("m", "abc")               // "YWJj\n"
+//	Pack("U*", 82, 252, 98, 121)   // "Rüby"
+func Pack(template string, items ...interface{}) (string, error) {
+	ds, err := parsePackTemplate(template)
+	if err != nil {
+		return "", err
+	}
+	var out []byte
+	idx := 0
+	next := func(op byte) (interface{}, error) {
+		if idx >= len(items) {
+			return nil, fmt.Errorf("stringx: pack(%c): too few arguments", op)
+		}
+		idx++
+		return items[idx-1], nil
+	}
+	count := func(d packDirective) int {
+		if d.star {
+			return len(items) - idx
+		}
+		return d.n(1)
+	}
+
+	for _, d := range ds {
+		switch d.op {
+		case 'a', 'A', 'Z', 'B', 'b', 'H', 'h':
+			item, err := next(d.op)
+			if err != nil {
+				return "", err
+			}
+			s, err := packString(d.op, item)
+			if err != nil {
+				return "", err
+			}
+			l := d.n(1)
+			if d.star {
+				l = len(s)
+			}
+			switch d.op {
+			case 'a', 'A', 'Z':
+				out = packBytes(out, d, s, l)
+			case 'B', 'b':
+				out = packBits(out, d.op, s, l)
+			default:
+				out = packHex(out, d.op, s, l)
+			}
+
+		case 'c', 'C', 's', 'S', 'l', 'L', 'q', 'Q', 'i', 'I', 'j', 'J', 'n', 'N', 'v', 'V':
+			size, _, bigEndian := d.intLayout()
+			mask := new(big.Int).SetUint64(math.MaxUint64)
+			for n := count(d); n > 0; n-- {
+				item, err := next(d.op)
+				if err != nil {
+					return "", err
+				}
+				i, err := packInteger(d.op, item)
+				if err != nil {
+					return "", err
+				}
+				out = appendUint(out, new(big.Int).And(i, mask).Uint64(), size, bigEndian)
+			}
+
+		case 'd', 'D', 'f', 'F', 'e', 'E', 'g', 'G':
+			size, bigEndian := d.floatLayout()
+			for n := count(d); n > 0; n-- {
+				item, err := next(d.op)
+				if err != nil {
+					return "", err
+				}
+				if _, ok := item.(string); ok {
+					return "", fmt.Errorf("stringx: pack(%c): can't convert string into Float", d.op)
+				}
+				x, err := toFloat(item)
+				if err != nil {
+					return "", err
+				}
+				if size == 8 {
+					out = appendUint(out, math.Float64bits(x), 8, bigEndian)
+				} else {
+					out = appendUint(out, uint64(math.Float32bits(float32(x))), 4, bigEndian)
+				}
+			}
+
+		case 'U':
+			for n := count(d); n > 0; n-- {
+				item, err := next(d.op)
+				if err != nil {
+					return "", err
+				}
+				i, err := packInteger(d.op, item)
+				if err != nil {
+					return "", err
+				}
+				if !i.IsInt64() || i.Int64() < 0 || i.Int64() > utf8.MaxRune {
+					return "", fmt.Errorf("stringx: pack(U): value out of range")
+				}
+				out = appendRune(out, rune(i.Int64()))
+			}
+
+		case 'w':
+			for n := count(d); n > 0; n-- {
+				item, err := next(d.op)
+				if err != nil {
+					return "", err
+				}
+				i, err := packInteger(d.op, item)
+				if err != nil {
+					return "", err
+				}
+				if i.Sign() < 0 {
+					return "", fmt.Errorf("stringx: pack(w): can't compress negative numbers")
+				}
+				out = appendBER(out, i)
+			}
+
+		case 'm', 'u':
+			item, err := next(d.op)
+			if err != nil {
+				return "", err
+			}
+			s, err := packString(d.op, item)
+			if err != nil {
+				return "", err
+			}
+			l := d.n(1)
+			if d.star {
+				l = 1
+				if d.op == 'u' {
+					l = 0
+				}
+			}
+			if l == 0 && d.op == 'm' {
+				out = append(out, base64.StdEncoding.EncodeToString([]byte(s))...)
+				break
+			}
+			switch {
+			case l <= 2:
+				l = 45
+			case l > 63 && d.op == 'u':
+				l = 63
+			default:
+				l = l / 3 * 3
+			}
+			for len(s) > 0 {
+				todo := l
+				if len(s) < todo {
+					todo = len(s)
+				}
+				out = encodeLine(out, d.op, s[:todo])
+				s = s[todo:]
+			}
+
+		case 'M':
+			item, err := next(d.op)
+			if err != nil {
+				return "", err
+			}
+			l := d.n(1)
+			if d.star || l <= 1 {
+				l = 72
+			}
+			out = encodeQP(out, toS(item), l)
+
+		case 'x':
+			l := d.n(1)
+			if d.star {
+				l = 0
+			}
+			out = append(out, make([]byte, l)...)
+
+		case 'X':
+			l := d.n(1)
+			if d.star {
+				l = 0
+			}
+			if l > len(out) {
+				return "", fmt.Errorf("stringx: pack(X): X outside of string")
+			}
+			out = out[:len(out)-l]
+
+		case '@':
+			l := d.n(1)
+			if d.star {
+				l = 0
+			}
+			if l > len(out) {
+				out = append(out, make([]byte, l-len(out))...)
+			} else {
+				out = out[:l]
+			}
+		}
+	}
+	return string(out), nil
+}
+
+// Unpack decodes str, which may contain binary data, according to the
+// directives in template, the way Ruby's String#unpack does. See Pack for
+// the directives.
+//
+// Integers are returned as int64, except for unsigned 64-bit integers,
+// which are returned as uint64, and w, which is returned as uint64 or as a
+// *big.Int if it doesn't fit. Floats are returned as float64, and the
+// string directives return strings. Integers and floats missing from the
+// end of str are returned as nil.
+//
+//	Unpack("abc \x00\x00abc \x00\x00", "A6Z6")  // []interface{}{"abc", "abc "}
+//	Unpack("\xff\xfe\x01\x00\x00\x00", "s>l<")  // []interface{}{int64(-2), int64(1)}
+//	Unpack("aa", "b8B8")                        // []interface{}{"10000110", "01100001"}
+//	Unpack("YWJj\n", "m")                       // []interface{}{"abc"}
+//	Unpack("\x01", "C3")                        // []interface{}{int64(1), nil, nil}
+func Unpack(str, template string) ([]interface{}, error) {
+	ds, err := parsePackTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+	result := []interface{}{}
+	pos := 0
+	for _, d := range ds {
+		rest := str[pos:]
+		switch d.op {
+		case 'a', 'A', 'Z':
+			l := d.n(1)
+			if d.star || l > len(rest) {
+				l = len(rest)
+			}
+			s := rest[:l]
+			switch d.op {
+			case 'A':
+				s = strings.TrimRight(s, " \x00")
+			case 'Z':
+				if i := strings.IndexByte(s, 0); i >= 0 {
+					s = s[:i]
+					if d.star {
+						l = i + 1
+					}
+				}
+			}
+			result = append(result, s)
+			pos += l
+
+		case 'B', 'b':
+			l := d.n(1)
+			if d.star || l > len(rest)*8 {
+				l = len(rest) * 8
+			}
+			bits := make([]byte, l)
+			for i := range bits {
+				b := rest[i/8]
+				if d.op == 'B' {
+					b >>= 7 - uint(i%8)
+				} else {
+					b >>= uint(i % 8)
+				}
+				bits[i] = '0' + b&1
+			}
+			result = append(result, string(bits))
+			pos += (l + 7) / 8
+
+		case 'H', 'h':
+			l := d.n(1)
+			if d.star || l > len(rest)*2 {
+				l = len(rest) * 2
+			}
+			const hex = "0123456789abcdef"
+			nibbles := make([]byte, l)
+			for i := range nibbles {
+				b := rest[i/2]
+				if (d.op == 'H') == (i%2 == 0) {
+					b >>= 4
+				}
+				nibbles[i] = hex[b&15]
+			}
+			result = append(result, string(nibbles))
+			pos += (l + 1) / 2
+
+		case 'c', 'C', 's', 'S', 'l', 'L', 'q', 'Q', 'i', 'I', 'j', 'J', 'n', 'N', 'v', 'V':
+			size, signed, bigEndian := d.intLayout()
+			n, missing := unpackCount(d, len(rest), size)
+			for i := 0; i < n; i++ {
+				v := readUint(rest[i*size:], size, bigEndian)
+				switch {
+				case signed:
+					shift := uint(64 - 8*size)
+					result = append(result, int64(v<<shift)>>shift)
+				case size == 8:
+					result = append(result, v)
+				default:
+					result = append(result, int64(v))
+				}
+			}
+			result = append(result, make([]interface{}, missing)...)
+			pos += n * size
+
+		case 'd', 'D', 'f', 'F', 'e', 'E', 'g', 'G':
+			size, bigEndian := d.floatLayout()
+			n, missing := unpackCount(d, len(rest), size)
+			for i := 0; i < n; i++ {
+				v := readUint(rest[i*size:], size, bigEndian)
+				if size == 8 {
+					result = append(result, math.Float64frombits(v))
+				} else {
+					result = append(result, float64(math.Float32frombits(uint32(v))))
+				}
+			}
+			result = append(result, make([]interface{}, missing)...)
+			pos += n * size
+
+		case 'U':
+			l := d.n(1)
+			for i := 0; (d.star || i < l) && pos < len(str); i++ {
+				r, size := utf8.DecodeRuneInString(str[pos:])
+				if r == utf8.RuneError && size <= 1 {
+					return nil, fmt.Errorf("stringx: unpack(U): malformed UTF-8 character")
+				}
+				result = append(result, int64(r))
+				pos += size
+			}
+
+		case 'w':
+			l := d.n(1)
+			for i := 0; (d.star || i < l) && pos < len(str); i++ {
+				v := new(big.Int)
+				for pos < len(str) {
+					b := str[pos]
+					pos++
+					v.Lsh(v, 7).Or(v, big.NewInt(int64(b&0x7f)))
+					if b&0x80 == 0 {
+						break
+					}
+				}
+				if v.IsUint64() {
+					result = append(result, v.Uint64())
+				} else {
+					result = append(result, v)
+				}
+			}
+
+		case 'm':
+			if d.hasCount && d.count == 0 {
+				b, err := base64.StdEncoding.Strict().DecodeString(rest)
+				if err != nil {
+					return nil, fmt.Errorf("stringx: unpack(m0): invalid base64")
+				}
+				result = append(result, string(b))
+			} else {
+				result = append(result, decodeBase64(rest))
+			}
+			pos = len(str)
+
+		case 'M':
+			result = append(result, decodeQP(rest))
+			pos = len(str)
+
+		case 'u':
+			result = append(result, decodeUU(rest))
+			pos = len(str)
+
+		case 'x':
+			l := d.n(1)
+			if d.star {
+				l = len(rest)
+			}
+			if l > len(rest) {
+				return nil, fmt.Errorf("stringx: unpack(x): x outside of string")
+			}
+			pos += l
+
+		case 'X':
+			l := d.n(1)
+			if d.star {
+				l = 0
+			}
+			if l > pos {
+				return nil, fmt.Errorf("stringx: unpack(X): X outside of string")
+			}
+			pos -= l
+
+		case '@':
+			l := d.n(1)
+			if d.star {
+				l = pos
+			}
+			if l > len(str) {
+				return nil, fmt.Errorf("stringx: unpack(@): @ outside of string")
+			}
+			pos = l
+		}
+	}
+	return result, nil
+}
+
+// unpackCount returns how many values of size bytes an integer or float
+// directive reads from available bytes, and how many are missing.
+func unpackCount(d packDirective, available, size int) (int, int) {
+	fit := available / size
+	if d.star {
+		return fit, 0
+	}
+	n := d.n(1)
+	if n > fit {
+		return fit, n - fit
+	}
+	return n, 0
+}
+
+// packString converts a string directive's item, which must be a string
+// or []byte.
+func packString(op byte, item interface{}) (string, error) {
+	switch s := item.(type) {
+	case string:
+		return s, nil
+	case []byte:
+		return string(s), nil
+	}
+	return "", fmt.Errorf("stringx: pack(%c): can't convert %T into String", op, item)
+}
+
+// packInteger converts an integer directive's item. Unlike Sprintf, it
+// doesn't parse strings.
+func packInteger(op byte, item interface{}) (*big.Int, error) {
+	if _, ok := item.(string); ok {
+		return nil, fmt.Errorf("stringx: pack(%c): can't convert string into Integer", op)
+	}
+	return toInteger(item)
+}
+
+// packBytes appends the a, A and Z directives' l bytes of s.
+func packBytes(out []byte, d packDirective, s string, l int) []byte {
+	if len(s) >= l {
+		out = append(out, s[:l]...)
+		if d.op == 'Z' && d.star {
+			out = append(out, 0)
+		}
+		return out
+	}
+	out = append(out, s...)
+	pad := byte(0)
+	if d.op == 'A' {
+		pad = ' '
+	}
+	for i := len(s); i < l; i++ {
+		out = append(out, pad)
+	}
+	return out
+}
+
+// packBits appends the B and b directives' l bits from s. As in Ruby, a
+// count beyond the end of s also appends (l - len(s) + 1) / 2 nulls.
+func packBits(out []byte, op byte, s string, l int) []byte {
+	extra := 0
+	if l > len(s) {
+		extra = (l - len(s) + 1) / 2
+		l = len(s)
+	}
+	var b byte
+	for i := 0; i < l; i++ {
+		bit := s[i] & 1
+		if op == 'B' {
+			b |= bit << (7 - uint(i%8))
+		} else {
+			b |= bit << uint(i%8)
+		}
+		if i%8 == 7 {
+			out = append(out, b)
+			b = 0
+		}
+	}
+	if l%8 != 0 {
+		out = append(out, b)
+	}
+	return append(out, make([]byte, extra)...)
+}
+
+// packHex appends the H and h directives' l nibbles from s. As in Ruby, a
+// count beyond the end of s also appends nulls to make up the bytes.
+func packHex(out []byte, op byte, s string, l int) []byte {
+	extra := 0
+	if l > len(s) {
+		extra = (l+1)/2 - (len(s)+1)/2
+		l = len(s)
+	}
+	var b byte
+	for i := 0; i < l; i++ {
+		c := s[i]
+		nibble := c & 15
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+			nibble = (nibble + 9) & 15
+		}
+		if (op == 'H') == (i%2 == 0) {
+			nibble <<= 4
+		}
+		b |= nibble
+		if i%2 == 1 {
+			out = append(out, b)
+			b = 0
+		}
+	}
+	if l%2 != 0 {
+		out = append(out, b)
+	}
+	return append(out, make([]byte, extra)...)
+}
+
+func appendUint(out []byte, v uint64, size int, bigEndian bool) []byte {
+	for i := 0; i < size; i++ {
+		shift := uint(8 * i)
+		if bigEndian {
+			shift = uint(8 * (size - 1 - i))
+		}
+		out = append(out, byte(v>>shift))
+	}
+	return out
+}
+
+func readUint(s string, size int, bigEndian bool) uint64 {
+	var v uint64
+	for i := 0; i < size; i++ {
+		shift := uint(8 * i)
+		if bigEndian {
+			shift = uint(8 * (size - 1 - i))
+		}
+		v |= uint64(s[i]) << shift
+	}
+	return v
+}
+
+func appendRune(out []byte, r rune) []byte {
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], r)
+	return append(out, buf[:n]...)
+}
+
+// appendBER appends i as a BER-compressed integer: base 128 digits, most
+// significant first, with the high bit set on all but the last.
+func appendBER(out []byte, i *big.Int) []byte {
+	var digits []byte
+	v := new(big.Int).Set(i)
+	mask := big.NewInt(0x7f)
+	for {
+		digits = append(digits, byte(new(big.Int).And(v, mask).Int64()))
+		v.Rsh(v, 7)
+		if v.Sign() == 0 {
+			break
+		}
+	}
+	for j := len(digits) - 1; j >= 0; j-- {
+		b := digits[j]
+		if j > 0 {
+			b |= 0x80
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+// encodeLine appends one line of base64 (m) or uuencoded (u) data,
+// followed by a line feed.
+func encodeLine(out []byte, op byte, s string) []byte {
+	if op == 'm' {
+		out = append(out, base64.StdEncoding.EncodeToString([]byte(s))...)
+		return append(out, '\n')
+	}
+	out = append(out, byte(len(s))+' ')
+	for ; len(s) > 0; s = s[min3(len(s)):] {
+		var b [3]byte
+		copy(b[:], s)
+		out = append(out,
+			uuTable[b[0]>>2],
+			uuTable[(b[0]<<4|b[1]>>4)&077],
+			uuTable[(b[1]<<2|b[2]>>6)&077],
+			uuTable[b[2]&077],
+		)
+		switch len(s) {
+		case 1:
+			out[len(out)-2], out[len(out)-1] = '`', '`'
+		case 2:
+			out[len(out)-1] = '`'
+		}
+	}
+	return append(out, '\n')
+}
+
+func min3(n int) int {
+	if n < 3 {
+		return n
+	}
+	return 3
+}
+
+// encodeQP appends s in quoted-printable encoding, with soft line breaks
+// once a line exceeds l characters.
+func encodeQP(out []byte, s string, l int) []byte {
+	const hex = "0123456789ABCDEF"
+	n := 0
+	prev := -1
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c > 126 || (c < 32 && c != '\n' && c != '\t') || c == '=':
+			out = append(out, '=', hex[c>>4], hex[c&15])
+			n += 3
+			prev = -1
+		case c == '\n':
+			if prev == ' ' || prev == '\t' {
+				out = append(out, '=', c)
+			}
+			out = append(out, c)
+			n = 0
+			prev = int(c)
+			continue
+		default:
+			out = append(out, c)
+			n++
+			prev = int(c)
+		}
+		if n > l {
+			out = append(out, '=', '\n')
+			n = 0
+			prev = '\n'
+		}
+	}
+	if n > 0 {
+		out = append(out, '=', '\n')
+	}
+	return out
+}
+
+// decodeBase64 leniently decodes s, ignoring characters outside the base64
+// alphabet and stopping at the first padding character.
+func decodeBase64(s string) string {
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+	var out []byte
+	var acc uint
+	bits := uint(0)
+	for i := 0; i < len(s) && s[i] != '='; i++ {
+		v := strings.IndexByte(alphabet, s[i])
+		if v < 0 {
+			continue
+		}
+		acc = acc<<6 | uint(v)
+		bits += 6
+		if bits >= 8 {
+			bits -= 8
+			out = append(out, byte(acc>>bits))
+		}
+	}
+	return string(out)
+}
+
+// decodeQP decodes quoted-printable s, leaving malformed escapes as they
+// are.
+func decodeQP(s string) string {
+	var out []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '=' {
+			out = append(out, c)
+			continue
+		}
+		switch {
+		case i+1 < len(s) && s[i+1] == '\n':
+			i++
+		case i+2 < len(s) && s[i+1] == '\r' && s[i+2] == '\n':
+			i += 2
+		case i+2 < len(s):
+			if b, err := strconv.ParseUint(s[i+1:i+3], 16, 8); err == nil {
+				out = append(out, byte(b))
+				i += 2
+				continue
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return string(out)
+}
+
+// decodeUU decodes uuencoded lines in s.
+func decodeUU(s string) string {
+	var out []byte
+	for len(s) > 0 {
+		line := s
+		if i := strings.IndexByte(s, '\n'); i >= 0 {
+			line, s = s[:i], s[i+1:]
+		} else {
+			s = ""
+		}
+		line = strings.TrimSuffix(line, "\r")
+		if line == "" {
+			continue
+		}
+		n := int((line[0] - ' ') & 077)
+		line = line[1:]
+		for n > 0 {
+			var c [4]byte
+			for j := range c {
+				if j < len(line) {
+					c[j] = (line[j] - ' ') & 077
+				}
+			}
+			b := []byte{c[0]<<2 | c[1]>>4, c[1]<<4 | c[2]>>2, c[2]<<6 | c[3]}
+			if n < 3 {
+				b = b[:n]
+			}
+			out = append(out, b...)
+			n -= len(b)
+			if len(line) > 4 {
+				line = line[4:]
+			} else {
+				line = ""
+			}
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,140 @@
+package stringx
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func Test_Pack(t *testing.T) {
+	tests := []struct {
+		template string
+		items    []interface{}
+		expected string
+	}{
+		{"CCC", []interface{}{65, 66, 67}, "ABC"},
+		{"C*", []interface{}{65, 66, 67}, "ABC"},
+		{"cC", []interface{}{-1, 257}, "\xff\x01"},
+		{"s>l<", []interface{}{-2, 1}, "\xff\xfe\x01\x00\x00\x00"},
+		{"S<L>Q>q<", []interface{}{1, 2, 3, -1}, "\x01\x00\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00\x03\xff\xff\xff\xff\xff\xff\xff\xff"},
+		{"nNvV", []interface{}{1, 2, 3, 4}, "\x00\x01\x00\x00\x00\x02\x03\x00\x04\x00\x00\x00"},
+		{"Q>", []interface{}{uint64(math.MaxUint64)}, "\xff\xff\xff\xff\xff\xff\xff\xff"},
+		{"N", []interface{}{2.9}, "\x00\x00\x00\x02"},
+		{"a3a3a3", []interface{}{"a", "b", "c"}, "a\x00\x00b\x00\x00c\x00\x00"},
+		{"a*A3Z*Z2a", []interface{}{"ab", "a", "b", "cde", []byte("xy")}, "aba  b\x00cdx"},
+		{"B*b*B4", []interface{}{"10000110", "10000110", "1111"}, "\x86\x61\xf0"},
+		{"b12", []interface{}{"1"}, "\x01\x00\x00\x00\x00\x00\x00"},
+		{"H*h*H3", []interface{}{"a1F", "a1", "abc"}, "\xa1\xf0\x1a\xab\xc0"},
+		{"h5", []interface{}{"1"}, "\x01\x00\x00"},
+		{"U*", []interface{}{82, 252, 98, 121, 0x1F600}, "Rüby\U0001F600"},
+		{"w*", []interface{}{0, 127, 128, 16384, new(big.Int).Lsh(big.NewInt(1), 64)},
+			"\x00\x7f\x81\x00\x81\x80\x00\x82\x80\x80\x80\x80\x80\x80\x80\x80\x00"},
+		{"E G e g", []interface{}{1.5, 1.5, 0.25, 0.25}, "\x00\x00\x00\x00\x00\x00\xf8\x3f\x3f\xf8\x00\x00\x00\x00\x00\x00\x00\x00\x80\x3e\x3e\x80\x00\x00"},
+		{"m", []interface{}{"abc"}, "YWJj\n"},
+		{"m0", []interface{}{"Man is"}, "TWFuIGlz"},
+		{"m3", []interface{}{"abcdefgh"}, "YWJj\nZGVm\nZ2g=\n"},
+		{"m", []interface{}{string(make([]byte, 46))}, "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA\nAA==\n"},
+		{"u", []interface{}{"abc"}, "#86)C\n"},
+		{"u", []interface{}{"ab"}, "\"86(`\n"},
+		{"M", []interface{}{"a=b\tc\xff \nd"}, "a=3Db\tc=FF =\n\nd=\n"},
+		{"M", []interface{}{"tab \n"}, "tab =\n\n"},
+		{"M5", []interface{}{"abcdefghij"}, "abcdef=\nghij=\n"},
+		{"M", []interface{}{42}, "42=\n"},
+		{"Cx2CXC", []interface{}{1, 2, 3}, "\x01\x00\x00\x03"},
+		{"a*@6a@1", []interface{}{"abc", "z"}, "a"},
+		{"a*@5", []interface{}{"abc"}, "abc\x00\x00"},
+		{" C # comment\n C ", []interface{}{1, 2}, "\x01\x02"},
+	}
+	for _, test := range tests {
+		got, err := Pack(test.template, test.items...)
+		if err != nil || got != test.expected {
+			t.Errorf("expected Pack(%q, %v) to return %q but got %q (%v)", test.template, test.items, test.expected, got, err)
+		}
+	}
+
+	if got, err := Pack("s_S!"+"l_", 1, 2, 3); err != nil || len(got) != 4+longSize {
+		t.Errorf("expected native sizes to give %d bytes but got %q (%v)", 4+longSize, got, err)
+	}
+
+	errs := []struct {
+		template string
+		items    []interface{}
+	}{
+		{"CC", []interface{}{1}},
+		{"C", []interface{}{"1"}},
+		{"a", []interface{}{1}},
+		{"U", []interface{}{-1}},
+		{"w", []interface{}{-1}},
+		{"d", []interface{}{"1.5"}},
+		{"CX2", []interface{}{1}},
+		{"y", nil},
+		{"C<", []interface{}{1}},
+		{"s<>", []interface{}{1}},
+	}
+	for _, test := range errs {
+		if got, err := Pack(test.template, test.items...); err == nil {
+			t.Errorf("expected Pack(%q, %v) to fail but got %q", test.template, test.items, got)
+		}
+	}
+}
+
+func Test_Unpack(t *testing.T) {
+	tests := []struct {
+		str, template string
+		expected      []interface{}
+	}{
+		{"ABC", "C*", []interface{}{int64(65), int64(66), int64(67)}},
+		{"\x01", "C3", []interface{}{int64(1), nil, nil}},
+		{"\xff\xfe\x01\x00\x00\x00", "s>l<", []interface{}{int64(-2), int64(1)}},
+		{"\xff\xff", "cC", []interface{}{int64(-1), int64(255)}},
+		{"\xff\xff\xff\xff\xff\xff\xff\xff", "Q<", []interface{}{uint64(math.MaxUint64)}},
+		{"\xff\xff\xff\xff\xff\xff\xff\xff", "q>", []interface{}{int64(-1)}},
+		{"\x00\x01\x00\x00\x00\x02\x03\x00\x04\x00\x00\x00", "nNvV", []interface{}{int64(1), int64(2), int64(3), int64(4)}},
+		{"\xff\xff\xff\xff", "N", []interface{}{int64(math.MaxUint32)}},
+		{"abc \x00\x00abc \x00\x00", "A6Z6", []interface{}{"abc", "abc "}},
+		{"ab\x00cd\x00", "Z*Z*a", []interface{}{"ab", "cd", ""}},
+		{"abc", "a2a5", []interface{}{"ab", "c"}},
+		{"aa", "b8B8", []interface{}{"10000110", "01100001"}},
+		{"\x86\x61", "B*", []interface{}{"1000011001100001"}},
+		{"\xa1\xf0\x0f", "H3h*", []interface{}{"a1f", "f0"}},
+		{"Rüby\U0001F600", "U*", []interface{}{int64(82), int64(252), int64(98), int64(121), int64(0x1F600)}},
+		{"\x00\x7f\x81\x00\x82\x80\x80\x80\x80\x80\x80\x80\x80\x00", "w*", []interface{}{uint64(0), uint64(127), uint64(128), new(big.Int).Lsh(big.NewInt(1), 64)}},
+		{"\x00\x00\x00\x00\x00\x00\xf8\x3f\x3e\x80\x00\x00", "Eg", []interface{}{1.5, 0.25}},
+		{"\x00\x00\x80\x3e", "e2", []interface{}{0.25, nil}},
+		{"YWJj\nZGVm\nZ2g=\n", "m", []interface{}{"abcdefgh"}},
+		{"YW*Jj", "m", []interface{}{"abc"}},
+		{"TWFuIGlz", "m0", []interface{}{"Man is"}},
+		{"#86)C\n", "u", []interface{}{"abc"}},
+		{"\"86(`\n", "u", []interface{}{"ab"}},
+		{"a=3Db\tc=FF =\n\nd=\n", "M", []interface{}{"a=b\tc\xff \nd"}},
+		{"a=zz=\r\nb", "M", []interface{}{"a=zzb"}},
+		{"\x01\x02\x03", "Cx*", []interface{}{int64(1)}},
+		{"\x01\x02\x03", "CxC", []interface{}{int64(1), int64(3)}},
+		{"\x01\x02\x03", "CXC@2C", []interface{}{int64(1), int64(1), int64(3)}},
+	}
+	for _, test := range tests {
+		got, err := Unpack(test.str, test.template)
+		if err != nil || !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("expected Unpack(%q, %q) to return %#v but got %#v (%v)", test.str, test.template, test.expected, got, err)
+		}
+	}
+
+	for _, template := range []string{"x4", "X", "@4", "m0", "y"} {
+		if got, err := Unpack("abc", template); err == nil {
+			t.Errorf("expected Unpack(\"abc\", %q) to fail but got %#v", template, got)
+		}
+	}
+	if got, err := Unpack("\xff", "U"); err == nil {
+		t.Errorf("expected malformed UTF-8 to fail but got %#v", got)
+	}
+
+	items := []interface{}{int64(-3), "abc", uint64(1) << 63, 2.5}
+	packed, err := Pack("l>A4Q<G", items...)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if got, err := Unpack(packed, "l>A4Q<G"); err != nil || !reflect.DeepEqual(got, items) {
+		t.Errorf("expected %#v to round-trip but got %#v (%v)", items, got, err)
+	}
+}
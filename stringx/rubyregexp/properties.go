@@ -0,0 +1,128 @@
+package rubyregexp
+
+import (
+	"strings"
+	"unicode"
+)
+
+// classItems is the translation of a POSIX bracket or character property:
+// the contents of an RE2 character class, possibly negated.
+type classItems struct {
+	items   string
+	negated bool
+}
+
+// single reports whether items is a single \p{...} or [:...:] item, which
+// RE2 can negate in place.
+func (c classItems) single() bool {
+	return (strings.HasPrefix(c.items, `\p{`) && strings.Count(c.items, `\p{`) == 1 && strings.HasSuffix(c.items, "}")) ||
+		(strings.HasPrefix(c.items, "[:") && strings.Count(c.items, "[:") == 1 && strings.HasSuffix(c.items, ":]"))
+}
+
+func (c classItems) negate() string {
+	if strings.HasPrefix(c.items, `\p{`) {
+		return `\P` + c.items[2:]
+	}
+	return "[:^" + c.items[2:]
+}
+
+// outside returns the items as a standalone expression.
+func (c classItems) outside() string {
+	switch {
+	case c.single() && strings.HasPrefix(c.items, `\p{`):
+		if c.negated {
+			return c.negate()
+		}
+		return c.items
+	case c.negated:
+		return "[^" + c.items + "]"
+	}
+	return "[" + c.items + "]"
+}
+
+// inside returns the items for use within a character class, or false if
+// they can't be negated there.
+func (c classItems) inside() (string, bool) {
+	switch {
+	case !c.negated:
+		return c.items, true
+	case c.single():
+		return c.negate(), true
+	}
+	return "", false
+}
+
+// posixClasses maps the POSIX bracket names, which are also property
+// names, to their Unicode definitions in Onigmo.
+var posixClasses = map[string]classItems{
+	"alnum":  {items: `\p{L}\p{M}\p{Nd}`},
+	"alpha":  {items: `\p{L}\p{M}`},
+	"ascii":  {items: `[:ascii:]`},
+	"blank":  {items: `\t\p{Zs}`},
+	"cntrl":  {items: `\p{Cc}`},
+	"digit":  {items: `\p{Nd}`},
+	"graph":  {items: `\p{L}\p{M}\p{N}\p{P}\p{S}\p{Cf}\p{Co}`},
+	"lower":  {items: `\p{Ll}`},
+	"print":  {items: `\p{L}\p{M}\p{N}\p{P}\p{S}\p{Cf}\p{Co}\p{Zs}`},
+	"punct":  {items: `\p{P}`},
+	"space":  {items: `\t\n\v\f\r\x{85}\p{Z}`},
+	"upper":  {items: `\p{Lu}`},
+	"word":   {items: `\p{L}\p{M}\p{Nd}\p{Pc}`},
+	"xdigit": {items: `[:xdigit:]`},
+}
+
+// categoryNames maps the long names of the general categories to the
+// short ones used by package unicode.
+var categoryNames = map[string]string{
+	"letter": "L", "casedletter": "LC", "uppercaseletter": "Lu", "lowercaseletter": "Ll",
+	"titlecaseletter": "Lt", "modifierletter": "Lm", "otherletter": "Lo",
+	"mark": "M", "nonspacingmark": "Mn", "spacingmark": "Mc", "enclosingmark": "Me",
+	"number": "N", "decimalnumber": "Nd", "letternumber": "Nl", "othernumber": "No",
+	"punctuation": "P", "connectorpunctuation": "Pc", "dashpunctuation": "Pd",
+	"openpunctuation": "Ps", "closepunctuation": "Pe", "initialpunctuation": "Pi",
+	"finalpunctuation": "Pf", "otherpunctuation": "Po",
+	"symbol": "S", "mathsymbol": "Sm", "currencysymbol": "Sc", "modifiersymbol": "Sk", "othersymbol": "So",
+	"separator": "Z", "spaceseparator": "Zs", "lineseparator": "Zl", "paragraphseparator": "Zp",
+	"other": "C", "control": "Cc", "format": "Cf", "surrogate": "Cs", "privateuse": "Co",
+}
+
+// propertyNames maps normalized category and script names to the names
+// package unicode uses.
+var propertyNames = func() map[string]string {
+	names := make(map[string]string)
+	for name := range unicode.Categories {
+		names[normalizeProperty(name)] = name
+	}
+	for name := range unicode.Scripts {
+		names[normalizeProperty(name)] = name
+	}
+	for long, short := range categoryNames {
+		if _, ok := unicode.Categories[short]; ok {
+			names[long] = short
+		}
+	}
+	return names
+}()
+
+// normalizeProperty folds a property name as Onigmo does, ignoring case,
+// spaces, hyphens and underscores.
+func normalizeProperty(name string) string {
+	name = strings.ToLower(name)
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(name)
+}
+
+// lookupProperty returns the translation of the character property name,
+// which may be a POSIX bracket name, a general category or a script.
+func lookupProperty(name string) (classItems, bool) {
+	key := normalizeProperty(name)
+	if key == "any" {
+		return classItems{items: `\x{0}-\x{10FFFF}`}, true
+	}
+	if items, ok := posixClasses[key]; ok {
+		return items, true
+	}
+	if name, ok := propertyNames[key]; ok {
+		return classItems{items: `\p{` + name + `}`}, true
+	}
+	return classItems{}, false
+}
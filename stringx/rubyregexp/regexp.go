@@ -0,0 +1,51 @@
+// Package rubyregexp compiles Ruby regular expressions for use with Go.
+// It translates the Onigmo syntax that Ruby uses to the RE2 syntax of
+// package regexp where an equivalent exists, and reports the exact
+// construct that stands in the way where it doesn't.
+//
+// The functions of package stringx that take a pattern accept a *Regexp as
+// well as a string or *regexp.Regexp.
+package rubyregexp
+
+import "regexp"
+
+// Regexp is a compiled Ruby regular expression. It embeds the translated
+// *regexp.Regexp, so all of its methods are available.
+type Regexp struct {
+	*regexp.Regexp
+	source string
+}
+
+// Compile translates a Ruby regular expression and compiles it. The error,
+// if any, is an *Error if the pattern can't be translated.
+//
+//	re, err := Compile(`\A(?<key>\h+)\s*=\s*(?<value>.*)\z`)
+//	re.FindStringSubmatch("ff = on")  // ["ff = on", "ff", "on"], nil
+//
+//	_, err = Compile(`(?<!\d)x`)
+//	err  // rubyregexp: lookbehind is not supported: "(?<!" at offset 0 in "(?<!\\d)x"
+func Compile(pattern string) (*Regexp, error) {
+	translated, err := Translate(pattern)
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(translated)
+	if err != nil {
+		return nil, err
+	}
+	return &Regexp{Regexp: re, source: pattern}, nil
+}
+
+// MustCompile is like Compile but panics if the pattern can't be compiled.
+func MustCompile(pattern string) *Regexp {
+	re, err := Compile(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return re
+}
+
+// Source returns the Ruby pattern the Regexp was compiled from.
+func (re *Regexp) Source() string {
+	return re.source
+}
@@ -0,0 +1,669 @@
+package rubyregexp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Error reports a Ruby regular expression that can't be translated, either
+// because it is malformed or because it uses a construct that RE2 doesn't
+// support.
+type Error struct {
+	// Pattern is the Ruby pattern being translated.
+	Pattern string
+	// Offset is the byte offset of the construct in Pattern.
+	Offset int
+	// Construct is the offending part of Pattern, such as "(?<=".
+	Construct string
+	// Reason describes the problem, such as "lookbehind is not supported".
+	Reason string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("rubyregexp: %s: %q at offset %d in %q", e.Reason, e.Construct, e.Offset, e.Pattern)
+}
+
+// Translate converts a Ruby (Onigmo) regular expression to the RE2 syntax
+// of package regexp. It translates:
+//
+//   - \A, \z and \Z; ^ and $ always match at line boundaries, as in Ruby
+//   - \h, \H, \R, \e, \s (which includes \v), \u and octal escapes
+//   - (?<name>...) and (?'name'...) groups; as in Ruby, plain groups
+//     don't capture when a pattern has named groups
+//   - POSIX brackets such as [[:alpha:]] and properties such as
+//     \p{Hiragana}, \p{^Alpha} and \p{Uppercase_Letter}, with Unicode
+//     semantics
+//   - inline options (?imx-imx) and (?imx-imx:...), including extended
+//     mode, and (?#...) comments
+//
+// Constructs without an RE2 equivalent, such as lookaround, backreferences,
+// atomic groups, possessive quantifiers and class intersection, give an
+// *Error locating them in the pattern.
+//
+// As RE2 has no lookahead, \Z consumes a final newline instead of merely
+// asserting it. It is only translated at the very end of a pattern, where
+// that can't change what the rest of the pattern matches, but the match
+// still includes the newline, so Sub and Gsub replace it too.
+//
+//	Translate(`\A\h+\z`)            // `(?m)\A[0-9A-Fa-f]+\z`, nil
+//	Translate(`(?<year>\d+)-(\d+)`) // `(?m)(?P<year>\d+)-(?:\d+)`, nil
+//	Translate(`(?<=a)b`)            // "", lookbehind is not supported
+func Translate(pattern string) (string, error) {
+	t := &translator{src: pattern, extended: []bool{false}}
+	t.captureUnnamed = !t.hasNamedGroups()
+	t.out.WriteString("(?m)")
+	if err := t.translate(); err != nil {
+		return "", err
+	}
+	return t.out.String(), nil
+}
+
+type translator struct {
+	src            string
+	pos            int
+	out            strings.Builder
+	extended       []bool
+	captureUnnamed bool
+}
+
+func (t *translator) errorf(offset, end int, format string, args ...interface{}) error {
+	if end > len(t.src) {
+		end = len(t.src)
+	}
+	return &Error{Pattern: t.src, Offset: offset, Construct: t.src[offset:end], Reason: fmt.Sprintf(format, args...)}
+}
+
+func (t *translator) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(t.src[t.pos:], prefix)
+}
+
+// hasNamedGroups reports whether the pattern has a named group, skipping
+// escapes and character classes.
+func (t *translator) hasNamedGroups() bool {
+	depth := 0
+	for i := 0; i < len(t.src); i++ {
+		switch c := t.src[i]; {
+		case c == '\\':
+			i++
+		case c == '[':
+			depth++
+		case c == ']' && depth > 0:
+			depth--
+		case depth == 0 && strings.HasPrefix(t.src[i:], "(?'"):
+			return true
+		case depth == 0 && strings.HasPrefix(t.src[i:], "(?<") && i+3 < len(t.src) &&
+			t.src[i+3] != '=' && t.src[i+3] != '!':
+			return true
+		}
+	}
+	return false
+}
+
+func (t *translator) translate() error {
+	for t.pos < len(t.src) {
+		start := t.pos
+		c := t.src[t.pos]
+		switch {
+		case t.extended[len(t.extended)-1] && isSpace(c):
+			t.pos++
+		case t.extended[len(t.extended)-1] && c == '#':
+			for t.pos < len(t.src) && t.src[t.pos] != '\n' {
+				t.pos++
+			}
+		case c == '\\':
+			if err := t.escape(); err != nil {
+				return err
+			}
+		case c == '[':
+			if err := t.class(); err != nil {
+				return err
+			}
+		case c == '(':
+			if err := t.group(); err != nil {
+				return err
+			}
+		case c == ')':
+			if len(t.extended) == 1 {
+				return t.errorf(start, start+1, "unmatched close parenthesis")
+			}
+			t.extended = t.extended[:len(t.extended)-1]
+			t.out.WriteByte(')')
+			t.pos++
+		case c == '*' || c == '+' || c == '?':
+			t.out.WriteByte(c)
+			t.pos++
+			if err := t.quantifierSuffix(); err != nil {
+				return err
+			}
+		case c == '{':
+			if t.interval() {
+				if err := t.quantifierSuffix(); err != nil {
+					return err
+				}
+			}
+		default:
+			t.out.WriteByte(c)
+			t.pos++
+		}
+	}
+	if len(t.extended) > 1 {
+		return t.errorf(len(t.src), len(t.src), "end pattern with unmatched parenthesis")
+	}
+	return nil
+}
+
+// quantifierSuffix checks what follows a quantifier: ? makes it lazy,
+// which RE2 supports, while + makes it possessive and a further
+// quantifier nests it, which RE2 doesn't support. A brace that doesn't
+// start an interval is a literal and is left to the caller.
+func (t *translator) quantifierSuffix() error {
+	if t.pos >= len(t.src) {
+		return nil
+	}
+	switch t.src[t.pos] {
+	case '?':
+		t.out.WriteByte('?')
+		t.pos++
+	case '+':
+		return t.errorf(t.pos-1, t.pos+1, "possessive quantifier is not supported")
+	case '*':
+		return t.errorf(t.pos-1, t.pos+1, "nested quantifier is not supported")
+	case '{':
+		if _, n := t.parseInterval(); n > 0 {
+			return t.errorf(t.pos-1, t.pos+1, "nested quantifier is not supported")
+		}
+	}
+	return nil
+}
+
+// interval copies a {n,m} quantifier, filling in Ruby's {,m} form, and
+// reports whether there was one. As in both Ruby and RE2, a brace that
+// doesn't start an interval is literal.
+func (t *translator) interval() bool {
+	body, n := t.parseInterval()
+	if n == 0 {
+		t.out.WriteString(`\{`)
+		t.pos++
+		return false
+	}
+	t.out.WriteString("{" + body + "}")
+	t.pos += n
+	return true
+}
+
+// parseInterval parses the {n,m} quantifier at the current position,
+// returning it in RE2's form and its length in the pattern, or a length of
+// 0 if the brace doesn't start one.
+func (t *translator) parseInterval() (string, int) {
+	end := strings.IndexByte(t.src[t.pos:], '}')
+	if end < 0 {
+		return "", 0
+	}
+	body := t.src[t.pos+1 : t.pos+end]
+	lo, hi := body, ""
+	if i := strings.IndexByte(body, ','); i >= 0 {
+		lo, hi = body[:i], body[i+1:]
+	}
+	if (lo == "" && hi == "") || !isDigits(lo) || !isDigits(hi) {
+		return "", 0
+	}
+	if lo == "" {
+		body = "0" + body
+	}
+	return body, end + 1
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+func (t *translator) group() error {
+	start := t.pos
+	if !t.hasPrefix("(?") {
+		t.pos++
+		if t.captureUnnamed {
+			t.out.WriteByte('(')
+		} else {
+			t.out.WriteString("(?:")
+		}
+		t.push(t.extended[len(t.extended)-1])
+		return nil
+	}
+
+	rest := t.src[t.pos+2:]
+	switch {
+	case strings.HasPrefix(rest, "#"):
+		end := strings.IndexByte(rest, ')')
+		if end < 0 {
+			return t.errorf(start, len(t.src), "end pattern in group")
+		}
+		t.pos += 2 + end + 1
+		return nil
+	case strings.HasPrefix(rest, ":"):
+		t.out.WriteString("(?:")
+		t.pos += 3
+	case strings.HasPrefix(rest, "="), strings.HasPrefix(rest, "!"):
+		return t.errorf(start, start+3, "lookahead is not supported")
+	case strings.HasPrefix(rest, "<="), strings.HasPrefix(rest, "<!"):
+		return t.errorf(start, start+4, "lookbehind is not supported")
+	case strings.HasPrefix(rest, ">"):
+		return t.errorf(start, start+3, "atomic group is not supported")
+	case strings.HasPrefix(rest, "~"):
+		return t.errorf(start, start+3, "absent operator is not supported")
+	case strings.HasPrefix(rest, "("):
+		return t.errorf(start, start+3, "conditional group is not supported")
+	case strings.HasPrefix(rest, "<"), strings.HasPrefix(rest, "'"):
+		close := byte('>')
+		if rest[0] == '\'' {
+			close = '\''
+		}
+		end := strings.IndexByte(rest[1:], close)
+		if end < 0 {
+			return t.errorf(start, len(t.src), "invalid group name")
+		}
+		name := rest[1 : 1+end]
+		if !isGroupName(name) {
+			return t.errorf(start, start+4+end, "invalid group name <%s>", name)
+		}
+		if !isASCIIGroupName(name) {
+			return t.errorf(start, start+4+end, "non-ASCII group name <%s> is not supported", name)
+		}
+		t.out.WriteString("(?P<" + name + ">")
+		t.pos += 2 + 1 + end + 1
+	default:
+		return t.options(start)
+	}
+	t.push(t.extended[len(t.extended)-1])
+	return nil
+}
+
+func (t *translator) push(extended bool) {
+	t.extended = append(t.extended, extended)
+}
+
+// isASCIIGroupName reports whether name, a valid group name, is one RE2
+// accepts too.
+func isASCIIGroupName(name string) bool {
+	for _, r := range name {
+		if r >= unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+func isGroupName(name string) bool {
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return false
+	}
+	for _, r := range name {
+		if !(r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// options translates (?imx-imx) and (?imx-imx:...). Ruby's m is RE2's s,
+// and x is handled here, as RE2 has no extended mode.
+func (t *translator) options(start int) error {
+	i := t.pos + 2
+	var on, off string
+	extended := t.extended[len(t.extended)-1]
+	negate := false
+	for ; i < len(t.src); i++ {
+		c := t.src[i]
+		switch c {
+		case 'i', 'm', 'x':
+			if c == 'x' {
+				extended = !negate
+				continue
+			}
+			if c == 'm' {
+				c = 's'
+			}
+			if negate {
+				off += string(c)
+			} else {
+				on += string(c)
+			}
+			continue
+		case '-':
+			if negate {
+				return t.errorf(start, i+1, "undefined group option")
+			}
+			negate = true
+			continue
+		case ')', ':':
+		case 'a', 'd', 'u':
+			return t.errorf(start, i+1, "character set option (?%c) is not supported", c)
+		default:
+			return t.errorf(start, i+1, "undefined group option")
+		}
+		break
+	}
+	if i == len(t.src) {
+		return t.errorf(start, i, "end pattern in group")
+	}
+
+	flags := on
+	if off != "" {
+		flags += "-" + off
+	}
+	if t.src[i] == ')' {
+		if flags != "" {
+			t.out.WriteString("(?" + flags + ")")
+		}
+		t.extended[len(t.extended)-1] = extended
+	} else {
+		t.out.WriteString("(?" + flags + ":")
+		t.push(extended)
+	}
+	t.pos = i + 1
+	return nil
+}
+
+// escape translates an escape sequence outside a character class.
+func (t *translator) escape() error {
+	start := t.pos
+	if t.pos+1 >= len(t.src) {
+		return t.errorf(start, start+1, "too short escape sequence")
+	}
+	c := t.src[t.pos+1]
+	t.pos += 2
+	switch c {
+	case 'A', 'z', 'b', 'B', 'd', 'D', 'w', 'W':
+		t.out.WriteString(`\` + string(c))
+	case 'Z':
+		if t.pos < len(t.src) {
+			return t.errorf(start, t.pos, `\Z is only supported at the end of a pattern`)
+		}
+		t.out.WriteString(`\n?\z`)
+	case 'h':
+		t.out.WriteString(`[0-9A-Fa-f]`)
+	case 'H':
+		t.out.WriteString(`[^0-9A-Fa-f]`)
+	case 's':
+		t.out.WriteString(`[\t\n\v\f\r ]`)
+	case 'S':
+		t.out.WriteString(`[^\t\n\v\f\r ]`)
+	case 'R':
+		t.out.WriteString(`(?:\r\n|[\n\v\f\r\x{85}\x{2028}\x{2029}])`)
+	case 'G':
+		return t.errorf(start, t.pos, `\G anchor is not supported`)
+	case 'K':
+		return t.errorf(start, t.pos, `\K keep is not supported`)
+	case 'X':
+		return t.errorf(start, t.pos, `\X extended grapheme cluster is not supported`)
+	case 'g':
+		return t.errorf(start, t.pos, "subexpression call is not supported")
+	case 'k':
+		return t.errorf(start, t.pos, "backreference is not supported")
+	case 'p', 'P':
+		items, err := t.property(start, c == 'P')
+		if err != nil {
+			return err
+		}
+		t.out.WriteString(items.outside())
+	default:
+		lit, ok, err := t.literalEscape(start, c)
+		if err != nil {
+			return err
+		}
+		if !ok && c >= '1' && c <= '9' {
+			return t.errorf(start, t.pos, "backreference is not supported")
+		}
+		t.out.WriteString(lit)
+	}
+	return nil
+}
+
+// literalEscape translates an escape sequence standing for a single
+// character, valid both inside and outside character classes. It reports
+// false for \1 to \9, which are backreferences outside a class.
+func (t *translator) literalEscape(start int, c byte) (string, bool, error) {
+	switch c {
+	case 'a', 'f', 'n', 'r', 't', 'v':
+		return `\` + string(c), true, nil
+	case 'e':
+		return `\x1B`, true, nil
+	case 'x':
+		n := 0
+		for n < 2 && t.pos+n < len(t.src) && isHex(t.src[t.pos+n]) {
+			n++
+		}
+		if n == 0 {
+			return "", false, t.errorf(start, t.pos, "invalid hex escape")
+		}
+		hex := t.src[t.pos : t.pos+n]
+		t.pos += n
+		return `\x{` + hex + `}`, true, nil
+	case 'u':
+		return t.unicodeEscape(start)
+	case 'c':
+		if t.pos >= len(t.src) || t.src[t.pos] >= 0x80 {
+			return "", false, t.errorf(start, t.pos+1, "invalid control code syntax")
+		}
+		ch := t.src[t.pos] & 0x1F
+		t.pos++
+		return fmt.Sprintf(`\x{%X}`, ch), true, nil
+	case 'C', 'M':
+		return "", false, t.errorf(start, t.pos, `\%c- escape is not supported`, c)
+	case '0':
+		n := 0
+		for n < 2 && t.pos+n < len(t.src) && t.src[t.pos+n] >= '0' && t.src[t.pos+n] <= '7' {
+			n++
+		}
+		v, _ := strconv.ParseUint("0"+t.src[t.pos:t.pos+n], 8, 8)
+		t.pos += n
+		return fmt.Sprintf(`\x{%X}`, v), true, nil
+	}
+	if c >= '1' && c <= '9' {
+		return "", false, nil
+	}
+	if c < 0x80 && !isAlnum(c) {
+		return `\` + string(c), true, nil
+	}
+	// Ruby treats other escaped characters as themselves.
+	r := []rune(t.src[t.pos-1:])[0]
+	t.pos += len(string(r)) - 1
+	return quoteRune(r), true, nil
+}
+
+func (t *translator) unicodeEscape(start int) (string, bool, error) {
+	if t.pos < len(t.src) && t.src[t.pos] == '{' {
+		end := strings.IndexByte(t.src[t.pos:], '}')
+		if end < 0 {
+			return "", false, t.errorf(start, len(t.src), "invalid Unicode escape")
+		}
+		fields := strings.Fields(t.src[t.pos+1 : t.pos+end])
+		if len(fields) == 0 {
+			return "", false, t.errorf(start, t.pos+end+1, "invalid Unicode escape")
+		}
+		var sb strings.Builder
+		for _, f := range fields {
+			if len(f) > 6 || !isHexString(f) {
+				return "", false, t.errorf(start, t.pos+end+1, "invalid Unicode escape")
+			}
+			sb.WriteString(`\x{` + f + `}`)
+		}
+		t.pos += end + 1
+		return sb.String(), true, nil
+	}
+	if t.pos+4 > len(t.src) || !isHexString(t.src[t.pos:t.pos+4]) {
+		return "", false, t.errorf(start, t.pos, "invalid Unicode escape")
+	}
+	hex := t.src[t.pos : t.pos+4]
+	t.pos += 4
+	return `\x{` + hex + `}`, true, nil
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isHexString(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isHex(s[i]) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isAlnum(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func quoteRune(r rune) string {
+	if r < 0x80 && !isAlnum(byte(r)) {
+		return `\` + string(r)
+	}
+	return string(r)
+}
+
+// class translates a bracketed character class.
+func (t *translator) class() error {
+	start := t.pos
+	t.pos++
+	t.out.WriteByte('[')
+	if t.hasPrefix("^") {
+		t.out.WriteByte('^')
+		t.pos++
+	}
+	if t.hasPrefix("]") {
+		t.out.WriteString(`\]`)
+		t.pos++
+	}
+	for t.pos < len(t.src) {
+		at := t.pos
+		c := t.src[t.pos]
+		switch {
+		case c == ']':
+			t.out.WriteByte(']')
+			t.pos++
+			return nil
+		case c == '[' && t.hasPrefix("[:"):
+			items, err := t.posixBracket()
+			if err != nil {
+				return err
+			}
+			t.out.WriteString(items)
+		case c == '[':
+			return t.errorf(at, at+1, "nested character class is not supported")
+		case c == '&' && t.hasPrefix("&&"):
+			return t.errorf(at, at+2, "character class intersection is not supported")
+		case c == '\\':
+			if err := t.classEscape(); err != nil {
+				return err
+			}
+		default:
+			t.out.WriteByte(c)
+			t.pos++
+		}
+	}
+	return t.errorf(start, len(t.src), "premature end of char-class")
+}
+
+func (t *translator) classEscape() error {
+	start := t.pos
+	if t.pos+1 >= len(t.src) {
+		return t.errorf(start, start+1, "premature end of char-class")
+	}
+	c := t.src[t.pos+1]
+	t.pos += 2
+	switch c {
+	case 'd', 'D', 'w', 'W', 'S':
+		t.out.WriteString(`\` + string(c))
+	case 'b':
+		t.out.WriteString(`\x08`)
+	case 'h':
+		t.out.WriteString(`0-9A-Fa-f`)
+	case 's':
+		t.out.WriteString(`\t\n\v\f\r `)
+	case 'H':
+		return t.errorf(start, t.pos, `\H is not supported in a character class`)
+	case 'p', 'P':
+		items, err := t.property(start, c == 'P')
+		if err != nil {
+			return err
+		}
+		inside, ok := items.inside()
+		if !ok {
+			return t.errorf(start, t.pos, "negated property is not supported in a character class")
+		}
+		t.out.WriteString(inside)
+	default:
+		lit, ok, err := t.literalEscape(start, c)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			// \1 to \7 are octal escapes in a class; \8 and \9 are literal.
+			if c <= '7' {
+				return t.errorf(start, t.pos, "octal escape is not supported")
+			}
+			lit = string(c)
+		}
+		t.out.WriteString(lit)
+	}
+	return nil
+}
+
+// posixBracket translates [:name:] and [:^name:] inside a class.
+func (t *translator) posixBracket() (string, error) {
+	start := t.pos
+	end := strings.Index(t.src[t.pos:], ":]")
+	if end < 0 {
+		return "", t.errorf(start, start+2, "invalid POSIX bracket type")
+	}
+	name := t.src[t.pos+2 : t.pos+end]
+	t.pos += end + 2
+	negated := strings.HasPrefix(name, "^")
+	name = strings.TrimPrefix(name, "^")
+	items, ok := posixClasses[name]
+	if !ok {
+		return "", t.errorf(start, t.pos, "invalid POSIX bracket type")
+	}
+	items.negated = negated
+	inside, ok := items.inside()
+	if !ok {
+		return "", t.errorf(start, t.pos, "negated POSIX bracket [:^%s:] is not supported", name)
+	}
+	return inside, nil
+}
+
+// property parses the name of a \p{...} or \P{...} property, the
+// backslash of which is at start.
+func (t *translator) property(start int, negated bool) (classItems, error) {
+	if !t.hasPrefix("{") {
+		return classItems{}, t.errorf(start, t.pos, "invalid character property name")
+	}
+	end := strings.IndexByte(t.src[t.pos:], '}')
+	if end < 0 {
+		return classItems{}, t.errorf(start, len(t.src), "invalid character property name")
+	}
+	name := t.src[t.pos+1 : t.pos+end]
+	t.pos += end + 1
+	if strings.HasPrefix(name, "^") {
+		negated = !negated
+		name = name[1:]
+	}
+	items, ok := lookupProperty(name)
+	if !ok {
+		return classItems{}, t.errorf(start, t.pos, "invalid character property name {%s}", name)
+	}
+	items.negated = negated
+	return items, nil
+}
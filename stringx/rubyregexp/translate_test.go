@@ -0,0 +1,142 @@
+package rubyregexp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Translate(t *testing.T) {
+	tests := map[string]string{
+		`\A\h+\z`:                  `(?m)\A[0-9A-Fa-f]+\z`,
+		`\H`:                       `(?m)[^0-9A-Fa-f]`,
+		`(?<year>\d+)-(\d+)`:       `(?m)(?P<year>\d+)-(?:\d+)`,
+		`(?'year'\d+)`:             `(?m)(?P<year>\d+)`,
+		`(a)(?:b)`:                 `(?m)(a)(?:b)`,
+		`a{,3}b{2,}c{1}?`:          `(?m)a{0,3}b{2,}c{1}?`,
+		`a{x}{`:                    `(?m)a\{x}\{`,
+		`a*{foo}b+{`:               `(?m)a*\{foo}b+\{`,
+		`(?i)a(?m-i:.)(?x: b c )`:  `(?m)(?i)a(?s-i:.)(?:bc)`,
+		`a(?#comment)b`:            `(?m)ab`,
+		`[[:alpha:][:^digit:]_]`:   `(?m)[\p{L}\p{M}\P{Nd}_]`,
+		`[[:xdigit:]][[:^ascii:]]`: `(?m)[[:xdigit:]][[:^ascii:]]`,
+		`\p{Hiragana}\p{^Greek}`:   `(?m)\p{Hiragana}\P{Greek}`,
+		`\P{alpha}\p{Lu}`:          `(?m)[^\p{L}\p{M}]\p{Lu}`,
+		`\p{Uppercase_Letter}`:     `(?m)\p{Lu}`,
+		`[\h\s\b]`:                 `(?m)[0-9A-Fa-f\t\n\v\f\r \x08]`,
+		`\e\0é\u{1F600 41}`:        `(?m)\x1B\x{0}é\x{1F600}\x{41}`,
+		`\x41\cA\y\.`:              `(?m)\x{41}\x{1}y\.`,
+		`a*?b+?c??`:                `(?m)a*?b+?c??`,
+		`a|b\Z`:                    `(?m)a|b\n?\z`,
+	}
+	for pattern, expected := range tests {
+		if got, err := Translate(pattern); err != nil || got != expected {
+			t.Errorf("expected Translate(%q) to return %q but got %q (%v)", pattern, expected, got, err)
+		}
+	}
+}
+
+func Test_TranslateErrors(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		offset    int
+		construct string
+		reason    string
+	}{
+		{`(?<=a)b`, 0, "(?<=", "lookbehind is not supported"},
+		{`ab(?<!c)`, 2, "(?<!", "lookbehind is not supported"},
+		{`a(?=b)`, 1, "(?=", "lookahead is not supported"},
+		{`a(?!b)`, 1, "(?!", "lookahead is not supported"},
+		{`(a)\1`, 3, `\1`, "backreference is not supported"},
+		{`(?<x>a)\k<x>`, 7, `\k`, "backreference is not supported"},
+		{`(?>a+)`, 0, "(?>", "atomic group is not supported"},
+		{`a++`, 1, "++", "possessive quantifier is not supported"},
+		{`a**`, 1, "**", "nested quantifier is not supported"},
+		{`a+{2}`, 1, "+{", "nested quantifier is not supported"},
+		{`[a-z&&[^aeiou]]`, 4, "&&", "character class intersection is not supported"},
+		{`[a[bc]]`, 2, "[", "nested character class is not supported"},
+		{`\Gabc`, 0, `\G`, `\G anchor is not supported`},
+		{`\p{Klingon}`, 0, `\p{Klingon}`, "invalid character property name {Klingon}"},
+		{`[[:foo:]]`, 1, "[:foo:]", "invalid POSIX bracket type"},
+		{`[[:^alpha:]]`, 1, "[:^alpha:]", "negated POSIX bracket [:^alpha:] is not supported"},
+		{`(a`, 2, "", "end pattern with unmatched parenthesis"},
+		{`a)`, 1, ")", "unmatched close parenthesis"},
+		{`[abc`, 0, "[abc", "premature end of char-class"},
+		{`(?a)x`, 0, "(?a", "character set option (?a) is not supported"},
+		{`a\Zb`, 1, `\Z`, `\Z is only supported at the end of a pattern`},
+		{`(a\Z)`, 2, `\Z`, `\Z is only supported at the end of a pattern`},
+		{`(?<név>a)`, 0, "(?<név>", "non-ASCII group name <név> is not supported"},
+	}
+	for _, test := range tests {
+		_, err := Translate(test.pattern)
+		e, ok := err.(*Error)
+		if !ok {
+			t.Errorf("expected Translate(%q) to return an *Error but got %v", test.pattern, err)
+			continue
+		}
+		if e.Offset != test.offset || e.Construct != test.construct || e.Reason != test.reason || e.Pattern != test.pattern {
+			t.Errorf("expected Translate(%q) to fail with %q at %d (%q) but got %+v", test.pattern, test.construct, test.offset, test.reason, e)
+		}
+	}
+
+	_, err := Translate(`(?<=a)b`)
+	if expected := `rubyregexp: lookbehind is not supported: "(?<=" at offset 0 in "(?<=a)b"`; err.Error() != expected {
+		t.Errorf("expected %q but got %q", expected, err.Error())
+	}
+}
+
+func Test_Compile(t *testing.T) {
+	re := MustCompile(`\A(?<key>\h+)\s*=\s*(?<value>.*)\z`)
+	if got := re.FindStringSubmatch("ff = on"); !reflect.DeepEqual(got, []string{"ff = on", "ff", "on"}) {
+		t.Errorf("expected named groups to match but got %q", got)
+	}
+	if re.Source() != `\A(?<key>\h+)\s*=\s*(?<value>.*)\z` {
+		t.Errorf("expected Source to return the Ruby pattern but got %q", re.Source())
+	}
+	if got := re.SubexpNames(); !reflect.DeepEqual(got, []string{"", "key", "value"}) {
+		t.Errorf("expected the group names to be kept but got %q", got)
+	}
+
+	tests := []struct {
+		pattern, str string
+		expected     bool
+	}{
+		{`^b`, "a\nb", true},
+		{`a$`, "a\nb", true},
+		{`\Ab`, "a\nb", false},
+		{`a\z`, "a\n", false},
+		{`a\Z`, "a\n", true},
+		{`a\Z`, "a", true},
+		{`a.b`, "a\nb", false},
+		{`(?m)a.b`, "a\nb", true},
+		{`\s`, "\v", true},
+		{`\p{Hiragana}+`, "ひらがな", true},
+		{`\A\p{Hiragana}+\z`, "カタカナ", false},
+		{`\A[[:alpha:]]+\z`, "Ünïcödé", true},
+		{`\A[[:upper:]]\z`, "É", true},
+		{`\A\R\z`, "\r\n", true},
+		{`(?x) a  b # comment
+		   c`, "abc", true},
+		{`(?x) a [ ] b`, "a b", true},
+		{`(?i:A)b`, "aB", false},
+	}
+	for _, test := range tests {
+		re, err := Compile(test.pattern)
+		if err != nil {
+			t.Errorf("expected Compile(%q) to succeed but got %v", test.pattern, err)
+			continue
+		}
+		if got := re.MatchString(test.str); got != test.expected {
+			t.Errorf("expected %q to match %q: %v but got %v", test.pattern, test.str, test.expected, got)
+		}
+	}
+
+	if _, err := Compile(`a{1001}`); err == nil {
+		t.Errorf("expected RE2 compile errors to be returned")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected MustCompile to panic")
+		}
+	}()
+	MustCompile(`(?<=a)`)
+}
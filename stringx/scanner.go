@@ -0,0 +1,395 @@
+package stringx
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// StringScanner provides lexical scanning operations on a string, in the
+// manner of Ruby's StringScanner. It keeps a scan pointer into the string;
+// the scanning methods match a pattern at (or, for the Until variants,
+// after) the pointer and advance it past the match.
+//
+// Patterns may be a string, which is matched literally, a *regexp.Regexp
+// or a *rubyregexp.Regexp. Regular expressions match against the rest of
+// the string, so ^ and \A match at the scan pointer.
+//
+//	s := NewStringScanner("3.14 + x")
+//	s.Scan(regexp.MustCompile(`\d+`))  // "3", true
+//	s.Scan(".")                        // ".", true
+//	s.Scan(`\d+`)                      // "", false (a literal string)
+//	s.Scan(regexp.MustCompile(`\d+`))  // "14", true
+//	s.Skip(regexp.MustCompile(`\s+`))  // 1
+//	s.Getch()                          // "+", true
+//	s.Rest()                           // " x"
+//
+// The positions reported by Pos, PreMatch and so on are byte offsets; use
+// CharPos for the position in characters.
+type StringScanner struct {
+	str     string
+	pos     int
+	prevPos int
+	// match is the last match, or nil if the last attempt failed.
+	match    *MatchData
+	anchored map[*regexp.Regexp]*regexp.Regexp
+}
+
+// NewStringScanner returns a StringScanner positioned at the start of str.
+func NewStringScanner(str string) *StringScanner {
+	return &StringScanner{str: str}
+}
+
+// Scan tries to match pattern at the scan pointer. On success it advances
+// the pointer past the match and returns the matched text and true.
+//
+//	s := NewStringScanner("test string")
+//	s.Scan(regexp.MustCompile(`\w+`))  // "test", true
+//	s.Scan(regexp.MustCompile(`\w+`))  // "", false
+//	s.Scan(regexp.MustCompile(`\s+`))  // " ", true
+func (s *StringScanner) Scan(pattern interface{}) (string, bool) {
+	return s.scanString(pattern, true, true)
+}
+
+// ScanUntil looks for pattern anywhere after the scan pointer. On success
+// it advances the pointer past the match and returns the text from the old
+// pointer up to and including the match, and true.
+//
+//	s := NewStringScanner("Fri Dec 12 1975 14:39")
+//	s.ScanUntil(regexp.MustCompile(`1`))  // "Fri Dec 1", true
+//	s.PreMatch()                          // "Fri Dec "
+//	s.ScanUntil("XYZ")                    // "", false
+func (s *StringScanner) ScanUntil(pattern interface{}) (string, bool) {
+	return s.scanString(pattern, false, true)
+}
+
+// Skip is like Scan, but returns the length of the match in bytes, or -1
+// if pattern doesn't match.
+func (s *StringScanner) Skip(pattern interface{}) int {
+	return s.scan(pattern, true, true)
+}
+
+// SkipUntil is like ScanUntil, but returns the number of bytes the
+// pointer advanced, or -1 if pattern doesn't match.
+func (s *StringScanner) SkipUntil(pattern interface{}) int {
+	return s.scan(pattern, false, true)
+}
+
+// Check is like Scan, but doesn't advance the scan pointer.
+func (s *StringScanner) Check(pattern interface{}) (string, bool) {
+	return s.scanString(pattern, true, false)
+}
+
+// CheckUntil is like ScanUntil, but doesn't advance the scan pointer.
+func (s *StringScanner) CheckUntil(pattern interface{}) (string, bool) {
+	return s.scanString(pattern, false, false)
+}
+
+// Match tries to match pattern at the scan pointer without advancing it,
+// returning the length of the match in bytes, or -1 if it doesn't match.
+// This is Ruby's match?.
+func (s *StringScanner) Match(pattern interface{}) int {
+	return s.scan(pattern, true, false)
+}
+
+// Exist looks for pattern anywhere after the scan pointer without
+// advancing it, returning the number of bytes up to and including the
+// match, or -1 if it doesn't match.
+func (s *StringScanner) Exist(pattern interface{}) int {
+	return s.scan(pattern, false, false)
+}
+
+// Getch scans one character, returning it and true, or "" and false at the
+// end of the string. An invalid UTF-8 byte is returned as a single
+// character.
+func (s *StringScanner) Getch() (string, bool) {
+	if s.pos >= len(s.str) {
+		s.match = nil
+		return "", false
+	}
+	_, size := utf8.DecodeRuneInString(s.str[s.pos:])
+	s.match = &MatchData{str: s.str, loc: []int{s.pos, s.pos + size}}
+	s.prevPos = s.pos
+	s.pos += size
+	return s.str[s.prevPos:s.pos], true
+}
+
+// Peek returns up to n bytes from the scan pointer without advancing it.
+func (s *StringScanner) Peek(n int) string {
+	if n < 0 {
+		n = 0
+	}
+	if s.pos+n > len(s.str) {
+		return s.str[s.pos:]
+	}
+	return s.str[s.pos : s.pos+n]
+}
+
+// Unscan sets the scan pointer back to where it was before the last
+// successful match. It returns an error if the last attempt failed or
+// nothing has been matched.
+//
+//	s := NewStringScanner("test string")
+//	s.Scan(regexp.MustCompile(`\w+`))  // "test", true
+//	s.Unscan()                         // nil
+//	s.Scan(regexp.MustCompile(`..`))   // "te", true
+func (s *StringScanner) Unscan() error {
+	if s.match == nil {
+		return fmt.Errorf("stringx: StringScanner: unscan error: not scanned yet")
+	}
+	s.pos = s.prevPos
+	s.match = nil
+	return nil
+}
+
+// Pos returns the byte offset of the scan pointer.
+func (s *StringScanner) Pos() int {
+	return s.pos
+}
+
+// SetPos moves the scan pointer to byte offset pos; a negative pos counts
+// back from the end of the string. It returns an error if pos is out of
+// range.
+func (s *StringScanner) SetPos(pos int) error {
+	if pos < 0 {
+		pos += len(s.str)
+	}
+	if pos < 0 || pos > len(s.str) {
+		return fmt.Errorf("stringx: StringScanner: index %d out of range", pos)
+	}
+	s.pos = pos
+	return nil
+}
+
+// CharPos returns the position of the scan pointer in characters.
+//
+//	s := NewStringScanner("abcädeföghi")
+//	s.ScanUntil("ö")
+//	s.Pos()      // 10
+//	s.CharPos()  // 8
+func (s *StringScanner) CharPos() int {
+	return utf8.RuneCountInString(s.str[:s.pos])
+}
+
+// EOS reports whether the scan pointer is at the end of the string.
+func (s *StringScanner) EOS() bool {
+	return s.pos >= len(s.str)
+}
+
+// BeginningOfLine reports whether the scan pointer is at the start of a
+// line.
+func (s *StringScanner) BeginningOfLine() bool {
+	return s.pos == 0 || s.str[s.pos-1] == '\n'
+}
+
+// Rest returns the part of the string after the scan pointer.
+func (s *StringScanner) Rest() string {
+	return s.str[s.pos:]
+}
+
+// RestSize returns the length in bytes of Rest.
+func (s *StringScanner) RestSize() int {
+	return len(s.str) - s.pos
+}
+
+// String returns the string being scanned.
+func (s *StringScanner) String() string {
+	return s.str
+}
+
+// SetString replaces the string being scanned and resets the scanner.
+func (s *StringScanner) SetString(str string) {
+	s.str = str
+	s.Reset()
+}
+
+// Concat appends str to the string being scanned, leaving the scan
+// pointer where it is.
+func (s *StringScanner) Concat(str string) {
+	s.str += str
+}
+
+// Reset moves the scan pointer to the start of the string and clears the
+// match data.
+func (s *StringScanner) Reset() {
+	s.pos = 0
+	s.match = nil
+}
+
+// Terminate moves the scan pointer to the end of the string and clears
+// the match data.
+func (s *StringScanner) Terminate() {
+	s.pos = len(s.str)
+	s.match = nil
+}
+
+// Matched returns the text of the last match and true, or "" and false if
+// the last attempt failed.
+func (s *StringScanner) Matched() (string, bool) {
+	return s.Group(0)
+}
+
+// MatchedSize returns the length in bytes of the last match, or -1 if the
+// last attempt failed.
+func (s *StringScanner) MatchedSize() int {
+	if s.match == nil {
+		return -1
+	}
+	return s.match.End(0) - s.match.Begin(0)
+}
+
+// PreMatch returns the part of the string before the last match, from the
+// very start of the string (not the scan pointer).
+//
+//	s := NewStringScanner("a test string")
+//	s.Scan(regexp.MustCompile(`\w`))   // "a", true
+//	s.Scan(regexp.MustCompile(`\s+`))  // " ", true
+//	s.Scan(regexp.MustCompile(`te`))   // "te", true
+//	s.PreMatch()                       // "a "
+//	s.PostMatch()                      // "st string"
+func (s *StringScanner) PreMatch() string {
+	if s.match == nil {
+		return ""
+	}
+	return s.match.PreMatch()
+}
+
+// PostMatch returns the part of the string after the last match.
+func (s *StringScanner) PostMatch() string {
+	if s.match == nil {
+		return ""
+	}
+	return s.match.PostMatch()
+}
+
+// Group returns capture group i of the last match, where group 0 is the
+// whole match. It returns "" and false if the last attempt failed, i is
+// out of range, or the group didn't participate in the match.
+//
+//	s := NewStringScanner("Fri Dec 12 1975 14:39")
+//	s.Scan(regexp.MustCompile(`(\w+) (\w+) (\d+) `))
+//	s.Group(0)  // "Fri Dec 12 ", true
+//	s.Group(2)  // "Dec", true
+//	s.Group(4)  // "", false
+func (s *StringScanner) Group(i int) (string, bool) {
+	if s.match == nil {
+		return "", false
+	}
+	return s.match.Group(i)
+}
+
+// Named returns the named capture group of the last match, or "" and false
+// if there is no such group or it didn't participate in the match.
+//
+//	s := NewStringScanner("Fri Dec 12 1975 14:39")
+//	s.Scan(regexp.MustCompile(`(?P<wday>\w+) (?P<month>\w+) (?P<day>\d+) `))
+//	s.Named("month")  // "Dec", true
+func (s *StringScanner) Named(name string) (string, bool) {
+	if s.match == nil {
+		return "", false
+	}
+	return s.match.Named(name)
+}
+
+// Size returns the number of groups in the last match, including the
+// whole match, or 0 if the last attempt failed.
+func (s *StringScanner) Size() int {
+	if s.match == nil {
+		return 0
+	}
+	return s.match.Size()
+}
+
+// Captures returns the capture groups of the last match, excluding the
+// whole match, or nil if the last attempt failed. Groups that didn't
+// participate in the match are "".
+func (s *StringScanner) Captures() []string {
+	if s.match == nil {
+		return nil
+	}
+	return s.match.Captures()
+}
+
+// NamedCaptures returns the named capture groups of the last match, or an
+// empty map if the last attempt failed.
+func (s *StringScanner) NamedCaptures() map[string]string {
+	if s.match == nil {
+		return make(map[string]string)
+	}
+	return s.match.NamedCaptures()
+}
+
+// scan matches pattern at or after the scan pointer, records the match
+// data, advances the pointer if requested, and returns the number of bytes
+// from the pointer to the end of the match, or -1.
+func (s *StringScanner) scan(pattern interface{}, anchored, advance bool) int {
+	rest := s.str[s.pos:]
+	var (
+		loc   []int
+		names []string
+	)
+	switch p := unwrapPattern(pattern).(type) {
+	case string:
+		i := 0
+		if anchored {
+			if !strings.HasPrefix(rest, p) {
+				i = -1
+			}
+		} else {
+			i = strings.Index(rest, p)
+		}
+		if i >= 0 {
+			loc = []int{i, i + len(p)}
+		}
+	case *regexp.Regexp:
+		re := p
+		if anchored {
+			re = s.anchoredRegexp(p)
+		}
+		loc = re.FindStringSubmatchIndex(rest)
+		names = p.SubexpNames()
+	default:
+		panic(fmt.Sprintf("stringx: StringScanner: pattern must be a string or regular expression, not %T", pattern))
+	}
+	if loc == nil {
+		s.match = nil
+		return -1
+	}
+
+	for i := range loc {
+		if loc[i] >= 0 {
+			loc[i] += s.pos
+		}
+	}
+	s.match = &MatchData{str: s.str, loc: loc, names: names}
+	n := loc[1] - s.pos
+	s.prevPos = s.pos
+	if advance {
+		s.pos = loc[1]
+	}
+	return n
+}
+
+func (s *StringScanner) scanString(pattern interface{}, anchored, advance bool) (string, bool) {
+	start := s.pos
+	n := s.scan(pattern, anchored, advance)
+	if n < 0 {
+		return "", false
+	}
+	return s.str[start : start+n], true
+}
+
+// anchoredRegexp returns a copy of re that only matches at the start of
+// the text, caching it for later scans.
+func (s *StringScanner) anchoredRegexp(re *regexp.Regexp) *regexp.Regexp {
+	if a, ok := s.anchored[re]; ok {
+		return a
+	}
+	if s.anchored == nil {
+		s.anchored = make(map[*regexp.Regexp]*regexp.Regexp)
+	}
+	a := regexp.MustCompile(`^(?:` + re.String() + `)`)
+	s.anchored[re] = a
+	return a
+}
@@ -0,0 +1,226 @@
+package stringx
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func Test_StringScanner(t *testing.T) {
+	word := regexp.MustCompile(`\w+`)
+	space := regexp.MustCompile(`\s+`)
+
+	s := NewStringScanner("This is an example string")
+	if s.EOS() {
+		t.Errorf("expected a new scanner not to be at the end")
+	}
+	if got, ok := s.Scan(word); !ok || got != "This" {
+		t.Errorf("expected Scan to return \"This\" but got %q, %v", got, ok)
+	}
+	if got, ok := s.Scan(word); ok {
+		t.Errorf("expected Scan to fail but got %q", got)
+	}
+	if got, ok := s.Matched(); ok {
+		t.Errorf("expected no match data after a failed scan but got %q", got)
+	}
+	if got, ok := s.Scan(space); !ok || got != " " {
+		t.Errorf("expected Scan to return \" \" but got %q, %v", got, ok)
+	}
+	for _, expected := range []string{"is", " ", "an", " ", "example", " ", "string"} {
+		got, ok := s.Scan(regexp.MustCompile(`\w+|\s+`))
+		if !ok || got != expected {
+			t.Errorf("expected Scan to return %q but got %q, %v", expected, got, ok)
+		}
+	}
+	if !s.EOS() {
+		t.Errorf("expected the scanner to be at the end")
+	}
+	if got, ok := s.Scan(regexp.MustCompile(`x*`)); !ok || got != "" {
+		t.Errorf("expected an empty match at the end but got %q, %v", got, ok)
+	}
+
+	s = NewStringScanner("3.14 + x")
+	if got, _ := s.Scan(regexp.MustCompile(`\d+`)); got != "3" {
+		t.Errorf("expected \"3\" but got %q", got)
+	}
+	if got, ok := s.Scan("."); !ok || got != "." {
+		t.Errorf("expected a literal \".\" but got %q, %v", got, ok)
+	}
+	if _, ok := s.Scan(`\d+`); ok {
+		t.Errorf("expected a string pattern to be literal")
+	}
+	if got := s.Skip(regexp.MustCompile(`\d+`)); got != 2 {
+		t.Errorf("expected Skip to return 2 but got %d", got)
+	}
+	if got := s.Skip("x"); got != -1 {
+		t.Errorf("expected Skip to return -1 but got %d", got)
+	}
+	if got := s.Match(space); got != 1 || s.Pos() != 4 {
+		t.Errorf("expected Match to return 1 without advancing but got %d at %d", got, s.Pos())
+	}
+	if got, ok := s.Check(space); !ok || got != " " || s.Pos() != 4 {
+		t.Errorf("expected Check to return \" \" without advancing but got %q at %d", got, s.Pos())
+	}
+	if got, ok := s.Getch(); !ok || got != " " {
+		t.Errorf("expected Getch to return \" \" but got %q", got)
+	}
+	if got := s.Peek(3); got != "+ x" {
+		t.Errorf("expected Peek(3) to return \"+ x\" but got %q", got)
+	}
+	if got := s.Peek(10); got != "+ x" {
+		t.Errorf("expected Peek(10) to return \"+ x\" but got %q", got)
+	}
+	if got := s.Rest(); got != "+ x" || s.RestSize() != 3 {
+		t.Errorf("expected Rest to return \"+ x\" but got %q", got)
+	}
+}
+
+func Test_StringScannerUntil(t *testing.T) {
+	s := NewStringScanner("Fri Dec 12 1975 14:39")
+	if got := s.Exist(regexp.MustCompile(`s`)); got != -1 {
+		t.Errorf("expected Exist to return -1 but got %d", got)
+	}
+	if got := s.Exist(regexp.MustCompile(`D`)); got != 5 || s.Pos() != 0 {
+		t.Errorf("expected Exist to return 5 without advancing but got %d", got)
+	}
+	if got, ok := s.CheckUntil(regexp.MustCompile(`12`)); !ok || got != "Fri Dec 12" || s.Pos() != 0 {
+		t.Errorf("expected CheckUntil to return \"Fri Dec 12\" but got %q at %d", got, s.Pos())
+	}
+	if got, ok := s.ScanUntil(regexp.MustCompile(`1`)); !ok || got != "Fri Dec 1" {
+		t.Errorf("expected ScanUntil to return \"Fri Dec 1\" but got %q", got)
+	}
+	if got := s.PreMatch(); got != "Fri Dec " {
+		t.Errorf("expected PreMatch to return \"Fri Dec \" but got %q", got)
+	}
+	if got := s.PostMatch(); got != "2 1975 14:39" {
+		t.Errorf("expected PostMatch to return \"2 1975 14:39\" but got %q", got)
+	}
+	if got, ok := s.ScanUntil("XYZ"); ok {
+		t.Errorf("expected ScanUntil to fail but got %q", got)
+	}
+	if got := s.SkipUntil(" 14"); got != 9 || s.Rest() != ":39" {
+		t.Errorf("expected SkipUntil to return 9 but got %d leaving %q", got, s.Rest())
+	}
+	if got, _ := s.Matched(); got != " 14" || s.MatchedSize() != 3 {
+		t.Errorf("expected Matched to return \" 14\" but got %q", got)
+	}
+}
+
+func Test_StringScannerGroups(t *testing.T) {
+	s := NewStringScanner("Fri Dec 12 1975 14:39")
+	re := regexp.MustCompile(`(?P<wday>\w+) (?P<month>\w+) (?P<day>\d+) (x)?`)
+	if _, ok := s.Scan(re); !ok {
+		t.Fatalf("expected Scan to match")
+	}
+	if got, ok := s.Group(0); !ok || got != "Fri Dec 12 " {
+		t.Errorf("expected Group(0) to return \"Fri Dec 12 \" but got %q", got)
+	}
+	if got, ok := s.Group(2); !ok || got != "Dec" {
+		t.Errorf("expected Group(2) to return \"Dec\" but got %q", got)
+	}
+	if got, ok := s.Group(4); ok {
+		t.Errorf("expected a non-participating group to fail but got %q", got)
+	}
+	if got, ok := s.Group(5); ok {
+		t.Errorf("expected an out of range group to fail but got %q", got)
+	}
+	if got, ok := s.Named("month"); !ok || got != "Dec" {
+		t.Errorf("expected Named(\"month\") to return \"Dec\" but got %q", got)
+	}
+	if got, ok := s.Named("year"); ok {
+		t.Errorf("expected an unknown name to fail but got %q", got)
+	}
+	if got := s.Size(); got != 5 {
+		t.Errorf("expected Size to return 5 but got %d", got)
+	}
+	if got := s.Captures(); !reflect.DeepEqual(got, []string{"Fri", "Dec", "12", ""}) {
+		t.Errorf("expected Captures to return [Fri Dec 12 ] but got %q", got)
+	}
+	expected := map[string]string{"wday": "Fri", "month": "Dec", "day": "12"}
+	if got := s.NamedCaptures(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected NamedCaptures to return %v but got %v", expected, got)
+	}
+
+	if _, ok := s.Scan(re); ok {
+		t.Errorf("expected the second Scan to fail")
+	}
+	if s.Captures() != nil || len(s.NamedCaptures()) != 0 || s.Size() != 0 || s.MatchedSize() != -1 {
+		t.Errorf("expected the match data to be cleared")
+	}
+}
+
+func Test_StringScannerAnchoring(t *testing.T) {
+	s := NewStringScanner("ab\ncd")
+	s.SetPos(1)
+	if got, ok := s.Scan(regexp.MustCompile(`^b`)); !ok || got != "b" {
+		t.Errorf("expected ^ to match at the scan pointer but got %q, %v", got, ok)
+	}
+	if _, ok := s.Scan(regexp.MustCompile(`(?m)^cd`)); ok {
+		t.Errorf("expected Scan to only match at the scan pointer")
+	}
+	if got := s.SkipUntil(regexp.MustCompile(`(?m)^`)); got != 0 {
+		t.Errorf("expected ^ to match at the scan pointer but got %d", got)
+	}
+	if got := s.SkipUntil("\n"); got != 1 || !s.BeginningOfLine() {
+		t.Errorf("expected SkipUntil to reach the next line but got %d", got)
+	}
+	if got, ok := s.Scan(regexp.MustCompile(`c|cd`)); !ok || got != "c" {
+		t.Errorf("expected alternation to keep leftmost-first semantics but got %q", got)
+	}
+}
+
+func Test_StringScannerPosition(t *testing.T) {
+	s := NewStringScanner("abcädeföghi")
+	s.ScanUntil("ö")
+	if s.Pos() != 10 || s.CharPos() != 8 {
+		t.Errorf("expected Pos 10 and CharPos 8 but got %d and %d", s.Pos(), s.CharPos())
+	}
+	if err := s.Unscan(); err != nil || s.Pos() != 0 {
+		t.Errorf("expected Unscan to return to 0 but got %d (%v)", s.Pos(), err)
+	}
+	if err := s.Unscan(); err == nil {
+		t.Errorf("expected a second Unscan to fail")
+	}
+	if got, ok := s.Getch(); !ok || got != "a" {
+		t.Errorf("expected Getch to return \"a\" but got %q", got)
+	}
+	s.SetPos(3)
+	if got, ok := s.Getch(); !ok || got != "ä" {
+		t.Errorf("expected Getch to return \"ä\" but got %q", got)
+	}
+	if err := s.SetPos(-2); err != nil || s.Rest() != "hi" {
+		t.Errorf("expected SetPos(-2) to leave \"hi\" but got %q (%v)", s.Rest(), err)
+	}
+	if err := s.SetPos(100); err == nil {
+		t.Errorf("expected SetPos(100) to fail")
+	}
+	s.Terminate()
+	if !s.EOS() {
+		t.Errorf("expected Terminate to move to the end")
+	}
+	if got, ok := s.Getch(); ok {
+		t.Errorf("expected Getch at the end to fail but got %q", got)
+	}
+	s.Reset()
+	if s.Pos() != 0 {
+		t.Errorf("expected Reset to move to the start")
+	}
+
+	s.SetString("foo")
+	s.Scan("fo")
+	s.Concat(" bar")
+	if s.String() != "foo bar" || s.Rest() != "o bar" {
+		t.Errorf("expected Concat to extend the string but got %q", s.Rest())
+	}
+
+	s = NewStringScanner("abc")
+	s.Scan("a")
+	s.Check("b")
+	if err := s.Unscan(); err != nil || s.Pos() != 1 {
+		t.Errorf("expected Unscan after Check to stay at 1 but got %d (%v)", s.Pos(), err)
+	}
+	s.Match("b")
+	if err := s.Unscan(); err != nil || s.Pos() != 1 {
+		t.Errorf("expected Unscan after Match to stay at 1 but got %d (%v)", s.Pos(), err)
+	}
+}
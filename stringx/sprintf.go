@@ -0,0 +1,656 @@
+package stringx
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Sprintf formats args according to format the way Ruby's Kernel#sprintf
+// (and String#%) does, returning an error where Ruby would raise an
+// ArgumentError.
+//
+// A format specification has the form %[flags][width][.precision]type,
+// where flags are any of space, #, +, -, 0, and an n$ position or
+// <name> reference. Width and precision may be given as * to take them
+// from the argument list. The types are:
+//
+//	b B     binary integer
+//	c       character, from an integer code point or the first rune of a string
+//	d i u   decimal integer
+//	o       octal integer
+//	x X     hexadecimal integer
+//	e E     float in exponential notation
+//	f       float in decimal notation
+//	g G     float in the shorter of e and f notation
+//	a A     float in hexadecimal notation
+//	s       the argument as a string (like Ruby's to_s)
+//	p       the argument inspected (like Ruby's inspect)
+//	%       a literal percent sign
+//
+// Named references %<name>s and %{name} take their values from a single
+// map[string]interface{} (or map[string]string) argument; %{name} is
+// always substituted as a string.
+//
+// As in Ruby, negative numbers given to b, o and x without a + or space
+// flag are shown in two's-complement notation, prefixed with "..".
+//
+//	Sprintf("%05.1f|%-4s|%+d", 3.14159, "ab", 5)  // "003.1|ab  |+5"
+//	Sprintf("%x %#b %o", -255, 5, -8)             // "..f01 0b101 ..70"
+//	Sprintf("%<n>03d: %{s}", map[string]interface{}{"n": 7, "s": "ok"})
+//	  // "007: ok"
+//	Sprintf("%c%c %p", 82, "uby", "str")          // "Ru \"str\""
+func Sprintf(format string, args ...interface{}) (string, error) {
+	f := &formatter{args: args}
+	var sb strings.Builder
+	for i := 0; i < len(format); {
+		j := strings.IndexByte(format[i:], '%')
+		if j < 0 {
+			sb.WriteString(format[i:])
+			break
+		}
+		sb.WriteString(format[i : i+j])
+		i += j + 1
+		n, err := f.directive(&sb, format[i:])
+		if err != nil {
+			return "", err
+		}
+		i += n
+	}
+	return sb.String(), nil
+}
+
+// formatSpec holds a parsed format specification.
+type formatSpec struct {
+	minus, plus, space, sharp, zero bool
+	width, prec                     int
+	hasWidth, hasPrec               bool
+}
+
+// formatter tracks how Sprintf has consumed its arguments. Like Ruby, it
+// refuses to mix unnumbered, numbered and named references.
+type formatter struct {
+	args     []interface{}
+	next     int
+	numbered bool
+	named    bool
+}
+
+func (f *formatter) nextArg() (interface{}, error) {
+	if f.numbered {
+		return nil, fmt.Errorf("stringx: unnumbered(%d) mixed with numbered", f.next+1)
+	}
+	if f.named {
+		return nil, fmt.Errorf("stringx: unnumbered(%d) mixed with named", f.next+1)
+	}
+	if f.next >= len(f.args) {
+		return nil, fmt.Errorf("stringx: too few arguments")
+	}
+	f.next++
+	return f.args[f.next-1], nil
+}
+
+func (f *formatter) posArg(n int) (interface{}, error) {
+	if f.next > 0 {
+		return nil, fmt.Errorf("stringx: numbered(%d) after unnumbered(%d)", n, f.next)
+	}
+	if f.named {
+		return nil, fmt.Errorf("stringx: numbered(%d) after named", n)
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("stringx: invalid index - %d$", n)
+	}
+	if n > len(f.args) {
+		return nil, fmt.Errorf("stringx: too few arguments")
+	}
+	f.numbered = true
+	return f.args[n-1], nil
+}
+
+func (f *formatter) namedArg(name string) (interface{}, error) {
+	if f.next > 0 {
+		return nil, fmt.Errorf("stringx: named<%s> after unnumbered(%d)", name, f.next)
+	}
+	if f.numbered {
+		return nil, fmt.Errorf("stringx: named<%s> after numbered", name)
+	}
+	if len(f.args) != 1 {
+		return nil, fmt.Errorf("stringx: one hash required")
+	}
+	f.named = true
+	switch m := f.args[0].(type) {
+	case map[string]interface{}:
+		if v, ok := m[name]; ok {
+			return v, nil
+		}
+	case map[string]string:
+		if v, ok := m[name]; ok {
+			return v, nil
+		}
+	default:
+		return nil, fmt.Errorf("stringx: one hash required")
+	}
+	return nil, fmt.Errorf("stringx: key<%s> not found", name)
+}
+
+// directive formats the specification at the start of s, which follows a
+// '%', and returns the number of bytes consumed.
+func (f *formatter) directive(sb *strings.Builder, s string) (int, error) {
+	var spec formatSpec
+	var arg interface{}
+	hasArg := false
+	setArg := func(v interface{}) error {
+		if hasArg {
+			return fmt.Errorf("stringx: value given twice - %%%s", s)
+		}
+		arg, hasArg = v, true
+		return nil
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == ' ':
+			spec.space = true
+		case c == '#':
+			spec.sharp = true
+		case c == '+':
+			spec.plus = true
+		case c == '-':
+			spec.minus = true
+		case c == '0':
+			spec.zero = true
+		case c >= '1' && c <= '9':
+			n, l := parseDigits(s[i:])
+			i += l - 1
+			if i+1 < len(s) && s[i+1] == '$' {
+				v, err := f.posArg(n)
+				if err != nil {
+					return 0, err
+				}
+				if err := setArg(v); err != nil {
+					return 0, err
+				}
+				i++
+				continue
+			}
+			if spec.hasWidth {
+				return 0, fmt.Errorf("stringx: width given twice")
+			}
+			spec.width, spec.hasWidth = n, true
+		case c == '<' || c == '{':
+			term := byte('>')
+			if c == '{' {
+				term = '}'
+			}
+			end := strings.IndexByte(s[i:], term)
+			if end < 0 {
+				return 0, fmt.Errorf("stringx: malformed name - unmatched parenthesis")
+			}
+			v, err := f.namedArg(s[i+1 : i+end])
+			if err != nil {
+				return 0, err
+			}
+			if err := setArg(v); err != nil {
+				return 0, err
+			}
+			i += end
+			if c == '{' {
+				sb.WriteString(formatString(&spec, toS(arg)))
+				return i + 1, nil
+			}
+		case c == '*':
+			if spec.hasWidth {
+				return 0, fmt.Errorf("stringx: width given twice")
+			}
+			n, l, err := f.starArg(s[i+1:])
+			if err != nil {
+				return 0, err
+			}
+			i += l
+			if n < 0 {
+				spec.minus, n = true, -n
+			}
+			spec.width, spec.hasWidth = n, true
+		case c == '.':
+			if spec.hasPrec {
+				return 0, fmt.Errorf("stringx: precision given twice")
+			}
+			spec.hasPrec = true
+			if i+1 < len(s) && s[i+1] == '*' {
+				n, l, err := f.starArg(s[i+2:])
+				if err != nil {
+					return 0, err
+				}
+				i += l + 1
+				if n < 0 {
+					spec.hasPrec = false
+				}
+				spec.prec = n
+				continue
+			}
+			n, l := parseDigits(s[i+1:])
+			spec.prec = n
+			i += l
+		case c == '%':
+			if spec != (formatSpec{}) || hasArg {
+				return 0, fmt.Errorf("stringx: invalid format character - %%")
+			}
+			sb.WriteByte('%')
+			return i + 1, nil
+		default:
+			if !strings.ContainsRune("bBcdiouxXeEfgGaAsp", rune(c)) {
+				return 0, fmt.Errorf("stringx: malformed format string - %%%c", c)
+			}
+			if !hasArg {
+				v, err := f.nextArg()
+				if err != nil {
+					return 0, err
+				}
+				arg = v
+			}
+			out, err := formatValue(&spec, c, arg)
+			if err != nil {
+				return 0, err
+			}
+			sb.WriteString(out)
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("stringx: incomplete format specifier; use %%%% (double %%) instead")
+}
+
+// starArg reads a * width or precision, which is either the next argument
+// or an n$ positional one, returning the value and the bytes consumed
+// after the '*'.
+func (f *formatter) starArg(s string) (int, int, error) {
+	var v interface{}
+	var err error
+	l := 0
+	if n, dl := parseDigits(s); dl > 0 && dl < len(s) && s[dl] == '$' {
+		v, err = f.posArg(n)
+		l = dl + 1
+	} else {
+		v, err = f.nextArg()
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	n, err := toInteger(v)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !n.IsInt64() || n.Int64() > math.MaxInt32 || n.Int64() < -math.MaxInt32 {
+		return 0, 0, fmt.Errorf("stringx: width too big")
+	}
+	return int(n.Int64()), l, nil
+}
+
+func parseDigits(s string) (int, int) {
+	n, i := 0, 0
+	for ; i < len(s) && s[i] >= '0' && s[i] <= '9'; i++ {
+		if n < math.MaxInt32/10 {
+			n = n*10 + int(s[i]-'0')
+		}
+	}
+	return n, i
+}
+
+func formatValue(spec *formatSpec, verb byte, arg interface{}) (string, error) {
+	switch verb {
+	case 's':
+		return formatString(spec, toS(arg)), nil
+	case 'p':
+		return formatString(spec, inspect(arg)), nil
+	case 'c':
+		var r rune
+		if s, ok := arg.(string); ok {
+			if s == "" {
+				return "", fmt.Errorf("stringx: %%c requires a character")
+			}
+			r, _ = utf8.DecodeRuneInString(s)
+		} else {
+			n, err := toInteger(arg)
+			if err != nil {
+				return "", err
+			}
+			if !n.IsInt64() || n.Int64() < 0 || n.Int64() > utf8.MaxRune {
+				return "", fmt.Errorf("stringx: %s out of char range", n)
+			}
+			r = rune(n.Int64())
+		}
+		return pad(spec, string(r), false), nil
+	case 'd', 'i', 'u', 'b', 'B', 'o', 'x', 'X':
+		n, err := toInteger(arg)
+		if err != nil {
+			return "", err
+		}
+		return formatInteger(spec, verb, n), nil
+	}
+	x, err := toFloat(arg)
+	if err != nil {
+		return "", err
+	}
+	return formatFloat(spec, verb, x), nil
+}
+
+// formatString applies width and precision, both counted in runes, to s.
+func formatString(spec *formatSpec, s string) string {
+	if spec.hasPrec && utf8.RuneCountInString(s) > spec.prec {
+		s = string([]rune(s)[:spec.prec])
+	}
+	return pad(spec, s, false)
+}
+
+// pad justifies s within the spec's width, using zeros after any sign or
+// radix prefix when zero is set.
+func pad(spec *formatSpec, s string, zero bool) string {
+	n := spec.width - utf8.RuneCountInString(s)
+	if n <= 0 {
+		return s
+	}
+	if spec.minus {
+		return s + strings.Repeat(" ", n)
+	}
+	if !zero {
+		return strings.Repeat(" ", n) + s
+	}
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-' || s[i] == ' ') {
+		i++
+	}
+	if i+1 < len(s) && s[i] == '0' && strings.IndexByte("xXbB", s[i+1]) >= 0 {
+		i += 2
+	}
+	return s[:i] + strings.Repeat("0", n) + s[i:]
+}
+
+func formatInteger(spec *formatSpec, verb byte, n *big.Int) string {
+	base := 10
+	prefix := ""
+	switch verb {
+	case 'b', 'B':
+		base, prefix = 2, "0"+string(verb)
+	case 'o':
+		base = 8
+	case 'x', 'X':
+		base, prefix = 16, "0"+string(verb)
+	}
+	if !spec.sharp || n.Sign() == 0 {
+		prefix = ""
+	}
+
+	sign := ""
+	if n.Sign() < 0 && base != 10 && !spec.plus && !spec.space {
+		// Ruby shows the digits of the infinite two's-complement form,
+		// keeping a single leading sign digit.
+		digits := twosComplement(n, base)
+		if verb == 'X' {
+			digits = strings.ToUpper(digits)
+		}
+		fill := digits[:1]
+		width := 0
+		if spec.hasPrec {
+			width = spec.prec - 2
+		} else if spec.zero && !spec.minus && spec.hasWidth {
+			width = spec.width - 2 - len(prefix)
+		}
+		if l := len(digits); width > l {
+			digits = strings.Repeat(fill, width-l) + digits
+		}
+		return pad(spec, prefix+".."+digits, false)
+	}
+
+	switch {
+	case n.Sign() < 0:
+		sign = "-"
+	case spec.plus:
+		sign = "+"
+	case spec.space:
+		sign = " "
+	}
+	digits := new(big.Int).Abs(n).Text(base)
+	if verb == 'X' {
+		digits = strings.ToUpper(digits)
+	}
+	if spec.hasPrec && len(digits) < spec.prec {
+		digits = strings.Repeat("0", spec.prec-len(digits)) + digits
+	}
+	if verb == 'o' && spec.sharp && digits[0] != '0' {
+		digits = "0" + digits
+	}
+	return pad(spec, sign+prefix+digits, spec.zero && !spec.hasPrec)
+}
+
+// twosComplement returns the digits of negative n in the given power of
+// two base, without redundant leading sign digits.
+func twosComplement(n *big.Int, base int) string {
+	l := len(new(big.Int).Abs(n).Text(base)) + 1
+	limit := new(big.Int).Exp(big.NewInt(int64(base)), big.NewInt(int64(l)), nil)
+	digits := limit.Add(limit, n).Text(base)
+	sd := strconv.FormatInt(int64(base-1), base)[0]
+	i := 0
+	for i+1 < len(digits) && digits[i] == sd && digits[i+1] == sd {
+		i++
+	}
+	return digits[i:]
+}
+
+func formatFloat(spec *formatSpec, verb byte, x float64) string {
+	if math.IsInf(x, 0) || math.IsNaN(x) {
+		s := "Inf"
+		if math.IsNaN(x) {
+			s = "NaN"
+		}
+		switch {
+		case math.IsInf(x, -1):
+			s = "-" + s
+		case spec.plus:
+			s = "+" + s
+		case spec.space:
+			s = " " + s
+		}
+		return pad(spec, s, false)
+	}
+
+	var flags strings.Builder
+	flags.WriteByte('%')
+	if spec.plus {
+		flags.WriteByte('+')
+	} else if spec.space {
+		flags.WriteByte(' ')
+	}
+	if spec.sharp {
+		flags.WriteByte('#')
+	}
+	prec := spec.prec
+	if !spec.hasPrec {
+		prec = 6
+	}
+	switch verb {
+	case 'a', 'A':
+		var s string
+		if spec.hasPrec {
+			s = fmt.Sprintf(flags.String()+".*x", prec, x)
+		} else {
+			s = fmt.Sprintf(flags.String()+"x", x)
+		}
+		// Go always writes at least two exponent digits; C and Ruby don't.
+		if i := strings.LastIndexByte(s, 'p'); i >= 0 && s[i+2] == '0' && len(s) > i+3 {
+			s = s[:i+2] + s[i+3:]
+		}
+		if verb == 'A' {
+			s = strings.ToUpper(s)
+		}
+		return pad(spec, s, spec.zero)
+	}
+	s := fmt.Sprintf(flags.String()+".*"+string(verb), prec, x)
+	return pad(spec, s, spec.zero)
+}
+
+func toInteger(v interface{}) (*big.Int, error) {
+	switch n := v.(type) {
+	case int:
+		return big.NewInt(int64(n)), nil
+	case int8:
+		return big.NewInt(int64(n)), nil
+	case int16:
+		return big.NewInt(int64(n)), nil
+	case int32:
+		return big.NewInt(int64(n)), nil
+	case int64:
+		return big.NewInt(n), nil
+	case uint:
+		return new(big.Int).SetUint64(uint64(n)), nil
+	case uint8:
+		return big.NewInt(int64(n)), nil
+	case uint16:
+		return big.NewInt(int64(n)), nil
+	case uint32:
+		return big.NewInt(int64(n)), nil
+	case uint64:
+		return new(big.Int).SetUint64(n), nil
+	case uintptr:
+		return new(big.Int).SetUint64(uint64(n)), nil
+	case float32:
+		return toInteger(float64(n))
+	case float64:
+		if math.IsInf(n, 0) || math.IsNaN(n) {
+			return nil, fmt.Errorf("stringx: %v can't be converted to Integer", n)
+		}
+		i, _ := big.NewFloat(math.Trunc(n)).Int(nil)
+		return i, nil
+	case *big.Int:
+		return n, nil
+	case *big.Float:
+		i, _ := n.Int(nil)
+		return i, nil
+	case string:
+		if i, ok := new(big.Int).SetString(strings.TrimSpace(n), 0); ok {
+			return i, nil
+		}
+		return nil, fmt.Errorf("stringx: invalid value for Integer(): %q", n)
+	case nil:
+		return nil, fmt.Errorf("stringx: can't convert nil into Integer")
+	}
+	return nil, fmt.Errorf("stringx: can't convert %T into Integer", v)
+}
+
+func toFloat(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case float32:
+		return float64(n), nil
+	case *big.Float:
+		x, _ := n.Float64()
+		return x, nil
+	case string:
+		x, err := strconv.ParseFloat(strings.Replace(strings.TrimSpace(n), "_", "", -1), 64)
+		if err != nil {
+			return 0, fmt.Errorf("stringx: invalid value for Float(): %q", n)
+		}
+		return x, nil
+	case nil:
+		return 0, fmt.Errorf("stringx: can't convert nil into Float")
+	}
+	i, err := toInteger(v)
+	if err != nil {
+		return 0, fmt.Errorf("stringx: can't convert %T into Float", v)
+	}
+	x, _ := new(big.Float).SetInt(i).Float64()
+	return x, nil
+}
+
+// toS converts v to a string the way Ruby's to_s would.
+func toS(v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case float64:
+		return rubyFloat(s)
+	case float32:
+		return rubyFloat(float64(s))
+	case fmt.Stringer:
+		return s.String()
+	case error:
+		return s.Error()
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return inspect(v)
+	}
+	return fmt.Sprint(v)
+}
+
+// rubyFloat formats x the way Ruby's Float#to_s does, always showing a
+// fractional part and switching to exponent notation for very large and
+// very small magnitudes.
+func rubyFloat(x float64) string {
+	switch {
+	case math.IsNaN(x):
+		return "NaN"
+	case math.IsInf(x, 1):
+		return "Infinity"
+	case math.IsInf(x, -1):
+		return "-Infinity"
+	}
+	if a := math.Abs(x); a >= 1e16 || (a != 0 && a < 1e-4) {
+		s := strconv.FormatFloat(x, 'e', -1, 64)
+		if i := strings.IndexByte(s, 'e'); !strings.Contains(s[:i], ".") {
+			s = s[:i] + ".0" + s[i:]
+		}
+		return s
+	}
+	s := strconv.FormatFloat(x, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
+
+// inspect converts v to a string the way Ruby's inspect would.
+func inspect(v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return strconv.Quote(s)
+	case float64, float32, fmt.Stringer, error:
+		return toS(v)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return "nil"
+		}
+		parts := make([]string, rv.Len())
+		for i := range parts {
+			parts[i] = inspect(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case reflect.Map:
+		if rv.IsNil() {
+			return "nil"
+		}
+		parts := make([]string, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			parts = append(parts, inspect(k.Interface())+" => "+inspect(rv.MapIndex(k).Interface()))
+		}
+		sort.Strings(parts)
+		return "{" + strings.Join(parts, ", ") + "}"
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return "nil"
+		}
+	}
+	return fmt.Sprint(v)
+}
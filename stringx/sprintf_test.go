@@ -0,0 +1,95 @@
+package stringx
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func Test_Sprintf(t *testing.T) {
+	tests := []struct {
+		format   string
+		args     []interface{}
+		expected string
+	}{
+		{"%d %i %u %s", []interface{}{1, int8(-2), uint64(3), "four"}, "1 -2 3 four"},
+		{"%+d % d %05d %-5d|", []interface{}{5, 5, -42, 7}, "+5  5 -0042 7    |"},
+		{"%20.8d|%-+20d|% 020d", []interface{}{-123, 123, 123}, "           -00000123|+123                | 0000000000000000123"},
+		{"%d", []interface{}{3.99}, "3"},
+		{"%d %d", []interface{}{"0x1A", "0b11"}, "26 3"},
+		{"%d", []interface{}{new(big.Int).Lsh(big.NewInt(1), 70)}, "1180591620717411303424"},
+
+		{"%o %#o %+o", []interface{}{123, 123, -123}, "173 0173 -173"},
+		{"%x %#x %X %#X", []interface{}{123, 123, 123, 123}, "7b 0x7b 7B 0X7B"},
+		{"%b %#b %B %#B", []interface{}{123, 123, 5, 5}, "1111011 0b1111011 101 0B101"},
+		{"%#x %#b %#o", []interface{}{0, 0, 0}, "0 0 0"},
+		{"%#20.8x|%#20.8b|%#20.8o", []interface{}{123, 123, 123}, "          0x0000007b|          0b01111011|            00000173"},
+		{"%#010x", []interface{}{255}, "0x000000ff"},
+
+		{"%x %#x %+x % x", []interface{}{-255, -255, -255, -255}, "..f01 0x..f01 -ff -ff"},
+		{"%x %x %X", []interface{}{-1, -16, -123}, "..f ..f0 ..F85"},
+		{"%o %#o %b %#b", []interface{}{-123, -123, -123, -123}, "..7605 ..7605 ..10000101 0b..10000101"},
+		{"%020x", []interface{}{-123}, "..ffffffffffffffff85"},
+		{"%20.8o|%20.8x|%20.8b", []interface{}{-123, -123, -11}, "            ..777605|            ..ffff85|            ..110101"},
+		{"%#20.8x|%-8b|", []interface{}{-123, -11}, "          0x..ffff85|..10101 |"},
+
+		{"%f %.2f %8.3f %-8.1f|", []interface{}{1.5, 3.14159, -2.0, 2.25}, "1.500000 3.14   -2.000 2.2     |"},
+		{"%.0f %#.0f %+.1f %08.2f", []interface{}{1234.0, 1234.0, 1.0, -1.5}, "1234 1234. +1.0 -0001.50"},
+		{"%e %.0e %#.0e %E", []interface{}{12345.678, 1, 1, 0.000123}, "1.234568e+04 1e+00 1.e+00 1.230000E-04"},
+		{"%g %g %#g %#g %G", []interface{}{123.4, 1234567.0, 123.4, 123456, 1e-10}, "123.4 1.23457e+06 123.400 123456. 1E-10"},
+		{"%a %A %.2a %a", []interface{}{3.0, -0.5, 1.0, 1e300}, "0x1.8p+1 -0X1P-1 0x1.00p+0 0x1.7e43c8800759cp+996"},
+		{"%f %+f %5.1f %f", []interface{}{math.Inf(1), math.Inf(1), math.NaN(), math.Inf(-1)}, "Inf +Inf   NaN -Inf"},
+		{"%.1f", []interface{}{"2.25"}, "2.2"},
+
+		{"%s|%5s|%-5s|%.2s|%5.1s", []interface{}{"abc", "abc", "abc", "abc", "abc"}, "abc|  abc|abc  |ab|    a"},
+		{"%s %s %s %s", []interface{}{nil, 1.0, 1e20, []int{1, 2}}, " 1.0 1.0e+20 [1, 2]"},
+		{"%3s|%.1s", []interface{}{"é", "éa"}, "  é|é"},
+		{"%c%c %c %3c", []interface{}{82, "uby", 'é', "x"}, "Ru é   x"},
+		{"%p %p %p %p", []interface{}{"str", nil, []interface{}{"a", 1}, map[string]int{"k": 1}}, `"str" nil ["a", 1] {"k" => 1}`},
+
+		{"%*d|%-*d|%*d", []interface{}{5, 1, 5, 2, -5, 3}, "    1|2    |3    "},
+		{"%.*f", []interface{}{2, 3.14159}, "3.14"},
+		{"%2$s %1$s %2$s", []interface{}{"a", "b"}, "b a b"},
+		{"%1$*2$d", []interface{}{1, 4}, "   1"},
+		{"100%% %s", []interface{}{"done"}, "100% done"},
+
+		{"%<n>03d: %<f>.1f", []interface{}{map[string]interface{}{"n": 7, "f": 2.25}}, "007: 2.2"},
+		{"%{a}-%{b}|%-4{a}|%05<b>s", []interface{}{map[string]string{"a": "x", "b": "y"}}, "x-y|x   |    y"},
+		{"%08<n>.3f", []interface{}{map[string]interface{}{"n": math.Pi}}, "0003.142"},
+	}
+	for _, test := range tests {
+		got, err := Sprintf(test.format, test.args...)
+		if err != nil || got != test.expected {
+			t.Errorf("expected Sprintf(%q) to give %q but got %q (%v)", test.format, test.expected, got, err)
+		}
+	}
+
+	errs := []struct {
+		format string
+		args   []interface{}
+	}{
+		{"%d", nil},
+		{"%d %d", []interface{}{1}},
+		{"%", []interface{}{1}},
+		{"%5", []interface{}{1}},
+		{"%y", []interface{}{1}},
+		{"%d", []interface{}{"abc"}},
+		{"%d", []interface{}{nil}},
+		{"%f", []interface{}{true}},
+		{"%c", []interface{}{""}},
+		{"%c", []interface{}{-1}},
+		{"%<a>s", []interface{}{"not a map"}},
+		{"%<a>s", []interface{}{map[string]interface{}{"b": 1}}},
+		{"%<a", []interface{}{map[string]interface{}{"a": 1}}},
+		{"%s %<a>s", []interface{}{map[string]interface{}{"a": 1}}},
+		{"%1$s %s", []interface{}{"a", "b"}},
+		{"%s %1$s", []interface{}{"a", "b"}},
+		{"%3$s", []interface{}{"a", "b"}},
+		{"%<a>1$s", []interface{}{map[string]interface{}{"a": 1}}},
+	}
+	for _, test := range errs {
+		if got, err := Sprintf(test.format, test.args...); err == nil {
+			t.Errorf("expected Sprintf(%q, %v) to fail but got %q", test.format, test.args, got)
+		}
+	}
+}
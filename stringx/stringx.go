@@ -25,29 +25,36 @@ const (
 
 // Compares self and other string, ignoring case, and returns
 // -1 if other string is larger, 0 if the two are equal, or
-// - 1 if other string is smaller.
+// - 1 if other string is smaller. As in Ruby, only ASCII letters are
+// folded and the strings are otherwise compared byte by byte; use
+// CaseCmpFold for Unicode case-insensitive equality.
 //
 //	CaseCmp("foo", "foo")        // 0
 //	CaseCmp("foo", "food")       // -1
 //	CaseCmp("food", "foo")       // 1
 //	CaseCmp("FOO", "foo")        // 0
 //	CaseCmp("foo", "FOO")        // 0
+//	CaseCmp("abc", "B")          // -1
 func CaseCmp(str, other string) int {
+	for i := 0; i < len(str) && i < len(other); i++ {
+		a, b := str[i], other[i]
+		if a >= 'A' && a <= 'Z' {
+			a += 'a' - 'A'
+		}
+		if b >= 'A' && b <= 'Z' {
+			b += 'a' - 'A'
+		}
+		if a > b {
+			return 1
+		} else if a < b {
+			return -1
+		}
+	}
+
 	if len(str) > len(other) {
 		return 1
 	} else if len(str) < len(other) {
 		return -1
-	} else if strings.EqualFold(str, other) {
-		return 0
-	}
-
-	i := 0
-	for ; i < len(str) && i < len(other); i++ {
-		if str[i] > other[i] {
-			return 1
-		} else if str[i] < other[i] {
-			return -1
-		}
 	}
 	return 0
 }
@@ -257,92 +264,42 @@ func FormatStrings(arr []string) string {
 	return s
 }
 
-// Gsub Returns a copy of str with all occurrences of
-// pattern substituted for the second argument. The
-// pattern is typically a Regexp; if given as a String, any
-// regular expression metacharacters it contains will be interpreted
-// literally, e.g. \d will match a backslash followed by 'd', instead of a
-// digit.
-//
-// If replacement is a String it will be substituted for the matched text.
-// It may contain back-references to the pattern's capture groups of the
-// form \d, where d is a group number. Unlike in Ruby, \k is unsupported.
-//
-//	GSub("hello", regexp.MustCompile(`[aeiou]`), "*")                 // "h*ll*"
-//	GSub("hello", regexp.MustCompile(`([aeiou])`), "<\1>")            // "h<e>ll<o>"
-//	GSub("hello", regexp.MustCompile(`.`), func(s string) string {    // "104 101 108 108 111 "
+// Gsub returns a copy of str with all occurrences of pattern replaced.
+// The pattern is typically a *regexp.Regexp; if given as a string, it is
+// matched literally, e.g. \d will match a backslash followed by 'd',
+// instead of a digit.
+//
+// If replacement is a string it will be substituted for the matched text.
+// It may contain the following back-references:
+//
+//	\0 .. \9    the numbered capture group (\0 is the whole match)
+//	\k<name>    the named capture group
+//	\&          the whole match
+//	\`          the text before the match
+//	\'          the text after the match
+//	\\          a literal backslash
+//
+// Groups that didn't participate in the match expand to "", as does
+// \k<name> when there is no group called name, where Ruby would raise an
+// IndexError.
+//
+// If replacement is a map[string]string, the matched text is looked up in
+// it and replaced by the value, or removed if there is none. If it is a
+// func(string) string, it is called with the matched text, and if it is a
+// func(*MatchData) string, it is called with the full match data. Gsub
+// returns str unchanged for any other pattern or replacement type.
+//
+//	Gsub("hello", regexp.MustCompile(`[aeiou]`), "*")                 // "h*ll*"
+//	Gsub("hello", regexp.MustCompile(`([aeiou])`), `<\1>`)            // "h<e>ll<o>"
+//	Gsub("hello", regexp.MustCompile(`(?P<c>[aeiou])`), `{\k<c>}`)    // "h{e}ll{o}"
+//	Gsub("hello", regexp.MustCompile(`[eo]`), map[string]string{"e": "3", "o": "*"})
+//	  // "h3ll*"
+//	Gsub("hello", regexp.MustCompile(`.`), func(s string) string {    // "104 101 108 108 111 "
 //		return strconv.Itoa(Ord(s)) + " "
 //	})
-func Gsub(orig string, match, replacer interface{}) string {
-	// prepare source
-	var source [][]string
-	if val, ok := match.(*regexp.Regexp); ok {
-		if !val.MatchString(orig) {
-			return orig
-		}
-		m := val.FindAllStringSubmatch(orig, -1)
-		source = m
-	}
-	if val, ok := match.(string); ok {
-		s := strings.Split(val, "|")
-		for _, v := range s {
-			source = append(source, []string{v})
-		}
-	}
-
-	// prepare replace
-	if val, ok := replacer.(string); ok {
-		var rplr []string
-		for i, v := range val {
-			if v == '\\' {
-				r := []rune{v}
-				for j := i + 1; j < len(val); j++ {
-					if !unicode.IsDigit([]rune(val)[j]) {
-						break
-					}
-					r = append(r, []rune(val)[j])
-				}
-				rplr = append(rplr, string(r))
-			}
-		}
-
-		// actual replace
-		for i, v := range source {
-			r := val
-			for _, v1 := range rplr {
-				idxStr := strings.TrimPrefix(v1, "\\")
-				idx, _ := strconv.Atoi(idxStr)
-				if idx > len(v)-1 {
-					err := fmt.Errorf("%d is greater than %d, over-capacity", idx+i, len(v)-1)
-					log.Printf("GSub: %s", err)
-					// panic(err)
-					continue
-				}
-				r = strings.Replace(r, v1, v[idx], 1)
-			}
-			orig = strings.Replace(orig, v[0], r, 1)
-		}
-
-		return orig
-	}
-
-	if val, ok := replacer.(func(s string) string); ok {
-		for _, v := range source {
-			orig = strings.Replace(orig, v[0], val(v[0]), 1)
-		}
-	}
-
-	if val, ok := replacer.(map[string]string); ok {
-		for _, v := range source {
-			if val1, ok := val[v[0]]; ok {
-				orig = strings.Replace(orig, v[0], val1, 1)
-			} else {
-				orig = strings.Replace(orig, v[0], "", 1)
-			}
-		}
-	}
-
-	return orig
+//	Gsub("a.b.c", ".", "-")                                          // "a-b-c"
+func Gsub(str string, pattern, replacement interface{}) string {
+	return substitute(str, pattern, replacement, -1)
 }
 
 // Returns the integer index of the first match for the given argument, or
@@ -383,6 +340,7 @@ func Gsub(orig string, match, replacer interface{}) string {
 //	Index("foo", regexp.MustCompile(`o.`), -2) // 1
 //	Index("foo", regexp.MustCompile(`.o`), -2) // 1
 func Index(str string, sub interface{}, offset ...int) int {
+	sub = unwrapPattern(sub)
 	var off int = 0
 	if len(offset) > 0 {
 		off = offset[0]
@@ -570,6 +528,11 @@ func MatchingStringsets(a, b interface{}) bool {
 	panic("unknown slice types")
 }
 
+// Next is an alias for Succ.
+func Next(str string) string {
+	return Succ(str)
+}
+
 // Ord returns the Integer ordinal of a one-character string.
 func Ord(str string) int {
 	return int(str[0])
@@ -583,6 +546,7 @@ func Ord(str string) int {
 //	Partition("hello", "x")                      // []string{"hello", "", ""}
 //	Partition("hello", regexp.MustCompile(`.l`)) // []string{"h", "el", "lo"}
 func Partition(str string, pat interface{}) []string {
+	pat = unwrapPattern(pat)
 	if pat == nil {
 		return nil
 	}
@@ -646,9 +610,10 @@ func Reverse(str string) string {
 //	Rindex("foo", "o", -3)        // -1
 //	Rindex("foo", "o", -4)        // -1
 //
-// If str or sub is empty or nil, or if sub is not a string or
-// *regexp.Regexp, Rindex will return -1.
+// If str or sub is empty or nil, or if sub is not a string,
+// *regexp.Regexp or *rubyregexp.Regexp, Rindex will return -1.
 func Rindex(str string, sub interface{}, offset ...int) int {
+	sub = unwrapPattern(sub)
 	var off int
 	if len(offset) > 0 {
 		off = offset[0]
@@ -718,6 +683,7 @@ func Rindex(str string, sub interface{}, offset ...int) int {
 //
 //	<<cruel>> <<world>>
 func Scan(str string, pattern interface{}, block ...func(match interface{})) interface{} {
+	pattern = unwrapPattern(pattern)
 	var fn func(match interface{})
 
 	if block != nil && len(block) != 0 {
@@ -894,6 +860,7 @@ func Scrub(str string, repl ...interface{}) string {
 //
 // Currently does not support the block version.
 func Split(s string, pattern interface{}, limit ...int) []string {
+	pattern = unwrapPattern(pattern)
 	if strings.TrimSpace(s) == "" {
 		return []string{}
 	}
@@ -1014,6 +981,94 @@ func Squeeze(s string, other ...string) string {
 	return xstrings.Squeeze(s, pat)
 }
 
+// Sub returns a copy of str with the first occurrence of pattern
+// replaced. Patterns and replacements are as for Gsub.
+//
+//	Sub("hello", regexp.MustCompile(`[aeiou]`), "*")                 // "h*llo"
+//	Sub("hello", regexp.MustCompile(`([aeiou])`), `<\1>`)            // "h<e>llo"
+//	Sub("hello", regexp.MustCompile(`l`), "[\\`|\\&|\\']")         // "he[he|l|lo]lo"
+//	Sub("hello", regexp.MustCompile(`l+`), func(m *MatchData) string {
+//		return strconv.Itoa(m.Begin(0))                               // "he2o"
+//	})
+func Sub(str string, pattern, replacement interface{}) string {
+	return substitute(str, pattern, replacement, 1)
+}
+
+// Succ returns the successor to str. The successor is calculated by
+// incrementing characters starting from the rightmost alphanumeric (or the
+// rightmost character if there are no alphanumerics) in the string.
+// Incrementing a digit always results in another digit, and incrementing a
+// letter results in another letter of the same case. Only ASCII letters
+// and digits count as alphanumerics; anything else is incremented by code
+// point.
+//
+// If the increment generates a carry, the alphanumeric to the left of it
+// is incremented. This process repeats until there is no carry, adding an
+// additional character if necessary. As in Ruby, a carry does not cross
+// a separator into a run of a different kind (letters or digits).
+//
+//	Succ("abcd")       // "abce"
+//	Succ("THX1138")    // "THX1139"
+//	Succ("<<koala>>")  // "<<koalb>>"
+//	Succ("az")         // "ba"
+//	Succ("zz99")       // "aaa00"
+//	Succ("1999zzz")    // "2000aaa"
+//	Succ("ZZZ9999")    // "AAAA0000"
+//	Succ("1.9.9")      // "2.0.0"
+//	Succ("***")        // "**+"
+func Succ(str string) string {
+	if str == "" {
+		return ""
+	}
+	rs := []rune(str)
+	var carry, lastAlnum rune
+	carryPos := -1
+	separated := false
+	for i := len(rs) - 1; i >= 0; i-- {
+		r := rs[i]
+		if !isASCIIAlnum(r) {
+			separated = true
+			continue
+		}
+		if separated && lastAlnum != 0 && isASCIIDigit(lastAlnum) != isASCIIDigit(r) {
+			break
+		}
+		separated = false
+		switch r {
+		case '9':
+			rs[i], carry = '0', '1'
+		case 'z':
+			rs[i], carry = 'a', 'a'
+		case 'Z':
+			rs[i], carry = 'A', 'A'
+		default:
+			rs[i]++
+			return string(rs)
+		}
+		lastAlnum, carryPos = r, i
+	}
+
+	if carryPos < 0 {
+		// No alphanumerics, so increment the rightmost character.
+		for i := len(rs) - 1; i >= 0; i-- {
+			switch rs[i] {
+			case utf8.MaxRune:
+				rs[i] = 0
+				continue
+			case 0xD7FF:
+				rs[i] = 0xE000
+			default:
+				rs[i]++
+			}
+			return string(rs)
+		}
+		carry, carryPos = 1, 0
+	}
+
+	rs = append(rs[:carryPos], append([]rune{carry}, rs[carryPos:]...)...)
+	return string(rs)
+}
+
 // Returns a copy of str with the characters in from_str replaced by the
 // corresponding characters in to_str.  If to_str is shorter than from_str,
 // it is padded with its last character in order to maintain the
@@ -1110,6 +1165,77 @@ func Truncate(str string, length int, options ...interface{}) string {
 	return string(out)
 }
 
+// Upto iterates through successive values, starting at start and ending
+// at stop inclusive (or exclusive, if exclusive is true), passing each
+// value in turn to fn. Values are generated with Succ.
+//
+//	Upto("a8", "b6", false, fn)  // "a8", "a9", "b0", "b1", "b2", "b3", "b4", "b5", "b6"
+//
+// If start and stop are both single ASCII characters, they are iterated
+// by character code; if both consist only of digits, they are iterated
+// numerically, padded to the length of start.
+//
+//	Upto("9", "A", false, fn)    // "9", ":", ";", "<", "=", ">", "?", "@", "A"
+//	Upto("25", "5", false, fn)   // nothing, as 25 > 5 numerically
+//	Upto("07", "11", true, fn)   // "07", "08", "09", "10"
+func Upto(start, stop string, exclusive bool, fn func(s string)) {
+	if len(start) == 1 && len(stop) == 1 && start[0] < utf8.RuneSelf && stop[0] < utf8.RuneSelf {
+		c, e := start[0], stop[0]
+		if c > e || (exclusive && c == e) {
+			return
+		}
+		for {
+			fn(string(c))
+			if !exclusive && c == e {
+				return
+			}
+			c++
+			if exclusive && c == e {
+				return
+			}
+		}
+	}
+
+	if isASCIIDigits(start) && isASCIIDigits(stop) {
+		b, errB := strconv.ParseInt(start, 10, 64)
+		e, errE := strconv.ParseInt(stop, 10, 64)
+		if errB == nil && errE == nil {
+			for ; b <= e && b >= 0; b++ {
+				if exclusive && b == e {
+					return
+				}
+				fn(fmt.Sprintf("%0*d", len(start), b))
+			}
+			return
+		}
+	}
+
+	n := strings.Compare(start, stop)
+	if n > 0 || (exclusive && n == 0) {
+		return
+	}
+	afterEnd := Succ(stop)
+	current := start
+	for current != afterEnd {
+		next := ""
+		last := !exclusive && current == stop
+		if !last {
+			next = Succ(current)
+		}
+		fn(current)
+		if last {
+			return
+		}
+		current = next
+		if exclusive && current == stop {
+			return
+		}
+		if len(current) > len(stop) || len(current) == 0 {
+			return
+		}
+	}
+}
+
 // getSplitType determines the type of split to perform.
 func getSplitType(pattern interface{}, defaultType splitType) splitType {
 	if pattern == nil {
@@ -1165,6 +1291,30 @@ func splitString(result *[]string, str string, beg, l, emptyCount int) int {
 	return emptyCount
 }
 
+// isASCIIAlnum reports whether r is an ASCII letter or digit.
+func isASCIIAlnum(r rune) bool {
+	return isASCIIDigit(r) || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+// isASCIIDigit reports whether r is an ASCII digit.
+func isASCIIDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// isASCIIDigits reports whether str is non-empty and made up only of ASCII
+// digits.
+func isASCIIDigits(str string) bool {
+	if str == "" {
+		return false
+	}
+	for _, r := range str {
+		if !isASCIIDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
 // reSplit splits a string based on a *regexp.Regexp, accounting for
 // capture groups.
 func reSplit(s string, re *regexp.Regexp, n int) []string {
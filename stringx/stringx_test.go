@@ -2,10 +2,13 @@ package stringx
 
 import (
 	"fmt"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
 	"testing"
+
+	"github.com/robicode/stdx/stringx/rubyregexp"
 )
 
 var errors int = 0
@@ -185,6 +188,75 @@ func Test_Gsub(t *testing.T) {
 	}); result != "104 101 108 108 111 " {
 		t.Errorf("expected '%s' but got '%s'", "104 101 108 108 111 ", result)
 	}
+
+	tests := []struct {
+		str         string
+		pattern     interface{}
+		replacement interface{}
+		expected    string
+	}{
+		{"hello", regexp.MustCompile(`(?P<c>[aeiou])`), `{\k<c>}`, "h{e}ll{o}"},
+		{"hello", regexp.MustCompile(`l+`), `<\0|\&>`, "he<ll|ll>o"},
+		{"hello", regexp.MustCompile(`l`), "[\\`|\\']", "he[he|lo][hel|o]o"},
+		{"hello", regexp.MustCompile(`e`), `\\\d\`, `h\\d\llo`},
+		{"hello", regexp.MustCompile(`(e)`), `\10`, "he0llo"},
+		{"hello", regexp.MustCompile(`(e)(x)?`), `[\2]`, "h[]llo"},
+		{"hello", regexp.MustCompile(`[eo]`), map[string]string{"e": "3", "o": "0"}, "h3ll0"},
+		{"hello", regexp.MustCompile(`[elo]`), map[string]string{"e": "3"}, "h3"},
+		{"a.b|c", ".", "-", "a-b|c"},
+		{"a.b|c", "b|c", "X", "a.X"},
+		{"aaa", "aa", "b", "ba"},
+		{"héllo", "", "-", "-h-é-l-l-o-"},
+		{"", "", "-", "-"},
+		{"hello", regexp.MustCompile(`e`), `<\k<x>>`, "h<>llo"},
+		{"abc", regexp.MustCompile(`x*`), "-", "-a-b-c-"},
+		{"hello", regexp.MustCompile(`z`), "*", "hello"},
+		{"hello", 42, "*", "hello"},
+		{"hello", "l", 42, "hello"},
+	}
+	for _, test := range tests {
+		if result := Gsub(test.str, test.pattern, test.replacement); result != test.expected {
+			t.Errorf("expected Gsub(%q, %v, %v) to return %q but got %q", test.str, test.pattern, test.replacement, test.expected, result)
+		}
+	}
+
+	result := Gsub("John Smith, Jane Doe", regexp.MustCompile(`(?P<first>\w+) (?P<last>\w+)`), func(m *MatchData) string {
+		last, _ := m.Named("last")
+		return fmt.Sprintf("%s %s@%d-%d", last, m.Captures()[0], m.Begin(0), m.End(0))
+	})
+	if result != "Smith John@0-10, Doe Jane@12-20" {
+		t.Errorf("expected %q but got %q", "Smith John@0-10, Doe Jane@12-20", result)
+	}
+}
+
+func Test_Sub(t *testing.T) {
+	tests := []struct {
+		str         string
+		pattern     interface{}
+		replacement interface{}
+		expected    string
+	}{
+		{"hello", regexp.MustCompile(`[aeiou]`), "*", "h*llo"},
+		{"hello", regexp.MustCompile(`([aeiou])`), `<\1>`, "h<e>llo"},
+		{"hello", regexp.MustCompile(`l`), "[\\`|\\&|\\']", "he[he|l|lo]lo"},
+		{"hello", regexp.MustCompile(`(?P<v>[aeiou])`), `\k<v>\k<v>`, "heello"},
+		{"hello", regexp.MustCompile(`[aeiou]`), map[string]string{"o": "0"}, "hllo"},
+		{"hello", regexp.MustCompile(`l+`), strings.ToUpper, "heLLo"},
+		{"a.b.c", ".", "-", "a-b.c"},
+		{"hello", "x", "-", "hello"},
+	}
+	for _, test := range tests {
+		if result := Sub(test.str, test.pattern, test.replacement); result != test.expected {
+			t.Errorf("expected Sub(%q, %v, %v) to return %q but got %q", test.str, test.pattern, test.replacement, test.expected, result)
+		}
+	}
+
+	result := Sub("hello", regexp.MustCompile(`l+`), func(m *MatchData) string {
+		return strconv.Itoa(m.Begin(0)) + m.PreMatch() + m.PostMatch()
+	})
+	if result != "he2heoo" {
+		t.Errorf("expected %q but got %q", "he2heoo", result)
+	}
 }
 
 func Test_Index(t *testing.T) {
@@ -536,6 +608,36 @@ func Test_Squeeze(t *testing.T) {
 	}
 }
 
+func Test_Succ(t *testing.T) {
+	tests := map[string]string{
+		"":           "",
+		"abcd":       "abce",
+		"THX1138":    "THX1139",
+		"<<koala>>":  "<<koalb>>",
+		"az":         "ba",
+		"zz":         "aaa",
+		"a9":         "b0",
+		"Zz":         "AAa",
+		"zz99":       "aaa00",
+		"1999zzz":    "2000aaa",
+		"ZZZ9999":    "AAAA0000",
+		"1.9.9":      "2.0.0",
+		"-9":         "-10",
+		"a.9":        "a.10",
+		"***":        "**+",
+		"é":          "ê",
+		"\U0010ffff": "\x01\x00",
+	}
+	for str, expected := range tests {
+		if got := Succ(str); got != expected {
+			t.Errorf("expected Succ(%q) to return %q but got %q", str, expected, got)
+		}
+	}
+	if Next("az") != "ba" {
+		t.Errorf("expected Next(\"az\") to return \"ba\" but got %q", Next("az"))
+	}
+}
+
 func Test_Tr(t *testing.T) {
 	if result := Tr("hello", "el", "ip"); result != "hippo" {
 		t.Errorf("expected '%s' but got '%s'", "hippo", result)
@@ -632,3 +734,72 @@ func expectMatchingSlices(t *testing.T, desc, str string, delim interface{}, exp
 		errors++
 	}
 }
+
+func Test_Upto(t *testing.T) {
+	tests := []struct {
+		start, stop string
+		exclusive   bool
+		expected    []string
+	}{
+		{"a8", "b6", false, []string{"a8", "a9", "b0", "b1", "b2", "b3", "b4", "b5", "b6"}},
+		{"a8", "b6", true, []string{"a8", "a9", "b0", "b1", "b2", "b3", "b4", "b5"}},
+		{"9", "A", false, []string{"9", ":", ";", "<", "=", ">", "?", "@", "A"}},
+		{"25", "5", false, []string{}},
+		{"07", "11", true, []string{"07", "08", "09", "10"}},
+		{"Y", "AB", false, []string{}},
+		{"Y", "Y", true, []string{}},
+		{"x", "ac", false, []string{}},
+		{"az", "bc", false, []string{"az", "ba", "bb", "bc"}},
+		{"b", "a", false, []string{}},
+		{"zz", "a", false, []string{}},
+	}
+	for _, test := range tests {
+		got := []string{}
+		Upto(test.start, test.stop, test.exclusive, func(s string) {
+			got = append(got, s)
+		})
+		if !equalSlices(got, test.expected) {
+			t.Errorf("expected Upto(%q, %q, %v) to yield %s but got %s", test.start, test.stop, test.exclusive,
+				quoteSliceElements(test.expected), quoteSliceElements(got))
+		}
+	}
+}
+
+func Test_RubyRegexpPatterns(t *testing.T) {
+	re := rubyregexp.MustCompile(`(?<word>\h+)`)
+	if result := Gsub("ab xy cd", re, `<\k<word>>`); result != "<ab> xy <cd>" {
+		t.Errorf("expected Gsub to return %q but got %q", "<ab> xy <cd>", result)
+	}
+	if result := Sub("ab xy cd", re, `<\0>`); result != "<ab> xy cd" {
+		t.Errorf("expected Sub to return %q but got %q", "<ab> xy cd", result)
+	}
+	if result := Index("xy cd", re); result != 3 {
+		t.Errorf("expected Index to return 3 but got %d", result)
+	}
+	if result := Rindex("ab xy cd", re); result != 6 {
+		t.Errorf("expected Rindex to return 6 but got %d", result)
+	}
+	if result := Partition("xy cd xy", re); !equalSlices(result, []string{"xy ", "cd", " xy"}) {
+		t.Errorf("expected Partition to return [xy  cd  xy] but got %s", quoteSliceElements(result))
+	}
+	if result := Split("a1b22c", rubyregexp.MustCompile(`\d+`)); !equalSlices(result, []string{"a", "b", "c"}) {
+		t.Errorf("expected Split to return [a b c] but got %s", quoteSliceElements(result))
+	}
+	if result := Scan("ab xy cd", rubyregexp.MustCompile(`\h\h`)); !reflect.DeepEqual(result, []string{"ab", "cd"}) {
+		t.Errorf("expected Scan to return [ab cd] but got %v", result)
+	}
+	if result := Truncate("Once upon a time", 12, "", rubyregexp.MustCompile(`[[:space:]]`)); result != "Once upon a" {
+		t.Errorf("expected Truncate to return %q but got %q", "Once upon a", result)
+	}
+
+	s := NewStringScanner("ab xy")
+	if got, ok := s.Scan(re); !ok || got != "ab" {
+		t.Errorf("expected StringScanner.Scan to return \"ab\" but got %q", got)
+	}
+	if got, ok := s.Named("word"); !ok || got != "ab" {
+		t.Errorf("expected the named group to be kept but got %q", got)
+	}
+	if _, ok := s.Scan(rubyregexp.MustCompile(`\A\s*\h`)); ok {
+		t.Errorf("expected \\A to match at the scan pointer only")
+	}
+}
@@ -0,0 +1,161 @@
+package stringx
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	transliterationsMu sync.RWMutex
+
+	// localeTransliterations holds the per-locale exceptions to
+	// defaultTransliterations, keyed by language code.
+	localeTransliterations = map[string]map[rune]string{
+		"de": {
+			'Ä': "Ae", 'Ö': "Oe", 'Ü': "Ue", 'ẞ': "SS",
+			'ä': "ae", 'ö': "oe", 'ü': "ue", 'ß': "ss",
+		},
+		"da": scandinavianTransliterations,
+		"nb": scandinavianTransliterations,
+		"nn": scandinavianTransliterations,
+		"no": scandinavianTransliterations,
+		"uk": {
+			'Г': "H", 'И': "Y", 'Й': "I", 'Х': "Kh",
+			'г': "h", 'и': "y", 'й': "i", 'х': "kh",
+		},
+	}
+
+	scandinavianTransliterations = map[rune]string{
+		'Æ': "Ae", 'Ø': "Oe", 'Å': "Aa",
+		'æ': "ae", 'ø': "oe", 'å': "aa",
+	}
+
+	unparameterizedChars = regexp.MustCompile(`[^a-zA-Z0-9\-_]+`)
+)
+
+// RegisterTransliterations adds the approximations in table to those used
+// by Transliterate for locale, replacing any already registered for the
+// same characters. Approximations should consist of ASCII characters.
+//
+//	RegisterTransliterations("es", map[rune]string{'ñ': "ny", 'Ñ': "Ny"})
+//	Transliterate("España", "es")  // "Espanya"
+func RegisterTransliterations(locale string, table map[rune]string) {
+	transliterationsMu.Lock()
+	defer transliterationsMu.Unlock()
+	locale = baseLocale(locale)
+	merged := make(map[rune]string, len(table))
+	for r, s := range localeTransliterations[locale] {
+		merged[r] = s
+	}
+	for r, s := range table {
+		merged[r] = s
+	}
+	localeTransliterations[locale] = merged
+}
+
+// Transliterate replaces non-ASCII characters in str with an ASCII
+// approximation, or with "?" if there isn't one. Accented Latin letters
+// lose their accents, other Latin letters such as ß and æ are spelt out,
+// and Greek and Cyrillic letters are romanized. Combining marks are
+// dropped, so decomposed input gives the same result as precomposed.
+//
+//	Transliterate("Ærøskøbing")  // "AEroskobing"
+//	Transliterate("Москва")      // "Moskva"
+//	Transliterate("日本")        // "??"
+//
+// If a locale is given, its conventions take precedence; an unknown locale
+// uses the defaults. Regional variants such as "de-AT" use the rules of
+// their language.
+//
+//	Transliterate("Jürgen Müller")        // "Jurgen Muller"
+//	Transliterate("Jürgen Müller", "de")  // "Juergen Mueller"
+//	Transliterate("Blåbær", "da")         // "Blaabaer"
+func Transliterate(str string, locale ...string) string {
+	var table map[rune]string
+	if len(locale) > 0 {
+		transliterationsMu.RLock()
+		table = localeTransliterations[baseLocale(locale[0])]
+		transliterationsMu.RUnlock()
+	}
+
+	var sb strings.Builder
+	for _, r := range str {
+		if r < utf8.RuneSelf {
+			sb.WriteRune(r)
+			continue
+		}
+		if s, ok := table[r]; ok {
+			sb.WriteString(s)
+		} else if s, ok := defaultTransliterations[r]; ok {
+			sb.WriteString(s)
+		} else if !unicode.Is(unicode.Mn, r) {
+			sb.WriteByte('?')
+		}
+	}
+	return sb.String()
+}
+
+func baseLocale(locale string) string {
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		locale = locale[:i]
+	}
+	return strings.ToLower(locale)
+}
+
+// ParameterizeOptions configures Parameterize.
+type ParameterizeOptions struct {
+	// Separator replaces runs of unwanted characters; it defaults to "-".
+	Separator string
+	// PreserveCase keeps the case of letters instead of lowercasing them.
+	PreserveCase bool
+	// MaxLength, if positive, is the maximum length of the result, which
+	// is cut at the last separator that fits where possible.
+	MaxLength int
+	// Locale is passed to Transliterate.
+	Locale string
+}
+
+// Parameterize turns str into a slug that may be used as part of a pretty
+// URL. It is transliterated to ASCII, then every run of characters other
+// than ASCII letters, digits, dashes and underscores is replaced by the
+// separator, which is not repeated and is trimmed from both ends.
+//
+//	Parameterize("Donald E. Knuth")  // "donald-e-knuth"
+//	Parameterize("^très|Jolie-- ")   // "tres-jolie"
+//	Parameterize("Donald E. Knuth", ParameterizeOptions{Separator: "_"})
+//	  // "donald_e_knuth"
+//	Parameterize("Donald E. Knuth", ParameterizeOptions{PreserveCase: true})
+//	  // "Donald-E-Knuth"
+//	Parameterize("Über die Brücke", ParameterizeOptions{Locale: "de"})
+//	  // "ueber-die-bruecke"
+//	Parameterize("Once upon a time in a world", ParameterizeOptions{MaxLength: 20})
+//	  // "once-upon-a-time-in"
+func Parameterize(str string, options ...ParameterizeOptions) string {
+	var o ParameterizeOptions
+	if len(options) > 0 {
+		o = options[0]
+	}
+	sep := o.Separator
+	if sep == "" {
+		sep = "-"
+	}
+
+	s := unparameterizedChars.ReplaceAllLiteralString(Transliterate(str, o.Locale), sep)
+	for doubled := sep + sep; strings.Contains(s, doubled); {
+		s = strings.ReplaceAll(s, doubled, sep)
+	}
+	s = strings.TrimSuffix(strings.TrimPrefix(s, sep), sep)
+	if !o.PreserveCase {
+		s = strings.ToLower(s)
+	}
+	if o.MaxLength > 0 && len(s) > o.MaxLength {
+		s = Truncate(s, o.MaxLength, "", sep)
+		for strings.HasSuffix(s, sep) {
+			s = strings.TrimSuffix(s, sep)
+		}
+	}
+	return s
+}
@@ -0,0 +1,767 @@
+package stringx
+
+// defaultTransliterations maps characters to their ASCII approximations.
+// Latin letters are approximated by their base letter with accents
+// removed, following the Unicode canonical decompositions, and letters
+// without a decomposition (ß, æ, ł and friends) by their conventional
+// spelling. Greek and Cyrillic letters use a simple phonetic
+// romanization, and typographic punctuation is mapped to its ASCII
+// counterpart.
+var defaultTransliterations = map[rune]string{
+	0x00A0: " ",    // No-Break Space
+	0x00AB: "<<",   // Left-Pointing Double Angle Quotation Mark
+	0x00BB: ">>",   // Right-Pointing Double Angle Quotation Mark
+	0x00C0: "A",    // Latin Capital Letter A With Grave
+	0x00C1: "A",    // Latin Capital Letter A With Acute
+	0x00C2: "A",    // Latin Capital Letter A With Circumflex
+	0x00C3: "A",    // Latin Capital Letter A With Tilde
+	0x00C4: "A",    // Latin Capital Letter A With Diaeresis
+	0x00C5: "A",    // Latin Capital Letter A With Ring Above
+	0x00C6: "AE",   // Latin Capital Letter Ae
+	0x00C7: "C",    // Latin Capital Letter C With Cedilla
+	0x00C8: "E",    // Latin Capital Letter E With Grave
+	0x00C9: "E",    // Latin Capital Letter E With Acute
+	0x00CA: "E",    // Latin Capital Letter E With Circumflex
+	0x00CB: "E",    // Latin Capital Letter E With Diaeresis
+	0x00CC: "I",    // Latin Capital Letter I With Grave
+	0x00CD: "I",    // Latin Capital Letter I With Acute
+	0x00CE: "I",    // Latin Capital Letter I With Circumflex
+	0x00CF: "I",    // Latin Capital Letter I With Diaeresis
+	0x00D0: "D",    // Latin Capital Letter Eth
+	0x00D1: "N",    // Latin Capital Letter N With Tilde
+	0x00D2: "O",    // Latin Capital Letter O With Grave
+	0x00D3: "O",    // Latin Capital Letter O With Acute
+	0x00D4: "O",    // Latin Capital Letter O With Circumflex
+	0x00D5: "O",    // Latin Capital Letter O With Tilde
+	0x00D6: "O",    // Latin Capital Letter O With Diaeresis
+	0x00D8: "O",    // Latin Capital Letter O With Stroke
+	0x00D9: "U",    // Latin Capital Letter U With Grave
+	0x00DA: "U",    // Latin Capital Letter U With Acute
+	0x00DB: "U",    // Latin Capital Letter U With Circumflex
+	0x00DC: "U",    // Latin Capital Letter U With Diaeresis
+	0x00DD: "Y",    // Latin Capital Letter Y With Acute
+	0x00DE: "TH",   // Latin Capital Letter Thorn
+	0x00DF: "ss",   // Latin Small Letter Sharp S
+	0x00E0: "a",    // Latin Small Letter A With Grave
+	0x00E1: "a",    // Latin Small Letter A With Acute
+	0x00E2: "a",    // Latin Small Letter A With Circumflex
+	0x00E3: "a",    // Latin Small Letter A With Tilde
+	0x00E4: "a",    // Latin Small Letter A With Diaeresis
+	0x00E5: "a",    // Latin Small Letter A With Ring Above
+	0x00E6: "ae",   // Latin Small Letter Ae
+	0x00E7: "c",    // Latin Small Letter C With Cedilla
+	0x00E8: "e",    // Latin Small Letter E With Grave
+	0x00E9: "e",    // Latin Small Letter E With Acute
+	0x00EA: "e",    // Latin Small Letter E With Circumflex
+	0x00EB: "e",    // Latin Small Letter E With Diaeresis
+	0x00EC: "i",    // Latin Small Letter I With Grave
+	0x00ED: "i",    // Latin Small Letter I With Acute
+	0x00EE: "i",    // Latin Small Letter I With Circumflex
+	0x00EF: "i",    // Latin Small Letter I With Diaeresis
+	0x00F0: "d",    // Latin Small Letter Eth
+	0x00F1: "n",    // Latin Small Letter N With Tilde
+	0x00F2: "o",    // Latin Small Letter O With Grave
+	0x00F3: "o",    // Latin Small Letter O With Acute
+	0x00F4: "o",    // Latin Small Letter O With Circumflex
+	0x00F5: "o",    // Latin Small Letter O With Tilde
+	0x00F6: "o",    // Latin Small Letter O With Diaeresis
+	0x00F8: "o",    // Latin Small Letter O With Stroke
+	0x00F9: "u",    // Latin Small Letter U With Grave
+	0x00FA: "u",    // Latin Small Letter U With Acute
+	0x00FB: "u",    // Latin Small Letter U With Circumflex
+	0x00FC: "u",    // Latin Small Letter U With Diaeresis
+	0x00FD: "y",    // Latin Small Letter Y With Acute
+	0x00FE: "th",   // Latin Small Letter Thorn
+	0x00FF: "y",    // Latin Small Letter Y With Diaeresis
+	0x0100: "A",    // Latin Capital Letter A With Macron
+	0x0101: "a",    // Latin Small Letter A With Macron
+	0x0102: "A",    // Latin Capital Letter A With Breve
+	0x0103: "a",    // Latin Small Letter A With Breve
+	0x0104: "A",    // Latin Capital Letter A With Ogonek
+	0x0105: "a",    // Latin Small Letter A With Ogonek
+	0x0106: "C",    // Latin Capital Letter C With Acute
+	0x0107: "c",    // Latin Small Letter C With Acute
+	0x0108: "C",    // Latin Capital Letter C With Circumflex
+	0x0109: "c",    // Latin Small Letter C With Circumflex
+	0x010A: "C",    // Latin Capital Letter C With Dot Above
+	0x010B: "c",    // Latin Small Letter C With Dot Above
+	0x010C: "C",    // Latin Capital Letter C With Caron
+	0x010D: "c",    // Latin Small Letter C With Caron
+	0x010E: "D",    // Latin Capital Letter D With Caron
+	0x010F: "d",    // Latin Small Letter D With Caron
+	0x0110: "D",    // Latin Capital Letter D With Stroke
+	0x0111: "d",    // Latin Small Letter D With Stroke
+	0x0112: "E",    // Latin Capital Letter E With Macron
+	0x0113: "e",    // Latin Small Letter E With Macron
+	0x0114: "E",    // Latin Capital Letter E With Breve
+	0x0115: "e",    // Latin Small Letter E With Breve
+	0x0116: "E",    // Latin Capital Letter E With Dot Above
+	0x0117: "e",    // Latin Small Letter E With Dot Above
+	0x0118: "E",    // Latin Capital Letter E With Ogonek
+	0x0119: "e",    // Latin Small Letter E With Ogonek
+	0x011A: "E",    // Latin Capital Letter E With Caron
+	0x011B: "e",    // Latin Small Letter E With Caron
+	0x011C: "G",    // Latin Capital Letter G With Circumflex
+	0x011D: "g",    // Latin Small Letter G With Circumflex
+	0x011E: "G",    // Latin Capital Letter G With Breve
+	0x011F: "g",    // Latin Small Letter G With Breve
+	0x0120: "G",    // Latin Capital Letter G With Dot Above
+	0x0121: "g",    // Latin Small Letter G With Dot Above
+	0x0122: "G",    // Latin Capital Letter G With Cedilla
+	0x0123: "g",    // Latin Small Letter G With Cedilla
+	0x0124: "H",    // Latin Capital Letter H With Circumflex
+	0x0125: "h",    // Latin Small Letter H With Circumflex
+	0x0126: "H",    // Latin Capital Letter H With Stroke
+	0x0127: "h",    // Latin Small Letter H With Stroke
+	0x0128: "I",    // Latin Capital Letter I With Tilde
+	0x0129: "i",    // Latin Small Letter I With Tilde
+	0x012A: "I",    // Latin Capital Letter I With Macron
+	0x012B: "i",    // Latin Small Letter I With Macron
+	0x012C: "I",    // Latin Capital Letter I With Breve
+	0x012D: "i",    // Latin Small Letter I With Breve
+	0x012E: "I",    // Latin Capital Letter I With Ogonek
+	0x012F: "i",    // Latin Small Letter I With Ogonek
+	0x0130: "I",    // Latin Capital Letter I With Dot Above
+	0x0131: "i",    // Latin Small Letter Dotless I
+	0x0132: "IJ",   // Latin Capital Ligature Ij
+	0x0133: "ij",   // Latin Small Ligature Ij
+	0x0134: "J",    // Latin Capital Letter J With Circumflex
+	0x0135: "j",    // Latin Small Letter J With Circumflex
+	0x0136: "K",    // Latin Capital Letter K With Cedilla
+	0x0137: "k",    // Latin Small Letter K With Cedilla
+	0x0138: "k",    // Latin Small Letter Kra
+	0x0139: "L",    // Latin Capital Letter L With Acute
+	0x013A: "l",    // Latin Small Letter L With Acute
+	0x013B: "L",    // Latin Capital Letter L With Cedilla
+	0x013C: "l",    // Latin Small Letter L With Cedilla
+	0x013D: "L",    // Latin Capital Letter L With Caron
+	0x013E: "l",    // Latin Small Letter L With Caron
+	0x013F: "L",    // Latin Capital Letter L With Middle Dot
+	0x0140: "l",    // Latin Small Letter L With Middle Dot
+	0x0141: "L",    // Latin Capital Letter L With Stroke
+	0x0142: "l",    // Latin Small Letter L With Stroke
+	0x0143: "N",    // Latin Capital Letter N With Acute
+	0x0144: "n",    // Latin Small Letter N With Acute
+	0x0145: "N",    // Latin Capital Letter N With Cedilla
+	0x0146: "n",    // Latin Small Letter N With Cedilla
+	0x0147: "N",    // Latin Capital Letter N With Caron
+	0x0148: "n",    // Latin Small Letter N With Caron
+	0x0149: "'n",   // Latin Small Letter N Preceded By Apostrophe
+	0x014A: "NG",   // Latin Capital Letter Eng
+	0x014B: "ng",   // Latin Small Letter Eng
+	0x014C: "O",    // Latin Capital Letter O With Macron
+	0x014D: "o",    // Latin Small Letter O With Macron
+	0x014E: "O",    // Latin Capital Letter O With Breve
+	0x014F: "o",    // Latin Small Letter O With Breve
+	0x0150: "O",    // Latin Capital Letter O With Double Acute
+	0x0151: "o",    // Latin Small Letter O With Double Acute
+	0x0152: "OE",   // Latin Capital Ligature Oe
+	0x0153: "oe",   // Latin Small Ligature Oe
+	0x0154: "R",    // Latin Capital Letter R With Acute
+	0x0155: "r",    // Latin Small Letter R With Acute
+	0x0156: "R",    // Latin Capital Letter R With Cedilla
+	0x0157: "r",    // Latin Small Letter R With Cedilla
+	0x0158: "R",    // Latin Capital Letter R With Caron
+	0x0159: "r",    // Latin Small Letter R With Caron
+	0x015A: "S",    // Latin Capital Letter S With Acute
+	0x015B: "s",    // Latin Small Letter S With Acute
+	0x015C: "S",    // Latin Capital Letter S With Circumflex
+	0x015D: "s",    // Latin Small Letter S With Circumflex
+	0x015E: "S",    // Latin Capital Letter S With Cedilla
+	0x015F: "s",    // Latin Small Letter S With Cedilla
+	0x0160: "S",    // Latin Capital Letter S With Caron
+	0x0161: "s",    // Latin Small Letter S With Caron
+	0x0162: "T",    // Latin Capital Letter T With Cedilla
+	0x0163: "t",    // Latin Small Letter T With Cedilla
+	0x0164: "T",    // Latin Capital Letter T With Caron
+	0x0165: "t",    // Latin Small Letter T With Caron
+	0x0166: "T",    // Latin Capital Letter T With Stroke
+	0x0167: "t",    // Latin Small Letter T With Stroke
+	0x0168: "U",    // Latin Capital Letter U With Tilde
+	0x0169: "u",    // Latin Small Letter U With Tilde
+	0x016A: "U",    // Latin Capital Letter U With Macron
+	0x016B: "u",    // Latin Small Letter U With Macron
+	0x016C: "U",    // Latin Capital Letter U With Breve
+	0x016D: "u",    // Latin Small Letter U With Breve
+	0x016E: "U",    // Latin Capital Letter U With Ring Above
+	0x016F: "u",    // Latin Small Letter U With Ring Above
+	0x0170: "U",    // Latin Capital Letter U With Double Acute
+	0x0171: "u",    // Latin Small Letter U With Double Acute
+	0x0172: "U",    // Latin Capital Letter U With Ogonek
+	0x0173: "u",    // Latin Small Letter U With Ogonek
+	0x0174: "W",    // Latin Capital Letter W With Circumflex
+	0x0175: "w",    // Latin Small Letter W With Circumflex
+	0x0176: "Y",    // Latin Capital Letter Y With Circumflex
+	0x0177: "y",    // Latin Small Letter Y With Circumflex
+	0x0178: "Y",    // Latin Capital Letter Y With Diaeresis
+	0x0179: "Z",    // Latin Capital Letter Z With Acute
+	0x017A: "z",    // Latin Small Letter Z With Acute
+	0x017B: "Z",    // Latin Capital Letter Z With Dot Above
+	0x017C: "z",    // Latin Small Letter Z With Dot Above
+	0x017D: "Z",    // Latin Capital Letter Z With Caron
+	0x017E: "z",    // Latin Small Letter Z With Caron
+	0x017F: "s",    // Latin Small Letter Long S
+	0x0180: "b",    // Latin Small Letter B With Stroke
+	0x0181: "B",    // Latin Capital Letter B With Hook
+	0x0187: "C",    // Latin Capital Letter C With Hook
+	0x0188: "c",    // Latin Small Letter C With Hook
+	0x0189: "D",    // Latin Capital Letter African D
+	0x018A: "D",    // Latin Capital Letter D With Hook
+	0x0191: "F",    // Latin Capital Letter F With Hook
+	0x0192: "f",    // Latin Small Letter F With Hook
+	0x0193: "G",    // Latin Capital Letter G With Hook
+	0x0197: "I",    // Latin Capital Letter I With Stroke
+	0x0198: "K",    // Latin Capital Letter K With Hook
+	0x0199: "k",    // Latin Small Letter K With Hook
+	0x019A: "l",    // Latin Small Letter L With Bar
+	0x019D: "N",    // Latin Capital Letter N With Left Hook
+	0x019E: "n",    // Latin Small Letter N With Long Right Leg
+	0x01A0: "O",    // Latin Capital Letter O With Horn
+	0x01A1: "o",    // Latin Small Letter O With Horn
+	0x01A4: "P",    // Latin Capital Letter P With Hook
+	0x01A5: "p",    // Latin Small Letter P With Hook
+	0x01AB: "t",    // Latin Small Letter T With Palatal Hook
+	0x01AC: "T",    // Latin Capital Letter T With Hook
+	0x01AD: "t",    // Latin Small Letter T With Hook
+	0x01AE: "T",    // Latin Capital Letter T With Retroflex Hook
+	0x01AF: "U",    // Latin Capital Letter U With Horn
+	0x01B0: "u",    // Latin Small Letter U With Horn
+	0x01B2: "V",    // Latin Capital Letter V With Hook
+	0x01B3: "Y",    // Latin Capital Letter Y With Hook
+	0x01B4: "y",    // Latin Small Letter Y With Hook
+	0x01B5: "Z",    // Latin Capital Letter Z With Stroke
+	0x01B6: "z",    // Latin Small Letter Z With Stroke
+	0x01C4: "DZ",   // Latin Capital Letter Dz With Caron
+	0x01C5: "Dz",   // Latin Capital Letter D With Small Letter Z With Caron
+	0x01C6: "dz",   // Latin Small Letter Dz With Caron
+	0x01C7: "LJ",   // Latin Capital Letter Lj
+	0x01C8: "Lj",   // Latin Capital Letter L With Small Letter J
+	0x01C9: "lj",   // Latin Small Letter Lj
+	0x01CA: "NJ",   // Latin Capital Letter Nj
+	0x01CB: "Nj",   // Latin Capital Letter N With Small Letter J
+	0x01CC: "nj",   // Latin Small Letter Nj
+	0x01CD: "A",    // Latin Capital Letter A With Caron
+	0x01CE: "a",    // Latin Small Letter A With Caron
+	0x01CF: "I",    // Latin Capital Letter I With Caron
+	0x01D0: "i",    // Latin Small Letter I With Caron
+	0x01D1: "O",    // Latin Capital Letter O With Caron
+	0x01D2: "o",    // Latin Small Letter O With Caron
+	0x01D3: "U",    // Latin Capital Letter U With Caron
+	0x01D4: "u",    // Latin Small Letter U With Caron
+	0x01D5: "U",    // Latin Capital Letter U With Diaeresis And Macron
+	0x01D6: "u",    // Latin Small Letter U With Diaeresis And Macron
+	0x01D7: "U",    // Latin Capital Letter U With Diaeresis And Acute
+	0x01D8: "u",    // Latin Small Letter U With Diaeresis And Acute
+	0x01D9: "U",    // Latin Capital Letter U With Diaeresis And Caron
+	0x01DA: "u",    // Latin Small Letter U With Diaeresis And Caron
+	0x01DB: "U",    // Latin Capital Letter U With Diaeresis And Grave
+	0x01DC: "u",    // Latin Small Letter U With Diaeresis And Grave
+	0x01DE: "A",    // Latin Capital Letter A With Diaeresis And Macron
+	0x01DF: "a",    // Latin Small Letter A With Diaeresis And Macron
+	0x01E0: "A",    // Latin Capital Letter A With Dot Above And Macron
+	0x01E1: "a",    // Latin Small Letter A With Dot Above And Macron
+	0x01E4: "G",    // Latin Capital Letter G With Stroke
+	0x01E5: "g",    // Latin Small Letter G With Stroke
+	0x01E6: "G",    // Latin Capital Letter G With Caron
+	0x01E7: "g",    // Latin Small Letter G With Caron
+	0x01E8: "K",    // Latin Capital Letter K With Caron
+	0x01E9: "k",    // Latin Small Letter K With Caron
+	0x01EA: "O",    // Latin Capital Letter O With Ogonek
+	0x01EB: "o",    // Latin Small Letter O With Ogonek
+	0x01EC: "O",    // Latin Capital Letter O With Ogonek And Macron
+	0x01ED: "o",    // Latin Small Letter O With Ogonek And Macron
+	0x01F0: "j",    // Latin Small Letter J With Caron
+	0x01F1: "DZ",   // Latin Capital Letter Dz
+	0x01F2: "Dz",   // Latin Capital Letter D With Small Letter Z
+	0x01F3: "dz",   // Latin Small Letter Dz
+	0x01F4: "G",    // Latin Capital Letter G With Acute
+	0x01F5: "g",    // Latin Small Letter G With Acute
+	0x01F8: "N",    // Latin Capital Letter N With Grave
+	0x01F9: "n",    // Latin Small Letter N With Grave
+	0x01FA: "A",    // Latin Capital Letter A With Ring Above And Acute
+	0x01FB: "a",    // Latin Small Letter A With Ring Above And Acute
+	0x0200: "A",    // Latin Capital Letter A With Double Grave
+	0x0201: "a",    // Latin Small Letter A With Double Grave
+	0x0202: "A",    // Latin Capital Letter A With Inverted Breve
+	0x0203: "a",    // Latin Small Letter A With Inverted Breve
+	0x0204: "E",    // Latin Capital Letter E With Double Grave
+	0x0205: "e",    // Latin Small Letter E With Double Grave
+	0x0206: "E",    // Latin Capital Letter E With Inverted Breve
+	0x0207: "e",    // Latin Small Letter E With Inverted Breve
+	0x0208: "I",    // Latin Capital Letter I With Double Grave
+	0x0209: "i",    // Latin Small Letter I With Double Grave
+	0x020A: "I",    // Latin Capital Letter I With Inverted Breve
+	0x020B: "i",    // Latin Small Letter I With Inverted Breve
+	0x020C: "O",    // Latin Capital Letter O With Double Grave
+	0x020D: "o",    // Latin Small Letter O With Double Grave
+	0x020E: "O",    // Latin Capital Letter O With Inverted Breve
+	0x020F: "o",    // Latin Small Letter O With Inverted Breve
+	0x0210: "R",    // Latin Capital Letter R With Double Grave
+	0x0211: "r",    // Latin Small Letter R With Double Grave
+	0x0212: "R",    // Latin Capital Letter R With Inverted Breve
+	0x0213: "r",    // Latin Small Letter R With Inverted Breve
+	0x0214: "U",    // Latin Capital Letter U With Double Grave
+	0x0215: "u",    // Latin Small Letter U With Double Grave
+	0x0216: "U",    // Latin Capital Letter U With Inverted Breve
+	0x0217: "u",    // Latin Small Letter U With Inverted Breve
+	0x0218: "S",    // Latin Capital Letter S With Comma Below
+	0x0219: "s",    // Latin Small Letter S With Comma Below
+	0x021A: "T",    // Latin Capital Letter T With Comma Below
+	0x021B: "t",    // Latin Small Letter T With Comma Below
+	0x021C: "Y",    // Latin Capital Letter Yogh
+	0x021D: "y",    // Latin Small Letter Yogh
+	0x021E: "H",    // Latin Capital Letter H With Caron
+	0x021F: "h",    // Latin Small Letter H With Caron
+	0x0224: "Z",    // Latin Capital Letter Z With Hook
+	0x0225: "z",    // Latin Small Letter Z With Hook
+	0x0226: "A",    // Latin Capital Letter A With Dot Above
+	0x0227: "a",    // Latin Small Letter A With Dot Above
+	0x0228: "E",    // Latin Capital Letter E With Cedilla
+	0x0229: "e",    // Latin Small Letter E With Cedilla
+	0x022A: "O",    // Latin Capital Letter O With Diaeresis And Macron
+	0x022B: "o",    // Latin Small Letter O With Diaeresis And Macron
+	0x022C: "O",    // Latin Capital Letter O With Tilde And Macron
+	0x022D: "o",    // Latin Small Letter O With Tilde And Macron
+	0x022E: "O",    // Latin Capital Letter O With Dot Above
+	0x022F: "o",    // Latin Small Letter O With Dot Above
+	0x0230: "O",    // Latin Capital Letter O With Dot Above And Macron
+	0x0231: "o",    // Latin Small Letter O With Dot Above And Macron
+	0x0232: "Y",    // Latin Capital Letter Y With Macron
+	0x0233: "y",    // Latin Small Letter Y With Macron
+	0x023A: "A",    // Latin Capital Letter A With Stroke
+	0x023B: "C",    // Latin Capital Letter C With Stroke
+	0x023C: "c",    // Latin Small Letter C With Stroke
+	0x023D: "L",    // Latin Capital Letter L With Bar
+	0x023E: "T",    // Latin Capital Letter T With Diagonal Stroke
+	0x0243: "B",    // Latin Capital Letter B With Stroke
+	0x0246: "E",    // Latin Capital Letter E With Stroke
+	0x0247: "e",    // Latin Small Letter E With Stroke
+	0x0248: "J",    // Latin Capital Letter J With Stroke
+	0x0249: "j",    // Latin Small Letter J With Stroke
+	0x024C: "R",    // Latin Capital Letter R With Stroke
+	0x024D: "r",    // Latin Small Letter R With Stroke
+	0x024E: "Y",    // Latin Capital Letter Y With Stroke
+	0x024F: "y",    // Latin Small Letter Y With Stroke
+	0x0386: "A",    // Greek Capital Letter Alpha With Tonos
+	0x0388: "E",    // Greek Capital Letter Epsilon With Tonos
+	0x0389: "I",    // Greek Capital Letter Eta With Tonos
+	0x038A: "I",    // Greek Capital Letter Iota With Tonos
+	0x038C: "O",    // Greek Capital Letter Omicron With Tonos
+	0x038E: "Y",    // Greek Capital Letter Upsilon With Tonos
+	0x038F: "O",    // Greek Capital Letter Omega With Tonos
+	0x0390: "i",    // Greek Small Letter Iota With Dialytika And Tonos
+	0x0391: "A",    // Greek Capital Letter Alpha
+	0x0392: "V",    // Greek Capital Letter Beta
+	0x0393: "G",    // Greek Capital Letter Gamma
+	0x0394: "D",    // Greek Capital Letter Delta
+	0x0395: "E",    // Greek Capital Letter Epsilon
+	0x0396: "Z",    // Greek Capital Letter Zeta
+	0x0397: "I",    // Greek Capital Letter Eta
+	0x0398: "Th",   // Greek Capital Letter Theta
+	0x0399: "I",    // Greek Capital Letter Iota
+	0x039A: "K",    // Greek Capital Letter Kappa
+	0x039B: "L",    // Greek Capital Letter Lamda
+	0x039C: "M",    // Greek Capital Letter Mu
+	0x039D: "N",    // Greek Capital Letter Nu
+	0x039E: "X",    // Greek Capital Letter Xi
+	0x039F: "O",    // Greek Capital Letter Omicron
+	0x03A0: "P",    // Greek Capital Letter Pi
+	0x03A1: "R",    // Greek Capital Letter Rho
+	0x03A3: "S",    // Greek Capital Letter Sigma
+	0x03A4: "T",    // Greek Capital Letter Tau
+	0x03A5: "Y",    // Greek Capital Letter Upsilon
+	0x03A6: "F",    // Greek Capital Letter Phi
+	0x03A7: "Ch",   // Greek Capital Letter Chi
+	0x03A8: "Ps",   // Greek Capital Letter Psi
+	0x03A9: "O",    // Greek Capital Letter Omega
+	0x03AA: "I",    // Greek Capital Letter Iota With Dialytika
+	0x03AB: "Y",    // Greek Capital Letter Upsilon With Dialytika
+	0x03AC: "a",    // Greek Small Letter Alpha With Tonos
+	0x03AD: "e",    // Greek Small Letter Epsilon With Tonos
+	0x03AE: "i",    // Greek Small Letter Eta With Tonos
+	0x03AF: "i",    // Greek Small Letter Iota With Tonos
+	0x03B0: "y",    // Greek Small Letter Upsilon With Dialytika And Tonos
+	0x03B1: "a",    // Greek Small Letter Alpha
+	0x03B2: "v",    // Greek Small Letter Beta
+	0x03B3: "g",    // Greek Small Letter Gamma
+	0x03B4: "d",    // Greek Small Letter Delta
+	0x03B5: "e",    // Greek Small Letter Epsilon
+	0x03B6: "z",    // Greek Small Letter Zeta
+	0x03B7: "i",    // Greek Small Letter Eta
+	0x03B8: "th",   // Greek Small Letter Theta
+	0x03B9: "i",    // Greek Small Letter Iota
+	0x03BA: "k",    // Greek Small Letter Kappa
+	0x03BB: "l",    // Greek Small Letter Lamda
+	0x03BC: "m",    // Greek Small Letter Mu
+	0x03BD: "n",    // Greek Small Letter Nu
+	0x03BE: "x",    // Greek Small Letter Xi
+	0x03BF: "o",    // Greek Small Letter Omicron
+	0x03C0: "p",    // Greek Small Letter Pi
+	0x03C1: "r",    // Greek Small Letter Rho
+	0x03C2: "s",    // Greek Small Letter Final Sigma
+	0x03C3: "s",    // Greek Small Letter Sigma
+	0x03C4: "t",    // Greek Small Letter Tau
+	0x03C5: "y",    // Greek Small Letter Upsilon
+	0x03C6: "f",    // Greek Small Letter Phi
+	0x03C7: "ch",   // Greek Small Letter Chi
+	0x03C8: "ps",   // Greek Small Letter Psi
+	0x03C9: "o",    // Greek Small Letter Omega
+	0x03CA: "i",    // Greek Small Letter Iota With Dialytika
+	0x03CB: "y",    // Greek Small Letter Upsilon With Dialytika
+	0x03CC: "o",    // Greek Small Letter Omicron With Tonos
+	0x03CD: "y",    // Greek Small Letter Upsilon With Tonos
+	0x03CE: "o",    // Greek Small Letter Omega With Tonos
+	0x0401: "E",    // Cyrillic Capital Letter Io
+	0x0402: "Dj",   // Cyrillic Capital Letter Dje
+	0x0404: "Ye",   // Cyrillic Capital Letter Ukrainian Ie
+	0x0405: "Dz",   // Cyrillic Capital Letter Dze
+	0x0406: "I",    // Cyrillic Capital Letter Byelorussian-Ukrainian I
+	0x0407: "Yi",   // Cyrillic Capital Letter Yi
+	0x0408: "J",    // Cyrillic Capital Letter Je
+	0x0409: "Lj",   // Cyrillic Capital Letter Lje
+	0x040A: "Nj",   // Cyrillic Capital Letter Nje
+	0x040B: "C",    // Cyrillic Capital Letter Tshe
+	0x040E: "U",    // Cyrillic Capital Letter Short U
+	0x040F: "Dz",   // Cyrillic Capital Letter Dzhe
+	0x0410: "A",    // Cyrillic Capital Letter A
+	0x0411: "B",    // Cyrillic Capital Letter Be
+	0x0412: "V",    // Cyrillic Capital Letter Ve
+	0x0413: "G",    // Cyrillic Capital Letter Ghe
+	0x0414: "D",    // Cyrillic Capital Letter De
+	0x0415: "E",    // Cyrillic Capital Letter Ie
+	0x0416: "Zh",   // Cyrillic Capital Letter Zhe
+	0x0417: "Z",    // Cyrillic Capital Letter Ze
+	0x0418: "I",    // Cyrillic Capital Letter I
+	0x0419: "I",    // Cyrillic Capital Letter Short I
+	0x041A: "K",    // Cyrillic Capital Letter Ka
+	0x041B: "L",    // Cyrillic Capital Letter El
+	0x041C: "M",    // Cyrillic Capital Letter Em
+	0x041D: "N",    // Cyrillic Capital Letter En
+	0x041E: "O",    // Cyrillic Capital Letter O
+	0x041F: "P",    // Cyrillic Capital Letter Pe
+	0x0420: "R",    // Cyrillic Capital Letter Er
+	0x0421: "S",    // Cyrillic Capital Letter Es
+	0x0422: "T",    // Cyrillic Capital Letter Te
+	0x0423: "U",    // Cyrillic Capital Letter U
+	0x0424: "F",    // Cyrillic Capital Letter Ef
+	0x0425: "Kh",   // Cyrillic Capital Letter Ha
+	0x0426: "Ts",   // Cyrillic Capital Letter Tse
+	0x0427: "Ch",   // Cyrillic Capital Letter Che
+	0x0428: "Sh",   // Cyrillic Capital Letter Sha
+	0x0429: "Shch", // Cyrillic Capital Letter Shcha
+	0x042A: "",     // Cyrillic Capital Letter Hard Sign
+	0x042B: "Y",    // Cyrillic Capital Letter Yeru
+	0x042C: "",     // Cyrillic Capital Letter Soft Sign
+	0x042D: "E",    // Cyrillic Capital Letter E
+	0x042E: "Iu",   // Cyrillic Capital Letter Yu
+	0x042F: "Ia",   // Cyrillic Capital Letter Ya
+	0x0430: "a",    // Cyrillic Small Letter A
+	0x0431: "b",    // Cyrillic Small Letter Be
+	0x0432: "v",    // Cyrillic Small Letter Ve
+	0x0433: "g",    // Cyrillic Small Letter Ghe
+	0x0434: "d",    // Cyrillic Small Letter De
+	0x0435: "e",    // Cyrillic Small Letter Ie
+	0x0436: "zh",   // Cyrillic Small Letter Zhe
+	0x0437: "z",    // Cyrillic Small Letter Ze
+	0x0438: "i",    // Cyrillic Small Letter I
+	0x0439: "i",    // Cyrillic Small Letter Short I
+	0x043A: "k",    // Cyrillic Small Letter Ka
+	0x043B: "l",    // Cyrillic Small Letter El
+	0x043C: "m",    // Cyrillic Small Letter Em
+	0x043D: "n",    // Cyrillic Small Letter En
+	0x043E: "o",    // Cyrillic Small Letter O
+	0x043F: "p",    // Cyrillic Small Letter Pe
+	0x0440: "r",    // Cyrillic Small Letter Er
+	0x0441: "s",    // Cyrillic Small Letter Es
+	0x0442: "t",    // Cyrillic Small Letter Te
+	0x0443: "u",    // Cyrillic Small Letter U
+	0x0444: "f",    // Cyrillic Small Letter Ef
+	0x0445: "kh",   // Cyrillic Small Letter Ha
+	0x0446: "ts",   // Cyrillic Small Letter Tse
+	0x0447: "ch",   // Cyrillic Small Letter Che
+	0x0448: "sh",   // Cyrillic Small Letter Sha
+	0x0449: "shch", // Cyrillic Small Letter Shcha
+	0x044A: "",     // Cyrillic Small Letter Hard Sign
+	0x044B: "y",    // Cyrillic Small Letter Yeru
+	0x044C: "",     // Cyrillic Small Letter Soft Sign
+	0x044D: "e",    // Cyrillic Small Letter E
+	0x044E: "iu",   // Cyrillic Small Letter Yu
+	0x044F: "ia",   // Cyrillic Small Letter Ya
+	0x0451: "e",    // Cyrillic Small Letter Io
+	0x0452: "dj",   // Cyrillic Small Letter Dje
+	0x0454: "ye",   // Cyrillic Small Letter Ukrainian Ie
+	0x0455: "dz",   // Cyrillic Small Letter Dze
+	0x0456: "i",    // Cyrillic Small Letter Byelorussian-Ukrainian I
+	0x0457: "yi",   // Cyrillic Small Letter Yi
+	0x0458: "j",    // Cyrillic Small Letter Je
+	0x0459: "lj",   // Cyrillic Small Letter Lje
+	0x045A: "nj",   // Cyrillic Small Letter Nje
+	0x045B: "c",    // Cyrillic Small Letter Tshe
+	0x045E: "u",    // Cyrillic Small Letter Short U
+	0x045F: "dz",   // Cyrillic Small Letter Dzhe
+	0x0490: "G",    // Cyrillic Capital Letter Ghe With Upturn
+	0x0491: "g",    // Cyrillic Small Letter Ghe With Upturn
+	0x1E00: "A",    // Latin Capital Letter A With Ring Below
+	0x1E01: "a",    // Latin Small Letter A With Ring Below
+	0x1E02: "B",    // Latin Capital Letter B With Dot Above
+	0x1E03: "b",    // Latin Small Letter B With Dot Above
+	0x1E04: "B",    // Latin Capital Letter B With Dot Below
+	0x1E05: "b",    // Latin Small Letter B With Dot Below
+	0x1E06: "B",    // Latin Capital Letter B With Line Below
+	0x1E07: "b",    // Latin Small Letter B With Line Below
+	0x1E08: "C",    // Latin Capital Letter C With Cedilla And Acute
+	0x1E09: "c",    // Latin Small Letter C With Cedilla And Acute
+	0x1E0A: "D",    // Latin Capital Letter D With Dot Above
+	0x1E0B: "d",    // Latin Small Letter D With Dot Above
+	0x1E0C: "D",    // Latin Capital Letter D With Dot Below
+	0x1E0D: "d",    // Latin Small Letter D With Dot Below
+	0x1E0E: "D",    // Latin Capital Letter D With Line Below
+	0x1E0F: "d",    // Latin Small Letter D With Line Below
+	0x1E10: "D",    // Latin Capital Letter D With Cedilla
+	0x1E11: "d",    // Latin Small Letter D With Cedilla
+	0x1E12: "D",    // Latin Capital Letter D With Circumflex Below
+	0x1E13: "d",    // Latin Small Letter D With Circumflex Below
+	0x1E14: "E",    // Latin Capital Letter E With Macron And Grave
+	0x1E15: "e",    // Latin Small Letter E With Macron And Grave
+	0x1E16: "E",    // Latin Capital Letter E With Macron And Acute
+	0x1E17: "e",    // Latin Small Letter E With Macron And Acute
+	0x1E18: "E",    // Latin Capital Letter E With Circumflex Below
+	0x1E19: "e",    // Latin Small Letter E With Circumflex Below
+	0x1E1A: "E",    // Latin Capital Letter E With Tilde Below
+	0x1E1B: "e",    // Latin Small Letter E With Tilde Below
+	0x1E1C: "E",    // Latin Capital Letter E With Cedilla And Breve
+	0x1E1D: "e",    // Latin Small Letter E With Cedilla And Breve
+	0x1E1E: "F",    // Latin Capital Letter F With Dot Above
+	0x1E1F: "f",    // Latin Small Letter F With Dot Above
+	0x1E20: "G",    // Latin Capital Letter G With Macron
+	0x1E21: "g",    // Latin Small Letter G With Macron
+	0x1E22: "H",    // Latin Capital Letter H With Dot Above
+	0x1E23: "h",    // Latin Small Letter H With Dot Above
+	0x1E24: "H",    // Latin Capital Letter H With Dot Below
+	0x1E25: "h",    // Latin Small Letter H With Dot Below
+	0x1E26: "H",    // Latin Capital Letter H With Diaeresis
+	0x1E27: "h",    // Latin Small Letter H With Diaeresis
+	0x1E28: "H",    // Latin Capital Letter H With Cedilla
+	0x1E29: "h",    // Latin Small Letter H With Cedilla
+	0x1E2A: "H",    // Latin Capital Letter H With Breve Below
+	0x1E2B: "h",    // Latin Small Letter H With Breve Below
+	0x1E2C: "I",    // Latin Capital Letter I With Tilde Below
+	0x1E2D: "i",    // Latin Small Letter I With Tilde Below
+	0x1E2E: "I",    // Latin Capital Letter I With Diaeresis And Acute
+	0x1E2F: "i",    // Latin Small Letter I With Diaeresis And Acute
+	0x1E30: "K",    // Latin Capital Letter K With Acute
+	0x1E31: "k",    // Latin Small Letter K With Acute
+	0x1E32: "K",    // Latin Capital Letter K With Dot Below
+	0x1E33: "k",    // Latin Small Letter K With Dot Below
+	0x1E34: "K",    // Latin Capital Letter K With Line Below
+	0x1E35: "k",    // Latin Small Letter K With Line Below
+	0x1E36: "L",    // Latin Capital Letter L With Dot Below
+	0x1E37: "l",    // Latin Small Letter L With Dot Below
+	0x1E38: "L",    // Latin Capital Letter L With Dot Below And Macron
+	0x1E39: "l",    // Latin Small Letter L With Dot Below And Macron
+	0x1E3A: "L",    // Latin Capital Letter L With Line Below
+	0x1E3B: "l",    // Latin Small Letter L With Line Below
+	0x1E3C: "L",    // Latin Capital Letter L With Circumflex Below
+	0x1E3D: "l",    // Latin Small Letter L With Circumflex Below
+	0x1E3E: "M",    // Latin Capital Letter M With Acute
+	0x1E3F: "m",    // Latin Small Letter M With Acute
+	0x1E40: "M",    // Latin Capital Letter M With Dot Above
+	0x1E41: "m",    // Latin Small Letter M With Dot Above
+	0x1E42: "M",    // Latin Capital Letter M With Dot Below
+	0x1E43: "m",    // Latin Small Letter M With Dot Below
+	0x1E44: "N",    // Latin Capital Letter N With Dot Above
+	0x1E45: "n",    // Latin Small Letter N With Dot Above
+	0x1E46: "N",    // Latin Capital Letter N With Dot Below
+	0x1E47: "n",    // Latin Small Letter N With Dot Below
+	0x1E48: "N",    // Latin Capital Letter N With Line Below
+	0x1E49: "n",    // Latin Small Letter N With Line Below
+	0x1E4A: "N",    // Latin Capital Letter N With Circumflex Below
+	0x1E4B: "n",    // Latin Small Letter N With Circumflex Below
+	0x1E4C: "O",    // Latin Capital Letter O With Tilde And Acute
+	0x1E4D: "o",    // Latin Small Letter O With Tilde And Acute
+	0x1E4E: "O",    // Latin Capital Letter O With Tilde And Diaeresis
+	0x1E4F: "o",    // Latin Small Letter O With Tilde And Diaeresis
+	0x1E50: "O",    // Latin Capital Letter O With Macron And Grave
+	0x1E51: "o",    // Latin Small Letter O With Macron And Grave
+	0x1E52: "O",    // Latin Capital Letter O With Macron And Acute
+	0x1E53: "o",    // Latin Small Letter O With Macron And Acute
+	0x1E54: "P",    // Latin Capital Letter P With Acute
+	0x1E55: "p",    // Latin Small Letter P With Acute
+	0x1E56: "P",    // Latin Capital Letter P With Dot Above
+	0x1E57: "p",    // Latin Small Letter P With Dot Above
+	0x1E58: "R",    // Latin Capital Letter R With Dot Above
+	0x1E59: "r",    // Latin Small Letter R With Dot Above
+	0x1E5A: "R",    // Latin Capital Letter R With Dot Below
+	0x1E5B: "r",    // Latin Small Letter R With Dot Below
+	0x1E5C: "R",    // Latin Capital Letter R With Dot Below And Macron
+	0x1E5D: "r",    // Latin Small Letter R With Dot Below And Macron
+	0x1E5E: "R",    // Latin Capital Letter R With Line Below
+	0x1E5F: "r",    // Latin Small Letter R With Line Below
+	0x1E60: "S",    // Latin Capital Letter S With Dot Above
+	0x1E61: "s",    // Latin Small Letter S With Dot Above
+	0x1E62: "S",    // Latin Capital Letter S With Dot Below
+	0x1E63: "s",    // Latin Small Letter S With Dot Below
+	0x1E64: "S",    // Latin Capital Letter S With Acute And Dot Above
+	0x1E65: "s",    // Latin Small Letter S With Acute And Dot Above
+	0x1E66: "S",    // Latin Capital Letter S With Caron And Dot Above
+	0x1E67: "s",    // Latin Small Letter S With Caron And Dot Above
+	0x1E68: "S",    // Latin Capital Letter S With Dot Below And Dot Above
+	0x1E69: "s",    // Latin Small Letter S With Dot Below And Dot Above
+	0x1E6A: "T",    // Latin Capital Letter T With Dot Above
+	0x1E6B: "t",    // Latin Small Letter T With Dot Above
+	0x1E6C: "T",    // Latin Capital Letter T With Dot Below
+	0x1E6D: "t",    // Latin Small Letter T With Dot Below
+	0x1E6E: "T",    // Latin Capital Letter T With Line Below
+	0x1E6F: "t",    // Latin Small Letter T With Line Below
+	0x1E70: "T",    // Latin Capital Letter T With Circumflex Below
+	0x1E71: "t",    // Latin Small Letter T With Circumflex Below
+	0x1E72: "U",    // Latin Capital Letter U With Diaeresis Below
+	0x1E73: "u",    // Latin Small Letter U With Diaeresis Below
+	0x1E74: "U",    // Latin Capital Letter U With Tilde Below
+	0x1E75: "u",    // Latin Small Letter U With Tilde Below
+	0x1E76: "U",    // Latin Capital Letter U With Circumflex Below
+	0x1E77: "u",    // Latin Small Letter U With Circumflex Below
+	0x1E78: "U",    // Latin Capital Letter U With Tilde And Acute
+	0x1E79: "u",    // Latin Small Letter U With Tilde And Acute
+	0x1E7A: "U",    // Latin Capital Letter U With Macron And Diaeresis
+	0x1E7B: "u",    // Latin Small Letter U With Macron And Diaeresis
+	0x1E7C: "V",    // Latin Capital Letter V With Tilde
+	0x1E7D: "v",    // Latin Small Letter V With Tilde
+	0x1E7E: "V",    // Latin Capital Letter V With Dot Below
+	0x1E7F: "v",    // Latin Small Letter V With Dot Below
+	0x1E80: "W",    // Latin Capital Letter W With Grave
+	0x1E81: "w",    // Latin Small Letter W With Grave
+	0x1E82: "W",    // Latin Capital Letter W With Acute
+	0x1E83: "w",    // Latin Small Letter W With Acute
+	0x1E84: "W",    // Latin Capital Letter W With Diaeresis
+	0x1E85: "w",    // Latin Small Letter W With Diaeresis
+	0x1E86: "W",    // Latin Capital Letter W With Dot Above
+	0x1E87: "w",    // Latin Small Letter W With Dot Above
+	0x1E88: "W",    // Latin Capital Letter W With Dot Below
+	0x1E89: "w",    // Latin Small Letter W With Dot Below
+	0x1E8A: "X",    // Latin Capital Letter X With Dot Above
+	0x1E8B: "x",    // Latin Small Letter X With Dot Above
+	0x1E8C: "X",    // Latin Capital Letter X With Diaeresis
+	0x1E8D: "x",    // Latin Small Letter X With Diaeresis
+	0x1E8E: "Y",    // Latin Capital Letter Y With Dot Above
+	0x1E8F: "y",    // Latin Small Letter Y With Dot Above
+	0x1E90: "Z",    // Latin Capital Letter Z With Circumflex
+	0x1E91: "z",    // Latin Small Letter Z With Circumflex
+	0x1E92: "Z",    // Latin Capital Letter Z With Dot Below
+	0x1E93: "z",    // Latin Small Letter Z With Dot Below
+	0x1E94: "Z",    // Latin Capital Letter Z With Line Below
+	0x1E95: "z",    // Latin Small Letter Z With Line Below
+	0x1E96: "h",    // Latin Small Letter H With Line Below
+	0x1E97: "t",    // Latin Small Letter T With Diaeresis
+	0x1E98: "w",    // Latin Small Letter W With Ring Above
+	0x1E99: "y",    // Latin Small Letter Y With Ring Above
+	0x1E9B: "s",    // Latin Small Letter Long S With Dot Above
+	0x1E9E: "SS",   // Latin Capital Letter Sharp S
+	0x1EA0: "A",    // Latin Capital Letter A With Dot Below
+	0x1EA1: "a",    // Latin Small Letter A With Dot Below
+	0x1EA2: "A",    // Latin Capital Letter A With Hook Above
+	0x1EA3: "a",    // Latin Small Letter A With Hook Above
+	0x1EA4: "A",    // Latin Capital Letter A With Circumflex And Acute
+	0x1EA5: "a",    // Latin Small Letter A With Circumflex And Acute
+	0x1EA6: "A",    // Latin Capital Letter A With Circumflex And Grave
+	0x1EA7: "a",    // Latin Small Letter A With Circumflex And Grave
+	0x1EA8: "A",    // Latin Capital Letter A With Circumflex And Hook Above
+	0x1EA9: "a",    // Latin Small Letter A With Circumflex And Hook Above
+	0x1EAA: "A",    // Latin Capital Letter A With Circumflex And Tilde
+	0x1EAB: "a",    // Latin Small Letter A With Circumflex And Tilde
+	0x1EAC: "A",    // Latin Capital Letter A With Circumflex And Dot Below
+	0x1EAD: "a",    // Latin Small Letter A With Circumflex And Dot Below
+	0x1EAE: "A",    // Latin Capital Letter A With Breve And Acute
+	0x1EAF: "a",    // Latin Small Letter A With Breve And Acute
+	0x1EB0: "A",    // Latin Capital Letter A With Breve And Grave
+	0x1EB1: "a",    // Latin Small Letter A With Breve And Grave
+	0x1EB2: "A",    // Latin Capital Letter A With Breve And Hook Above
+	0x1EB3: "a",    // Latin Small Letter A With Breve And Hook Above
+	0x1EB4: "A",    // Latin Capital Letter A With Breve And Tilde
+	0x1EB5: "a",    // Latin Small Letter A With Breve And Tilde
+	0x1EB6: "A",    // Latin Capital Letter A With Breve And Dot Below
+	0x1EB7: "a",    // Latin Small Letter A With Breve And Dot Below
+	0x1EB8: "E",    // Latin Capital Letter E With Dot Below
+	0x1EB9: "e",    // Latin Small Letter E With Dot Below
+	0x1EBA: "E",    // Latin Capital Letter E With Hook Above
+	0x1EBB: "e",    // Latin Small Letter E With Hook Above
+	0x1EBC: "E",    // Latin Capital Letter E With Tilde
+	0x1EBD: "e",    // Latin Small Letter E With Tilde
+	0x1EBE: "E",    // Latin Capital Letter E With Circumflex And Acute
+	0x1EBF: "e",    // Latin Small Letter E With Circumflex And Acute
+	0x1EC0: "E",    // Latin Capital Letter E With Circumflex And Grave
+	0x1EC1: "e",    // Latin Small Letter E With Circumflex And Grave
+	0x1EC2: "E",    // Latin Capital Letter E With Circumflex And Hook Above
+	0x1EC3: "e",    // Latin Small Letter E With Circumflex And Hook Above
+	0x1EC4: "E",    // Latin Capital Letter E With Circumflex And Tilde
+	0x1EC5: "e",    // Latin Small Letter E With Circumflex And Tilde
+	0x1EC6: "E",    // Latin Capital Letter E With Circumflex And Dot Below
+	0x1EC7: "e",    // Latin Small Letter E With Circumflex And Dot Below
+	0x1EC8: "I",    // Latin Capital Letter I With Hook Above
+	0x1EC9: "i",    // Latin Small Letter I With Hook Above
+	0x1ECA: "I",    // Latin Capital Letter I With Dot Below
+	0x1ECB: "i",    // Latin Small Letter I With Dot Below
+	0x1ECC: "O",    // Latin Capital Letter O With Dot Below
+	0x1ECD: "o",    // Latin Small Letter O With Dot Below
+	0x1ECE: "O",    // Latin Capital Letter O With Hook Above
+	0x1ECF: "o",    // Latin Small Letter O With Hook Above
+	0x1ED0: "O",    // Latin Capital Letter O With Circumflex And Acute
+	0x1ED1: "o",    // Latin Small Letter O With Circumflex And Acute
+	0x1ED2: "O",    // Latin Capital Letter O With Circumflex And Grave
+	0x1ED3: "o",    // Latin Small Letter O With Circumflex And Grave
+	0x1ED4: "O",    // Latin Capital Letter O With Circumflex And Hook Above
+	0x1ED5: "o",    // Latin Small Letter O With Circumflex And Hook Above
+	0x1ED6: "O",    // Latin Capital Letter O With Circumflex And Tilde
+	0x1ED7: "o",    // Latin Small Letter O With Circumflex And Tilde
+	0x1ED8: "O",    // Latin Capital Letter O With Circumflex And Dot Below
+	0x1ED9: "o",    // Latin Small Letter O With Circumflex And Dot Below
+	0x1EDA: "O",    // Latin Capital Letter O With Horn And Acute
+	0x1EDB: "o",    // Latin Small Letter O With Horn And Acute
+	0x1EDC: "O",    // Latin Capital Letter O With Horn And Grave
+	0x1EDD: "o",    // Latin Small Letter O With Horn And Grave
+	0x1EDE: "O",    // Latin Capital Letter O With Horn And Hook Above
+	0x1EDF: "o",    // Latin Small Letter O With Horn And Hook Above
+	0x1EE0: "O",    // Latin Capital Letter O With Horn And Tilde
+	0x1EE1: "o",    // Latin Small Letter O With Horn And Tilde
+	0x1EE2: "O",    // Latin Capital Letter O With Horn And Dot Below
+	0x1EE3: "o",    // Latin Small Letter O With Horn And Dot Below
+	0x1EE4: "U",    // Latin Capital Letter U With Dot Below
+	0x1EE5: "u",    // Latin Small Letter U With Dot Below
+	0x1EE6: "U",    // Latin Capital Letter U With Hook Above
+	0x1EE7: "u",    // Latin Small Letter U With Hook Above
+	0x1EE8: "U",    // Latin Capital Letter U With Horn And Acute
+	0x1EE9: "u",    // Latin Small Letter U With Horn And Acute
+	0x1EEA: "U",    // Latin Capital Letter U With Horn And Grave
+	0x1EEB: "u",    // Latin Small Letter U With Horn And Grave
+	0x1EEC: "U",    // Latin Capital Letter U With Horn And Hook Above
+	0x1EED: "u",    // Latin Small Letter U With Horn And Hook Above
+	0x1EEE: "U",    // Latin Capital Letter U With Horn And Tilde
+	0x1EEF: "u",    // Latin Small Letter U With Horn And Tilde
+	0x1EF0: "U",    // Latin Capital Letter U With Horn And Dot Below
+	0x1EF1: "u",    // Latin Small Letter U With Horn And Dot Below
+	0x1EF2: "Y",    // Latin Capital Letter Y With Grave
+	0x1EF3: "y",    // Latin Small Letter Y With Grave
+	0x1EF4: "Y",    // Latin Capital Letter Y With Dot Below
+	0x1EF5: "y",    // Latin Small Letter Y With Dot Below
+	0x1EF6: "Y",    // Latin Capital Letter Y With Hook Above
+	0x1EF7: "y",    // Latin Small Letter Y With Hook Above
+	0x1EF8: "Y",    // Latin Capital Letter Y With Tilde
+	0x1EF9: "y",    // Latin Small Letter Y With Tilde
+	0x2002: " ",    // En Space
+	0x2003: " ",    // Em Space
+	0x2009: " ",    // Thin Space
+	0x2010: "-",    // Hyphen
+	0x2011: "-",    // Non-Breaking Hyphen
+	0x2012: "-",    // Figure Dash
+	0x2013: "-",    // En Dash
+	0x2014: "-",    // Em Dash
+	0x2018: "'",    // Left Single Quotation Mark
+	0x2019: "'",    // Right Single Quotation Mark
+	0x201A: "'",    // Single Low-9 Quotation Mark
+	0x201C: "\"",   // Left Double Quotation Mark
+	0x201D: "\"",   // Right Double Quotation Mark
+	0x201E: "\"",   // Double Low-9 Quotation Mark
+	0x2026: "...",  // Horizontal Ellipsis
+	0x2039: "<",    // Single Left-Pointing Angle Quotation Mark
+	0x203A: ">",    // Single Right-Pointing Angle Quotation Mark
+}
@@ -0,0 +1,78 @@
+package stringx
+
+import "testing"
+
+func Test_Transliterate(t *testing.T) {
+	tests := []struct {
+		str, locale, expected string
+	}{
+		{"Ærøskøbing", "", "AEroskobing"},
+		{"Crème brûlée", "", "Creme brulee"},
+		{"Łódź", "", "Lodz"},
+		{"Straße", "", "Strasse"},
+		{"Café", "", "Cafe"},
+		{"Москва", "", "Moskva"},
+		{"Щука и ёж", "", "Shchuka i ezh"},
+		{"Αθήνα", "", "Athina"},
+		{"“quoted” – text…", "", "\"quoted\" - text..."},
+		{"日本", "", "??"},
+		{"plain ascii", "", "plain ascii"},
+		{"Jürgen Müller", "de", "Juergen Mueller"},
+		{"Jürgen Müller", "de-AT", "Juergen Mueller"},
+		{"Jürgen Müller", "de_CH", "Juergen Mueller"},
+		{"Jürgen Müller", "fr", "Jurgen Muller"},
+		{"Blåbær", "da", "Blaabaer"},
+		{"Blåbær", "sv", "Blabaer"},
+		{"Харків", "uk", "Kharkiv"},
+	}
+	for _, test := range tests {
+		if got := Transliterate(test.str, test.locale); got != test.expected {
+			t.Errorf("expected Transliterate(%q, %q) to return %q but got %q", test.str, test.locale, test.expected, got)
+		}
+	}
+	if got := Transliterate("Jürgen"); got != "Jurgen" {
+		t.Errorf("expected Transliterate(\"Jürgen\") to return \"Jurgen\" but got %q", got)
+	}
+
+	RegisterTransliterations("es", map[rune]string{'ñ': "ny"})
+	RegisterTransliterations("es-ES", map[rune]string{'Ñ': "Ny"})
+	if got := Transliterate("España ÑU", "es"); got != "Espanya NyU" {
+		t.Errorf("expected registered transliterations to apply but got %q", got)
+	}
+	if got := Transliterate("España"); got != "Espana" {
+		t.Errorf("expected the defaults to be unaffected but got %q", got)
+	}
+}
+
+func Test_Parameterize(t *testing.T) {
+	tests := []struct {
+		str      string
+		options  ParameterizeOptions
+		expected string
+	}{
+		{"Donald E. Knuth", ParameterizeOptions{}, "donald-e-knuth"},
+		{"^très|Jolie-- ", ParameterizeOptions{}, "tres-jolie"},
+		{"Random text with *(bad)* characters", ParameterizeOptions{}, "random-text-with-bad-characters"},
+		{"Allow_Under_Scores", ParameterizeOptions{}, "allow_under_scores"},
+		{"日本 text", ParameterizeOptions{}, "text"},
+		{"Donald E. Knuth", ParameterizeOptions{Separator: "_"}, "donald_e_knuth"},
+		{"Trailing bad characters!@#", ParameterizeOptions{Separator: "__sep__"}, "trailing__sep__bad__sep__characters"},
+		{"Donald E. Knuth", ParameterizeOptions{PreserveCase: true}, "Donald-E-Knuth"},
+		{"Über die Brücke", ParameterizeOptions{}, "uber-die-brucke"},
+		{"Über die Brücke", ParameterizeOptions{Locale: "de"}, "ueber-die-bruecke"},
+		{"Once upon a time in a world", ParameterizeOptions{MaxLength: 20}, "once-upon-a-time-in"},
+		{"Once upon a time in a world", ParameterizeOptions{MaxLength: 19}, "once-upon-a-time-in"},
+		{"Once upon a time in a world", ParameterizeOptions{MaxLength: 18}, "once-upon-a-time"},
+		{"Once upon a time", ParameterizeOptions{MaxLength: 100}, "once-upon-a-time"},
+		{"Supercalifragilistic", ParameterizeOptions{MaxLength: 5}, "super"},
+		{"a b c d", ParameterizeOptions{Separator: "__", MaxLength: 6}, "a__b"},
+	}
+	for _, test := range tests {
+		if got := Parameterize(test.str, test.options); got != test.expected {
+			t.Errorf("expected Parameterize(%q, %+v) to return %q but got %q", test.str, test.options, test.expected, got)
+		}
+	}
+	if got := Parameterize("Donald E. Knuth"); got != "donald-e-knuth" {
+		t.Errorf("expected Parameterize without options to return \"donald-e-knuth\" but got %q", got)
+	}
+}